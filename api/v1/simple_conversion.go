@@ -0,0 +1,129 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// ConversionDataAnnotation holds the v2 spec of a Simple served as v1 when
+// that spec carries options v1 cannot represent, so they survive a round trip.
+const ConversionDataAnnotation = "demo.demo.local/conversion-data"
+
+// ConvertTo converts this Simple (v1) to the Hub version (v2).
+func (src *Simple) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*demov2.Simple)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	// Start from the v2 spec saved by ConvertFrom, if any
+	var restored demov2.SimpleSpec
+	if data, ok := src.Annotations[ConversionDataAnnotation]; ok {
+		if err := json.Unmarshal([]byte(data), &restored); err != nil {
+			return fmt.Errorf("decoding %s annotation: %w", ConversionDataAnnotation, err)
+		}
+		delete(dst.Annotations, ConversionDataAnnotation)
+		if len(dst.Annotations) == 0 {
+			dst.Annotations = nil
+		}
+	}
+
+	// Fields present in v1 always win over the saved ones
+	dst.Spec = restored
+	dst.Spec.Message = src.Spec.Message
+	dst.Spec.Interval = src.Spec.Interval.DeepCopy()
+	dst.Spec.Messages = nil
+	for i, text := range src.Spec.Messages {
+		message := demov2.MessageSpec{Text: text}
+		if i < len(restored.Messages) && restored.Messages[i].Text == text {
+			message = restored.Messages[i]
+		}
+		dst.Spec.Messages = append(dst.Spec.Messages, message)
+	}
+
+	dst.Status = demov2.SimpleStatus{
+		Replied:            src.Status.Replied,
+		ObservedGeneration: src.Status.ObservedGeneration,
+		Conditions:         src.Status.DeepCopy().Conditions,
+		LastRepliedTime:    src.Status.LastRepliedTime.DeepCopy(),
+		NextReplyTime:      src.Status.NextReplyTime.DeepCopy(),
+	}
+	for _, message := range src.Status.Messages {
+		dst.Status.Messages = append(dst.Status.Messages, demov2.MessageStatus{
+			Message:       message.Message,
+			Delivered:     message.Delivered,
+			DeliveredTime: message.DeliveredTime.DeepCopy(),
+		})
+	}
+
+	return nil
+}
+
+// ConvertFrom converts the Hub version (v2) to this version (v1).
+func (dst *Simple) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*demov2.Simple)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	delete(dst.Annotations, ConversionDataAnnotation)
+
+	dst.Spec = SimpleSpec{
+		Message:  src.Spec.Message,
+		Interval: src.Spec.Interval.DeepCopy(),
+	}
+	for _, message := range src.Spec.Messages {
+		dst.Spec.Messages = append(dst.Spec.Messages, message.Text)
+	}
+
+	// Save the v2 spec if converting back would not reproduce it
+	var roundTrip demov2.Simple
+	if err := dst.ConvertTo(&roundTrip); err != nil {
+		return err
+	}
+	if !equality.Semantic.DeepEqual(roundTrip.Spec, src.Spec) {
+		data, err := json.Marshal(src.Spec)
+		if err != nil {
+			return fmt.Errorf("encoding %s annotation: %w", ConversionDataAnnotation, err)
+		}
+		if dst.Annotations == nil {
+			dst.Annotations = map[string]string{}
+		}
+		dst.Annotations[ConversionDataAnnotation] = string(data)
+	}
+
+	dst.Status = SimpleStatus{
+		Replied:            src.Status.Replied,
+		ObservedGeneration: src.Status.ObservedGeneration,
+		Conditions:         src.Status.DeepCopy().Conditions,
+		LastRepliedTime:    src.Status.LastRepliedTime.DeepCopy(),
+		NextReplyTime:      src.Status.NextReplyTime.DeepCopy(),
+	}
+	for _, message := range src.Status.Messages {
+		dst.Status.Messages = append(dst.Status.Messages, MessageStatus{
+			Message:       message.Message,
+			Delivered:     message.Delivered,
+			DeliveredTime: message.DeliveredTime.DeepCopy(),
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+var _ = Describe("Simple conversion", func() {
+	It("should round trip a v1 Simple through v2", func() {
+		src := &Simple{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+			Spec: SimpleSpec{
+				Message:  "first",
+				Messages: []string{"second", "third"},
+				Interval: &metav1.Duration{Duration: time.Minute},
+			},
+			Status: SimpleStatus{
+				Replied:  true,
+				Messages: []MessageStatus{{Message: "first", Delivered: true}},
+			},
+		}
+
+		hub := &demov2.Simple{}
+		Expect(src.ConvertTo(hub)).To(Succeed())
+		Expect(hub.Spec.Message).To(Equal("first"))
+		Expect(hub.Spec.Messages).To(Equal([]demov2.MessageSpec{{Text: "second"}, {Text: "third"}}))
+		Expect(hub.Status.Replied).To(BeTrue())
+
+		dst := &Simple{}
+		Expect(dst.ConvertFrom(hub)).To(Succeed())
+		Expect(dst).To(Equal(src))
+	})
+
+	It("should preserve v2-only options through v1", func() {
+		hub := &demov2.Simple{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+			Spec: demov2.SimpleSpec{
+				Messages: []demov2.MessageSpec{{Name: "greeting", Text: "hello"}, {Text: "world"}},
+			},
+		}
+
+		spoke := &Simple{}
+		Expect(spoke.ConvertFrom(hub)).To(Succeed())
+		Expect(spoke.Spec.Messages).To(Equal([]string{"hello", "world"}))
+		Expect(spoke.Annotations).To(HaveKey(ConversionDataAnnotation))
+
+		restored := &demov2.Simple{}
+		Expect(spoke.ConvertTo(restored)).To(Succeed())
+		Expect(restored.Spec).To(Equal(hub.Spec))
+		Expect(restored.Annotations).NotTo(HaveKey(ConversionDataAnnotation))
+
+		By("editing the message through v1")
+		spoke.Spec.Messages[0] = "hi"
+		Expect(spoke.ConvertTo(restored)).To(Succeed())
+		Expect(restored.Spec.Messages).To(Equal([]demov2.MessageSpec{{Text: "hi"}, {Text: "world"}}))
+	})
+
+	It("should not annotate Simples that v1 fully represents", func() {
+		hub := &demov2.Simple{
+			Spec: demov2.SimpleSpec{Messages: []demov2.MessageSpec{{Text: "hello"}}},
+		}
+
+		spoke := &Simple{}
+		Expect(spoke.ConvertFrom(hub)).To(Succeed())
+		Expect(spoke.Annotations).To(BeEmpty())
+	})
+})
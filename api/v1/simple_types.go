@@ -25,16 +25,92 @@ import (
 
 // SimpleSpec defines the desired state
 type SimpleSpec struct {
+	// +optional
 	// +kubebuilder:validation:MinLength=1
-	// Message is the string to print
+	// Message is the string to print.
+	// Deprecated: use Messages. When set it is delivered before Messages.
+	Message string `json:"message,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:items:MinLength=1
+	// Messages are the strings to print, in order
+	Messages []string `json:"messages,omitempty"`
+
+	// +optional
+	// Interval makes the controller re-deliver the messages on the given cadence.
+	// When unset the messages are delivered once.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// AllMessages returns the messages to deliver, honoring the deprecated Message field.
+func (s *SimpleSpec) AllMessages() []string {
+	if s.Message == "" {
+		return s.Messages
+	}
+	return append([]string{s.Message}, s.Messages...)
+}
+
+// Condition types reported in SimpleStatus.Conditions
+const (
+	// ConditionReady is True once the Message has been replied
+	ConditionReady = "Ready"
+	// ConditionProgressing is True while the controller is working towards Ready
+	ConditionProgressing = "Progressing"
+	// ConditionDegraded is True when the last reconcile failed
+	ConditionDegraded = "Degraded"
+)
+
+// Condition reasons reported in SimpleStatus.Conditions
+const (
+	// ReasonReconciling means the controller is processing the resource
+	ReasonReconciling = "Reconciling"
+	// ReasonReplied means the Message has been seen and logged
+	ReasonReplied = "Replied"
+	// ReasonReconcileFailed means a reconcile step returned an error
+	ReasonReconcileFailed = "ReconcileFailed"
+)
+
+// MessageStatus records the delivery state of a single message
+type MessageStatus struct {
+	// Message is the delivered string
 	Message string `json:"message"`
+
+	// Delivered indicates that the message has been logged
+	Delivered bool `json:"delivered"`
+
+	// +optional
+	// DeliveredTime is when the message was last delivered
+	DeliveredTime *metav1.Time `json:"deliveredTime,omitempty"`
 }
 
 // SimpleStatus defines the observed state
 type SimpleStatus struct {
 	// +optional
-	// Replied indicates that we’ve seen and logged the Message
+	// Replied indicates that we’ve seen and logged the Message.
+	// Kept for compatibility, prefer the Ready condition.
 	Replied bool `json:"replied,omitempty"`
+
+	// +optional
+	// ObservedGeneration is the most recent metadata.generation the status reflects
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// Conditions represent the latest available observations of the Simple's state
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// +optional
+	// Messages tracks the delivery state of each message in Spec.AllMessages order
+	Messages []MessageStatus `json:"messages,omitempty"`
+
+	// +optional
+	// LastRepliedTime is when the messages were last delivered
+	LastRepliedTime *metav1.Time `json:"lastRepliedTime,omitempty"`
+
+	// +optional
+	// NextReplyTime is when the messages will be delivered again, if Spec.Interval is set
+	NextReplyTime *metav1.Time `json:"nextReplyTime,omitempty"`
 }
 
 // +kubebuilder:object:root=true
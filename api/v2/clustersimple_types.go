@@ -0,0 +1,118 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterSimpleSpec defines the desired state of ClusterSimple
+type ClusterSimpleSpec struct {
+	// +kubebuilder:validation:MinItems=1
+	// Messages are written to the ConfigMap propagated to every selected namespace
+	Messages []MessageSpec `json:"messages"`
+
+	// NamespaceSelector selects the namespaces the ConfigMap is propagated to.
+	// An empty selector selects every namespace.
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
+}
+
+// NamespacePropagationStatus reports the ConfigMap of a selected namespace
+type NamespacePropagationStatus struct {
+	// Namespace is the selected namespace
+	Namespace string `json:"namespace"`
+
+	// Propagated is true once the ConfigMap carries the current messages
+	Propagated bool `json:"propagated"`
+
+	// +optional
+	// LastPropagatedTime is when the ConfigMap was last written successfully
+	LastPropagatedTime *metav1.Time `json:"lastPropagatedTime,omitempty"`
+
+	// +optional
+	// LastError is the error of the last failed propagation
+	LastError string `json:"lastError,omitempty"`
+}
+
+// ClusterSimpleStatus defines the observed state of ClusterSimple
+type ClusterSimpleStatus struct {
+	// +optional
+	// ObservedGeneration is the generation last reconciled by the controller
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// Conditions represent the latest available observations of the ClusterSimple's state
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=namespace
+	// Namespaces reports the propagation to every selected namespace
+	Namespaces []NamespacePropagationStatus `json:"namespaces,omitempty"`
+
+	// +optional
+	// Propagated is the number of namespaces holding the current messages
+	Propagated int32 `json:"propagated,omitempty"`
+}
+
+// Condition reasons reported in ClusterSimpleStatus.Conditions
+const (
+	// ReasonPropagated means the messages reached every selected namespace
+	ReasonPropagated = "Propagated"
+	// ReasonPropagationFailed means the messages could not be written to some namespaces
+	ReasonPropagationFailed = "PropagationFailed"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=csmp
+// +kubebuilder:printcolumn:name="Propagated",type=integer,JSONPath=`.status.propagated`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ClusterSimple is the Schema for the clustersimples API. Its messages are
+// propagated as a ConfigMap to every namespace matching its selector.
+type ClusterSimple struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	// spec defines the desired state of ClusterSimple
+	// +required
+	Spec ClusterSimpleSpec `json:"spec"`
+
+	// status defines the observed state of ClusterSimple
+	// +optional
+	Status ClusterSimpleStatus `json:"status,omitempty,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterSimpleList contains a list of ClusterSimple
+type ClusterSimpleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterSimple `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterSimple{}, &ClusterSimpleList{})
+}
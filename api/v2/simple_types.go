@@ -0,0 +1,1460 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// MessageSpec defines a single message and its delivery options
+type MessageSpec struct {
+	// +kubebuilder:validation:MinLength=1
+	// Text is the string to print. It is rendered as a Go template with the
+	// Simple's .Name, .Namespace, .Labels and .Annotations, the .Cluster info and
+	// a `configMap "name" "key"` function reading ConfigMaps of its namespace.
+	Text string `json:"text"`
+
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]+$`
+	// Name identifies the message. Named messages are also written to their own
+	// key in the message ConfigMap.
+	Name string `json:"name,omitempty"`
+}
+
+// MessageSource selects a message stored in a ConfigMap or a Secret.
+// Exactly one of the references must be set.
+// +kubebuilder:validation:XValidation:rule="has(self.configMapKeyRef) != has(self.secretKeyRef)",message="exactly one of configMapKeyRef or secretKeyRef must be set"
+type MessageSource struct {
+	// +optional
+	// ConfigMapKeyRef selects a key of a ConfigMap in the Simple's namespace
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+
+	// +optional
+	// SecretKeyRef selects a key of a Secret in the Simple's namespace
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// MessageURLSource fetches a message over HTTP.
+type MessageURLSource struct {
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// URL is fetched with GET, the response body is the message
+	URL string `json:"url"`
+
+	// +optional
+	// PollInterval is how often the URL is fetched again. Defaults to 5m.
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+
+	// +optional
+	// AuthorizationSecretRef selects a key of a Secret in the Simple's
+	// namespace holding the Authorization header, e.g. "Bearer <token>"
+	AuthorizationSecretRef *corev1.SecretKeySelector `json:"authorizationSecretRef,omitempty"`
+}
+
+// GitSource pulls a message from a file of a Git repository.
+type GitSource struct {
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// URL is the repository, cloned over HTTP(S), e.g. https://github.com/org/messages.git
+	URL string `json:"url"`
+
+	// +optional
+	// Ref is the branch, tag or commit SHA to check out. Defaults to the
+	// default branch of the repository.
+	Ref string `json:"ref,omitempty"`
+
+	// +kubebuilder:validation:MinLength=1
+	// Path is the file holding the message, relative to the root of the repository
+	Path string `json:"path"`
+
+	// +optional
+	// Interval is how often the repository is synced. Defaults to 5m.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// +optional
+	// SecretRef names a Secret in the Simple's namespace with the username
+	// and password keys to authenticate with, the password may be a token
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+}
+
+// EncryptedMessage is a message stored encrypted, decrypted by the controller
+// when it delivers it. Exactly one decryption provider must be set.
+// +kubebuilder:validation:XValidation:rule="has(self.sealedBox) != has(self.vaultTransit)",message="exactly one of sealedBox or vaultTransit must be set"
+type EncryptedMessage struct {
+	// +kubebuilder:validation:MinLength=1
+	// Ciphertext is the encrypted message: the base64 of a sealed box for
+	// SealedBox, the "vault:v1:..." ciphertext of Vault for VaultTransit
+	Ciphertext string `json:"ciphertext"`
+
+	// +optional
+	// SealedBox opens Ciphertext as a libsodium sealed box (crypto_box_seal),
+	// e.g. made with PyNaCl's SealedBox or the sodium CLI
+	SealedBox *SealedBoxDecryption `json:"sealedBox,omitempty"`
+
+	// +optional
+	// VaultTransit decrypts Ciphertext with a key of the transit secrets
+	// engine of HashiCorp Vault, which never leaves Vault
+	VaultTransit *VaultTransitDecryption `json:"vaultTransit,omitempty"`
+}
+
+// SealedBoxDecryption opens sealed boxes with an X25519 key pair.
+type SealedBoxDecryption struct {
+	// PrivateKeySecretRef selects a key of a Secret in the Simple's namespace
+	// holding the base64 of the 32 bytes X25519 private key. The public key is
+	// derived from it.
+	PrivateKeySecretRef corev1.SecretKeySelector `json:"privateKeySecretRef"`
+}
+
+// VaultTransitDecryption decrypts with the transit secrets engine of Vault.
+type VaultTransitDecryption struct {
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// Address is the URL of Vault, e.g. https://vault.example.com:8200
+	Address string `json:"address"`
+
+	// +optional
+	// +kubebuilder:default=transit
+	// Mount is the path the transit engine is mounted at
+	Mount string `json:"mount,omitempty"`
+
+	// +kubebuilder:validation:MinLength=1
+	// KeyName is the transit key Ciphertext was encrypted with
+	KeyName string `json:"keyName"`
+
+	// TokenSecretRef selects a key of a Secret in the Simple's namespace
+	// holding a Vault token allowed to decrypt with KeyName
+	TokenSecretRef corev1.SecretKeySelector `json:"tokenSecretRef"`
+}
+
+// EchoSpec configures the HTTP echo Deployment that serves the messages.
+type EchoSpec struct {
+	// +optional
+	// +kubebuilder:default="hashicorp/http-echo:1.0"
+	// Image is the http-echo compatible image to run
+	Image string `json:"image,omitempty"`
+
+	// +optional
+	// +kubebuilder:default=5678
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// Port is the container port the echo server listens on
+	Port int32 `json:"port,omitempty"`
+
+	// WorkloadSpec sets the resources and the scheduling of the echo Pods
+	WorkloadSpec `json:",inline"`
+}
+
+// WorkloadSpec sets the resources and the scheduling of the Pods the operator
+// creates for a Simple. Unset fields are left to the cluster defaults.
+type WorkloadSpec struct {
+	// +optional
+	// Resources are the compute resources of the container. The webhook
+	// defaults the requests the operator is configured with.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// +optional
+	// NodeSelector restricts the Pods to the nodes with these labels
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// +optional
+	// +listType=atomic
+	// Tolerations let the Pods run on nodes with matching taints
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// Affinity holds the node and Pod affinity rules of the Pods. They are
+	// validated when the Pods are created, the schema of the CRD leaves them
+	// out to keep its size under the limits of kubectl apply.
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:MaxLength=253
+	// PriorityClassName is the PriorityClass of the Pods
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// +optional
+	// +listType=atomic
+	// TopologySpreadConstraints spread the Pods across the topology domains
+	// of the cluster, e.g. its zones
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+}
+
+// ExposeSpec exposes the echo Deployment over HTTP.
+// +kubebuilder:validation:XValidation:rule="!(has(self.ingress) && has(self.httpRoute))",message="ingress and httpRoute are mutually exclusive"
+type ExposeSpec struct {
+	// +optional
+	// +kubebuilder:default=80
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// Port is the port of the Service in front of the echo pods
+	Port int32 `json:"port,omitempty"`
+
+	// +optional
+	// Ingress routes to the Service with an Ingress when set
+	Ingress *IngressSpec `json:"ingress,omitempty"`
+
+	// +optional
+	// HTTPRoute routes to the Service with a Gateway API HTTPRoute when set
+	HTTPRoute *HTTPRouteSpec `json:"httpRoute,omitempty"`
+}
+
+// IngressSpec configures the Ingress of ExposeSpec.
+type IngressSpec struct {
+	// +optional
+	// ClassName is the IngressClass of the Ingress. Defaults to the default class.
+	ClassName *string `json:"className,omitempty"`
+
+	// +optional
+	// Host is the host the Ingress serves. Defaults to all hosts.
+	Host string `json:"host,omitempty"`
+
+	// +optional
+	// +kubebuilder:default="/"
+	// +kubebuilder:validation:Pattern=`^/`
+	// Path is the path prefix the Ingress serves
+	Path string `json:"path,omitempty"`
+
+	// +optional
+	// TLSSecretName is the Secret holding the TLS certificate of Host. The
+	// Ingress serves HTTPS when set.
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+}
+
+// HTTPRouteSpec configures the HTTPRoute of ExposeSpec.
+type HTTPRouteSpec struct {
+	// GatewayName is the Gateway the HTTPRoute is attached to
+	GatewayName string `json:"gatewayName"`
+
+	// +optional
+	// GatewayNamespace is the namespace of the Gateway. Defaults to the namespace of the Simple.
+	GatewayNamespace string `json:"gatewayNamespace,omitempty"`
+
+	// +optional
+	// SectionName is the listener of the Gateway the HTTPRoute is attached to
+	SectionName string `json:"sectionName,omitempty"`
+
+	// +optional
+	// Host is the hostname the HTTPRoute serves. Defaults to the hostnames of the listener.
+	Host string `json:"host,omitempty"`
+
+	// +optional
+	// +kubebuilder:default="/"
+	// +kubebuilder:validation:Pattern=`^/`
+	// Path is the path prefix the HTTPRoute serves
+	Path string `json:"path,omitempty"`
+}
+
+// RunSpec runs the messages as a command in a Job.
+type RunSpec struct {
+	// +optional
+	// +kubebuilder:default="busybox:1.36"
+	// Image is the image of the Job container
+	Image string `json:"image,omitempty"`
+
+	// +optional
+	// Command is the command of the Job container. Its items are rendered like
+	// the message texts. Defaults to running the messages with "sh -c".
+	Command []string `json:"command,omitempty"`
+
+	// +optional
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=0
+	// SuccessfulJobsHistoryLimit is the number of finished successful Jobs to keep
+	SuccessfulJobsHistoryLimit *int32 `json:"successfulJobsHistoryLimit,omitempty"`
+
+	// +optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=0
+	// FailedJobsHistoryLimit is the number of finished failed Jobs to keep
+	FailedJobsHistoryLimit *int32 `json:"failedJobsHistoryLimit,omitempty"`
+
+	// WorkloadSpec sets the resources and the scheduling of the Job Pods
+	WorkloadSpec `json:",inline"`
+}
+
+// PodTargetSpec selects the Pods the messages are written to as an annotation.
+type PodTargetSpec struct {
+	// Selector selects the Pods in the namespace of the Simple
+	Selector metav1.LabelSelector `json:"selector"`
+
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=316
+	// +kubebuilder:validation:Pattern=`^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`
+	// AnnotationKey is the annotation the messages are written to, e.g. to be
+	// read by a sidecar through the downward API
+	AnnotationKey string `json:"annotationKey"`
+}
+
+// SinksSpec configures where the messages are delivered besides the controller log.
+type SinksSpec struct {
+	// +optional
+	// Slack posts the messages to a Slack incoming webhook
+	Slack *SlackSink `json:"slack,omitempty"`
+
+	// +optional
+	// HTTP sends the messages as JSON to an HTTP endpoint
+	HTTP *HTTPSink `json:"http,omitempty"`
+
+	// +optional
+	// NATS publishes the messages to a NATS subject
+	NATS *NATSSink `json:"nats,omitempty"`
+
+	// +optional
+	// Kafka produces the messages as a record to a Kafka topic
+	Kafka *KafkaSink `json:"kafka,omitempty"`
+
+	// +optional
+	// Email sends the messages as an email through an SMTP server
+	Email *EmailSink `json:"email,omitempty"`
+}
+
+// Names returns the names of the configured sinks, as in their JSON field names.
+func (s *SinksSpec) Names() []string {
+	if s == nil {
+		return nil
+	}
+	var names []string
+	for _, sink := range []struct {
+		name       string
+		configured bool
+	}{
+		{"slack", s.Slack != nil},
+		{"http", s.HTTP != nil},
+		{"nats", s.NATS != nil},
+		{"kafka", s.Kafka != nil},
+		{"email", s.Email != nil},
+	} {
+		if sink.configured {
+			names = append(names, sink.name)
+		}
+	}
+	return names
+}
+
+// SlackSink delivers the messages to a Slack incoming webhook.
+type SlackSink struct {
+	// WebhookURLSecretRef selects the Secret key holding the incoming webhook URL
+	WebhookURLSecretRef corev1.SecretKeySelector `json:"webhookURLSecretRef"`
+}
+
+// HTTPSink delivers the messages as a JSON payload to an HTTP endpoint.
+type HTTPSink struct {
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// URL is the endpoint receiving the messages
+	URL string `json:"url"`
+
+	// +optional
+	// +kubebuilder:default=POST
+	// +kubebuilder:validation:Enum=POST;PUT;PATCH
+	// Method is the HTTP method of the request
+	Method string `json:"method,omitempty"`
+
+	// +optional
+	// HeadersSecretRef names a Secret whose keys and values are sent as request headers
+	HeadersSecretRef *corev1.LocalObjectReference `json:"headersSecretRef,omitempty"`
+
+	// +optional
+	// TLS configures how the server certificate is verified
+	TLS *HTTPSinkTLS `json:"tls,omitempty"`
+
+	// +optional
+	// Retry configures how failed deliveries are retried
+	Retry *RetryPolicy `json:"retry,omitempty"`
+}
+
+// NATSSink publishes the messages to a NATS subject.
+type NATSSink struct {
+	// ConnectionSecretRef names a Secret holding the server "url" and optionally
+	// "user" and "password", or "token"
+	ConnectionSecretRef corev1.LocalObjectReference `json:"connectionSecretRef"`
+
+	// +optional
+	// +kubebuilder:default="simples.{{ .Namespace }}.{{ .Name }}"
+	// Subject is a Go template of the subject, rendered with the Simple's .Name and .Namespace
+	Subject string `json:"subject,omitempty"`
+
+	// +optional
+	// JetStream publishes through JetStream and waits for the stream to acknowledge the message
+	JetStream bool `json:"jetStream,omitempty"`
+}
+
+// KafkaSink produces the messages as a record keyed by namespace/name.
+type KafkaSink struct {
+	// +kubebuilder:validation:MinItems=1
+	// Brokers are the host:port addresses of the bootstrap brokers
+	Brokers []string `json:"brokers"`
+
+	// +kubebuilder:validation:MinLength=1
+	// Topic is the topic the record is produced to
+	Topic string `json:"topic"`
+
+	// +optional
+	// CredentialsSecretRef names a Secret holding the SASL "mechanism" (PLAIN,
+	// SCRAM-SHA-256 or SCRAM-SHA-512), "username" and "password", and
+	// optionally a PEM "ca.crt" to trust
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+
+	// +optional
+	// TLS connects to the brokers over TLS
+	TLS bool `json:"tls,omitempty"`
+}
+
+// EmailSink sends the messages as an email to a list of recipients.
+type EmailSink struct {
+	// SMTPSecretRef names a Secret holding the SMTP "host", "port", "from"
+	// address and optionally "username" and "password"
+	SMTPSecretRef corev1.LocalObjectReference `json:"smtpSecretRef"`
+
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=50
+	// +kubebuilder:validation:items:Pattern=`^[^@\s]+@[^@\s]+$`
+	// To are the recipient addresses, each receiving its own email
+	To []string `json:"to"`
+
+	// +optional
+	// Subject is the subject of the email. Defaults to "Message from <namespace>/<name>".
+	Subject string `json:"subject,omitempty"`
+}
+
+// HTTPSinkTLS configures TLS for an HTTP sink.
+type HTTPSinkTLS struct {
+	// +optional
+	// CASecretRef selects a Secret key holding PEM encoded CA certificates to trust
+	CASecretRef *corev1.SecretKeySelector `json:"caSecretRef,omitempty"`
+
+	// +optional
+	// InsecureSkipVerify disables the verification of the server certificate
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// RetryPolicy configures the retries of a sink delivery with exponential backoff.
+type RetryPolicy struct {
+	// +optional
+	// +kubebuilder:default=4
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	// MaxAttempts is the number of attempts, including the first one
+	MaxAttempts int32 `json:"maxAttempts,omitempty"`
+
+	// +optional
+	// Backoff is the delay before the first retry, doubled on every attempt. Defaults to 1s.
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
+}
+
+// BackoffSpec bounds the retries of a failed reconciliation with exponential backoff.
+type BackoffSpec struct {
+	// +kubebuilder:validation:Minimum=0
+	// MaxRetries is the number of retries after the first failure
+	MaxRetries int32 `json:"maxRetries"`
+
+	// +optional
+	// +kubebuilder:default=2
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	// Factor multiplies the delay on every retry
+	Factor int32 `json:"factor,omitempty"`
+
+	// +optional
+	// Delay is the delay before the first retry. Defaults to 1s.
+	Delay *metav1.Duration `json:"delay,omitempty"`
+
+	// +optional
+	// MaxDuration stops the retries once this long has passed since the
+	// first failure, even if MaxRetries isn't reached
+	MaxDuration *metav1.Duration `json:"maxDuration,omitempty"`
+
+	// +optional
+	// DeadLetter copies the messages and the last error into the
+	// "<name>-dead-letter" ConfigMap once the retries are exhausted
+	DeadLetter bool `json:"deadLetter,omitempty"`
+}
+
+// SimpleSpec defines the desired state
+// +kubebuilder:validation:XValidation:rule="has(self.message) || has(self.messageFrom) || has(self.messageURL) || has(self.gitSource) || has(self.encryptedMessage) || has(self.messageKey) || (has(self.messages) && size(self.messages) > 0) || has(self.payload)",message="message, messageFrom, messageURL, gitSource, encryptedMessage, messageKey, messages or payload must be set"
+// +kubebuilder:validation:XValidation:rule="!(has(self.message) && has(self.messageFrom))",message="message and messageFrom are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="!(has(self.messageURL) && (has(self.message) || has(self.messageFrom)))",message="messageURL is mutually exclusive with message and messageFrom"
+// +kubebuilder:validation:XValidation:rule="!(has(self.gitSource) && (has(self.message) || has(self.messageFrom) || has(self.messageURL)))",message="gitSource is mutually exclusive with message, messageFrom and messageURL"
+// +kubebuilder:validation:XValidation:rule="!(has(self.encryptedMessage) && (has(self.message) || has(self.messageFrom) || has(self.messageURL) || has(self.gitSource)))",message="encryptedMessage is mutually exclusive with message, messageFrom, messageURL and gitSource"
+// +kubebuilder:validation:XValidation:rule="!has(self.encryptedMessage) || (has(self.output) && self.output.kind == 'Secret' && !has(self.echo) && !has(self.run) && !has(self.podTarget))",message="encryptedMessage requires output.kind Secret, and can't be used with echo, run or podTarget"
+// +kubebuilder:validation:XValidation:rule="!(has(self.messageKey) && (has(self.message) || has(self.messageFrom) || has(self.messageURL) || has(self.gitSource) || has(self.encryptedMessage)))",message="messageKey is mutually exclusive with message, messageFrom, messageURL, gitSource and encryptedMessage"
+// +kubebuilder:validation:XValidation:rule="has(self.messageKey) == has(self.catalog)",message="messageKey and catalog must be set together"
+// +kubebuilder:validation:XValidation:rule="!has(self.locale) || has(self.messageKey)",message="locale requires messageKey"
+// +kubebuilder:validation:XValidation:rule="!(has(self.schedule) && size(self.schedule) > 0 && has(self.interval))",message="interval and schedule are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="!has(self.expose) || has(self.echo)",message="expose requires echo"
+type SimpleSpec struct {
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// Message is the string to print. Like the texts of Messages it may be a Go
+	// template, see MessageSpec.Text.
+	// Deprecated: use Messages. When set it is delivered before Messages.
+	Message string `json:"message,omitempty"`
+
+	// +optional
+	// MessageFrom loads a message from a ConfigMap or Secret. It is delivered
+	// before Messages and re-delivered whenever the source changes. It is
+	// mutually exclusive with Message.
+	MessageFrom *MessageSource `json:"messageFrom,omitempty"`
+
+	// +optional
+	// MessageURL fetches a message from an HTTP URL on an interval. It is
+	// delivered before Messages and re-delivered whenever the content changes.
+	// It is mutually exclusive with Message and MessageFrom.
+	MessageURL *MessageURLSource `json:"messageURL,omitempty"`
+
+	// +optional
+	// GitSource pulls a message from a Git repository on an interval. It is
+	// delivered before Messages and re-delivered whenever the file changes.
+	// It is mutually exclusive with Message, MessageFrom and MessageURL.
+	GitSource *GitSource `json:"gitSource,omitempty"`
+
+	// +optional
+	// EncryptedMessage is a message stored encrypted in the Simple, which is
+	// only decrypted by the controller to deliver it. It is delivered before
+	// Messages and is never written back in plaintext: the Simple is redacted
+	// like with Redact, and its messages may only be written to a Secret
+	// with Output, not to the echo server, a run Job or Pod annotations. It
+	// is mutually exclusive with Message, MessageFrom, MessageURL and GitSource.
+	EncryptedMessage *EncryptedMessage `json:"encryptedMessage,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:MaxLength=200
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]+$`
+	// MessageKey delivers the entry of the message catalog Catalog for
+	// Locale, and is re-delivered whenever the catalog changes. It is
+	// delivered before Messages and is mutually exclusive with Message,
+	// MessageFrom, MessageURL, GitSource and EncryptedMessage.
+	MessageKey string `json:"messageKey,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:MaxLength=35
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z]{2,8}(-[a-zA-Z0-9]{1,8})*$`
+	// Locale is the BCP 47 locale MessageKey is delivered in, e.g. de-AT,
+	// the default locale of the controller when unset. The catalog is
+	// searched for the key "<messageKey>.<locale>", then for the locale less
+	// its last subtag down to its language (de), then for the default
+	// locale, and last for "<messageKey>" itself.
+	Locale string `json:"locale,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:MaxLength=253
+	// Catalog is the name of the ConfigMap of the namespace holding the
+	// entries of MessageKey. It is required with MessageKey.
+	Catalog string `json:"catalog,omitempty"`
+
+	// +optional
+	// Messages are the messages to print, in order
+	Messages []MessageSpec `json:"messages,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// Payload is a structured message: an object, or a string holding a YAML
+	// or JSON document of an object. It is delivered after Messages as the
+	// "payload" message, its top-level keys are also written to the message
+	// ConfigMap, and the HTTP sink sends it as the request body.
+	Payload *runtime.RawExtension `json:"payload,omitempty"`
+
+	// +optional
+	// Interval makes the controller re-deliver the messages on the given cadence.
+	// When unset the messages are delivered once.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// +optional
+	// Schedule re-delivers the messages on a cron schedule, e.g. "0 9 * * MON".
+	// It is mutually exclusive with Interval.
+	Schedule string `json:"schedule,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// StartingDeadlineSeconds skips scheduled deliveries that are more than this
+	// many seconds late, e.g. because the controller was down. When unset late
+	// deliveries are always made, once.
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// DeletionNotificationURL receives a JSON "deleted" notification via POST
+	// when the Simple is deleted, before its finalizer is removed
+	DeletionNotificationURL string `json:"deletionNotificationURL,omitempty"`
+
+	// +optional
+	// Suspend tells the controller to stop delivering messages until it is
+	// set back to false. Deletion is still handled while suspended.
+	Suspend bool `json:"suspend,omitempty"`
+
+	// +optional
+	// Echo deploys an HTTP echo server returning the messages when set
+	Echo *EchoSpec `json:"echo,omitempty"`
+
+	// +optional
+	// Expose puts a Service, and optionally an Ingress or an HTTPRoute, in
+	// front of the echo Deployment. It requires Echo.
+	Expose *ExposeSpec `json:"expose,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// Replicas is the number of echo server pods. Defaults to 1.
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// TTLSecondsAfterReplied deletes the Simple once it has been Ready for
+	// this many seconds. When unset the Simple is kept forever.
+	TTLSecondsAfterReplied *int32 `json:"ttlSecondsAfterReplied,omitempty"`
+
+	// +optional
+	// Sinks are additional destinations the messages are delivered to
+	Sinks *SinksSpec `json:"sinks,omitempty"`
+
+	// +optional
+	// Run creates a Job running the messages as a command on every delivery
+	Run *RunSpec `json:"run,omitempty"`
+
+	// +optional
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// HistoryLimit is the number of entries kept in Status.History
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
+
+	// +optional
+	// Immutable makes the validating webhook reject changes to the messages,
+	// their sources, catalog, locale and format, and unsetting Immutable,
+	// unless the AllowMutationAnnotation is set to "true".
+	Immutable bool `json:"immutable,omitempty"`
+
+	// +optional
+	// Backoff bounds the retries of failed reconciliations. Once they are
+	// exhausted the Simple stays Failed until its spec changes. When unset
+	// failures are retried forever.
+	Backoff *BackoffSpec `json:"backoff,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=3600
+	// TimeoutSeconds bounds the duration of a reconciliation, aborting hung
+	// fetches and sink deliveries. Overrides the --reconcile-timeout of the operator.
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// +optional
+	// Priority orders the reconciliations waiting in the queue of the
+	// operator: High Simples, e.g. alerts, are reconciled before the Normal
+	// ones and those before the Low ones. Defaults to Normal.
+	Priority Priority `json:"priority,omitempty"`
+
+	// +optional
+	// LogLevel sets the verbosity of the logs about this Simple: Debug logs
+	// its reconciliation in detail whatever the level of the operator, Error
+	// only logs its failures. Defaults to the level of the operator.
+	LogLevel LogLevel `json:"logLevel,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:MaxItems=100
+	// +kubebuilder:validation:items:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:items:MaxLength=63
+	// TargetNamespaces are namespaces the message ConfigMap is replicated to,
+	// in addition to the namespace of the Simple
+	TargetNamespaces []string `json:"targetNamespaces,omitempty"`
+
+	// +optional
+	// NamespaceSelector replicates the message ConfigMap to every namespace
+	// matching it, in addition to TargetNamespaces
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// +optional
+	// PodTarget writes the messages, joined by newlines, to an annotation of
+	// the selected Pods
+	PodTarget *PodTargetSpec `json:"podTarget,omitempty"`
+
+	// +optional
+	// Output configures the object the messages are written to
+	Output *OutputSpec `json:"output,omitempty"`
+
+	// +optional
+	// Redact keeps the messages out of the logs, events and status of the
+	// Simple, and out of the errors of the webhook rejecting them.
+	// Status.Messages then holds the SHA-256 of each message.
+	Redact bool `json:"redact,omitempty"`
+
+	// +optional
+	// Format is how the messages are rendered into the message ConfigMap, the
+	// echo server, the Pod annotation and the Slack and email sinks: Plain
+	// text, one message per line, a JSON or YAML document with the metadata of
+	// the Simple, or Markdown, sent as HTML by email. Defaults to Plain.
+	Format MessageFormat `json:"format,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=20
+	// Clusters are remote clusters the message ConfigMap, or Secret with
+	// Output, is replicated to
+	Clusters []ClusterTarget `json:"clusters,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:MaxItems=20
+	// DependsOn are Simples that must be Ready before the messages of this
+	// one are delivered
+	DependsOn []ObjectReference `json:"dependsOn,omitempty"`
+
+	// +optional
+	// Propagation selects the labels and annotations of the Simple copied
+	// onto the objects created for it, in addition to the --default-child-labels
+	// of the operator
+	Propagation *PropagationSpec `json:"propagation,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	// ServiceAccountName is a ServiceAccount of the Simple's namespace the
+	// objects created for the Simple are written as, so they are limited to
+	// what it is allowed to do. The Pods of the run Jobs also run as it. The
+	// operator's own permissions are used when it is empty.
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+}
+
+// PropagationSpec selects labels and annotations by key. A key ending with
+// "*" selects every key with that prefix, e.g. "team.example.com/*".
+type PropagationSpec struct {
+	// +optional
+	// +kubebuilder:validation:MaxItems=50
+	// Labels are the keys of the labels copied
+	Labels []string `json:"labels,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:MaxItems=50
+	// Annotations are the keys of the annotations copied
+	Annotations []string `json:"annotations,omitempty"`
+}
+
+// ObjectReference refers to a Simple.
+type ObjectReference struct {
+	// +kubebuilder:validation:MinLength=1
+	// Name is the name of the Simple
+	Name string `json:"name"`
+
+	// +optional
+	// Namespace is the namespace of the Simple, defaults to the namespace of
+	// the referring Simple
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ClusterTarget is a remote cluster the messages are replicated to.
+type ClusterTarget struct {
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	// Name identifies the cluster in Status.Clusters
+	Name string `json:"name"`
+
+	// KubeconfigSecretRef selects the kubeconfig of the cluster in a Secret of
+	// the namespace of the Simple
+	KubeconfigSecretRef corev1.SecretKeySelector `json:"kubeconfigSecretRef"`
+
+	// +optional
+	// Namespace is the namespace of the cluster the messages are written to.
+	// Defaults to the namespace of the Simple.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// MessageFormat is how the messages of a Simple are rendered
+// +kubebuilder:validation:Enum=Plain;JSON;YAML;Markdown
+type MessageFormat string
+
+const (
+	// FormatPlain renders the texts one per line
+	FormatPlain MessageFormat = "Plain"
+	// FormatJSON renders a JSON document of the messages and the Simple
+	FormatJSON MessageFormat = "JSON"
+	// FormatYAML renders a YAML document of the messages and the Simple
+	FormatYAML MessageFormat = "YAML"
+	// FormatMarkdown renders the texts as Markdown paragraphs, and as HTML where supported
+	FormatMarkdown MessageFormat = "Markdown"
+)
+
+// OutputSpec configures the object the messages are written to.
+type OutputSpec struct {
+	// +optional
+	// +kubebuilder:default=ConfigMap
+	// Kind is the kind of the object holding the messages, named
+	// <name>-message. Use Secret for sensitive messages.
+	Kind OutputKind `json:"kind,omitempty"`
+}
+
+// OutputKind is the kind of the object the messages are written to
+// +kubebuilder:validation:Enum=ConfigMap;Secret
+type OutputKind string
+
+const (
+	// OutputKindConfigMap writes the messages to a ConfigMap
+	OutputKindConfigMap OutputKind = "ConfigMap"
+	// OutputKindSecret writes the messages to a Secret
+	OutputKindSecret OutputKind = "Secret"
+)
+
+// Priority is the priority of the reconciliations of a Simple
+// +kubebuilder:validation:Enum=Low;Normal;High
+type Priority string
+
+const (
+	// PriorityLow is reconciled once no other Simple is waiting
+	PriorityLow Priority = "Low"
+	// PriorityNormal is the default priority
+	PriorityNormal Priority = "Normal"
+	// PriorityHigh is reconciled before the other Simples
+	PriorityHigh Priority = "High"
+)
+
+// LogLevel is the verbosity of the logs about a Simple
+// +kubebuilder:validation:Enum=Debug;Info;Error
+type LogLevel string
+
+const (
+	// LogLevelDebug also logs the details of the reconciliation
+	LogLevelDebug LogLevel = "Debug"
+	// LogLevelInfo logs the deliveries and failures
+	LogLevelInfo LogLevel = "Info"
+	// LogLevelError only logs the failures
+	LogLevelError LogLevel = "Error"
+)
+
+// PriorityOrDefault returns the priority of the reconciliations of the Simple.
+func (s *SimpleSpec) PriorityOrDefault() Priority {
+	if s.Priority == "" {
+		return PriorityNormal
+	}
+	return s.Priority
+}
+
+// DeprecatedField is a field of SimpleSpec due to be removed.
+type DeprecatedField struct {
+	// Name is the JSON name of the field
+	Name string
+	// Replacement is the JSON name of the field to use instead
+	Replacement string
+}
+
+// DeprecatedFields returns the deprecated fields the spec sets.
+func (s *SimpleSpec) DeprecatedFields() []DeprecatedField {
+	var fields []DeprecatedField
+	if s.Message != "" {
+		fields = append(fields, DeprecatedField{Name: "message", Replacement: "messages"})
+	}
+	return fields
+}
+
+// Redacted tells whether the messages are kept out of the logs, events and
+// status, as asked by Redact or implied by EncryptedMessage.
+func (s *SimpleSpec) Redacted() bool {
+	return s.Redact || s.EncryptedMessage != nil
+}
+
+// OutputKind returns the kind of the object the messages are written to.
+func (s *SimpleSpec) OutputKind() OutputKind {
+	if s.Output == nil || s.Output.Kind == "" {
+		return OutputKindConfigMap
+	}
+	return s.Output.Kind
+}
+
+// PayloadMessageName is the name of the message delivering Payload.
+const PayloadMessageName = "payload"
+
+// PayloadJSON returns Payload as a compact JSON object, or nil if it is unset.
+// A string payload is parsed as a YAML or JSON document.
+func (s *SimpleSpec) PayloadJSON() ([]byte, error) {
+	if s.Payload == nil || len(s.Payload.Raw) == 0 {
+		return nil, nil
+	}
+	data := s.Payload.Raw
+	var document string
+	if err := json.Unmarshal(data, &document); err == nil {
+		if data, err = yaml.YAMLToJSON([]byte(document)); err != nil {
+			return nil, fmt.Errorf("parsing the payload document: %w", err)
+		}
+	}
+	var object map[string]any
+	if err := json.Unmarshal(data, &object); err != nil || object == nil {
+		return nil, fmt.Errorf("the payload must be an object")
+	}
+	return json.Marshal(object)
+}
+
+// AllMessages returns the messages to deliver, honoring the deprecated Message field.
+func (s *SimpleSpec) AllMessages() []MessageSpec {
+	if s.Message == "" {
+		return s.Messages
+	}
+	return append([]MessageSpec{{Text: s.Message}}, s.Messages...)
+}
+
+// Condition types reported in SimpleStatus.Conditions
+const (
+	// ConditionReady is True once the messages have been replied
+	ConditionReady = "Ready"
+	// ConditionProgressing is True while the controller is working towards Ready
+	ConditionProgressing = "Progressing"
+	// ConditionDegraded is True when the last reconcile failed
+	ConditionDegraded = "Degraded"
+	// ConditionSuspended is True while Spec.Suspend pauses reconciliation
+	ConditionSuspended = "Suspended"
+	// ConditionHTTPSinkDelivered is True once the messages reached the HTTP sink
+	ConditionHTTPSinkDelivered = "HTTPSinkDelivered"
+	// ConditionSlackSinkDelivered is True once the messages reached the Slack sink
+	ConditionSlackSinkDelivered = "SlackSinkDelivered"
+	// ConditionNATSSinkDelivered is True once the messages reached the NATS sink
+	ConditionNATSSinkDelivered = "NATSSinkDelivered"
+	// ConditionKafkaSinkDelivered is True once the messages reached the Kafka sink
+	ConditionKafkaSinkDelivered = "KafkaSinkDelivered"
+	// ConditionEmailSinkDelivered is True once the messages reached the email sink
+	ConditionEmailSinkDelivered = "EmailSinkDelivered"
+	// ConditionAllSinksDelivered is True once the messages reached every
+	// configured sink. Simples without sinks don't have it.
+	ConditionAllSinksDelivered = "AllSinksDelivered"
+	// ConditionReconciliationPaused is True while the PausedAnnotation is set
+	ConditionReconciliationPaused = "ReconciliationPaused"
+	// ConditionMessageFetched reports the last fetch of Spec.MessageURL or Spec.GitSource
+	ConditionMessageFetched = "MessageFetched"
+	// ConditionReconcileTimeout is True when the last reconcile was aborted at its deadline
+	ConditionReconcileTimeout = "ReconcileTimeout"
+	// ConditionClustersSynced is True once every cluster of Spec.Clusters carries the current messages
+	ConditionClustersSynced = "ClustersSynced"
+	// ConditionWaitingForDependencies is True while a Simple of Spec.DependsOn isn't Ready
+	ConditionWaitingForDependencies = "WaitingForDependencies"
+	// ConditionDuplicate is True when an older Simple of the namespace delivered the same messages
+	ConditionDuplicate = "Duplicate"
+	// ConditionEchoProgressing mirrors the Progressing condition of the echo Deployment
+	ConditionEchoProgressing = "EchoProgressing"
+	// ConditionEchoAvailable mirrors the Available condition of the echo Deployment
+	ConditionEchoAvailable = "EchoAvailable"
+	// ConditionWorkloadReady aggregates the echo Deployment and the last run
+	// Job: it is True once every echo replica is ready and the Job succeeded
+	ConditionWorkloadReady = "WorkloadReady"
+	// ConditionEndpointUnavailable is True while Status.URL doesn't serve the messages
+	ConditionEndpointUnavailable = "EndpointUnavailable"
+	// ConditionDeprecatedFieldsInUse is True while the spec sets deprecated fields
+	ConditionDeprecatedFieldsInUse = "DeprecatedFieldsInUse"
+)
+
+// Annotations changing how a Simple is handled
+const (
+	// PausedAnnotation set to "true" makes the controller skip the Simple until it is removed
+	PausedAnnotation = "simple.example.com/paused"
+	// AllowMutationAnnotation set to "true" allows changing the messages of an immutable Simple
+	AllowMutationAnnotation = "simple.example.com/allow-mutation"
+	// ProtectedAnnotation set to "true" makes the webhook reject the deletion of the Simple
+	ProtectedAnnotation = "simple.example.com/protected"
+	// UnlockDeletionAnnotation set to the name of a protected Simple allows deleting it
+	UnlockDeletionAnnotation = "simple.example.com/unlock-deletion"
+	// ResendAnnotation set to a new value, e.g. the current time, re-delivers
+	// every message once. The controller removes it once handled.
+	ResendAnnotation = "simple.example.com/resend"
+	// AdoptAnnotation set to "true" lets the controller take over existing
+	// objects without an owner named like the children of the Simple
+	AdoptAnnotation = "simple.example.com/adopt"
+	// MaxDeliveriesPerMinuteAnnotation lowers how many times per minute the
+	// edits of the Simple may be re-delivered, it can't raise or lift the
+	// limit of the operator
+	MaxDeliveriesPerMinuteAnnotation = "simple.example.com/max-deliveries-per-minute"
+	// SkipReferenceCheckAnnotation set to "true" makes the webhook admit the
+	// Simple even if the ConfigMaps and Secrets it refers to don't exist yet
+	SkipReferenceCheckAnnotation = "simple.example.com/skip-reference-check"
+)
+
+// AcceptReplicasLabel set to "true" on a namespace lets the Simples of other
+// namespaces replicate their message ConfigMap or Secret into it
+const AcceptReplicasLabel = "simple.example.com/accept-replicas"
+
+// DeletionProtected tells whether the ProtectedAnnotation forbids deleting
+// the Simple, i.e. it isn't unlocked by the UnlockDeletionAnnotation.
+func (s *Simple) DeletionProtected() bool {
+	return s.Annotations[ProtectedAnnotation] == "true" && s.Annotations[UnlockDeletionAnnotation] != s.Name
+}
+
+// ResendRequested returns the value of the ResendAnnotation of the Simple,
+// and whether it asks for a re-delivery not recorded in Status.LastResend yet.
+func (s *Simple) ResendRequested() (string, bool) {
+	resend := s.Annotations[ResendAnnotation]
+	return resend, resend != "" && resend != s.Status.LastResend
+}
+
+// SimplePhase is the lifecycle phase reported in SimpleStatus.Phase
+// +kubebuilder:validation:Enum=Pending;Delivering;Replied;Failed
+type SimplePhase string
+
+const (
+	// PhasePending means the controller hasn't started delivering the messages
+	PhasePending SimplePhase = "Pending"
+	// PhaseDelivering means the controller is delivering the messages
+	PhaseDelivering SimplePhase = "Delivering"
+	// PhaseReplied means the messages have been delivered
+	PhaseReplied SimplePhase = "Replied"
+	// PhaseFailed means the last delivery failed and will be retried, unless
+	// the retries of Spec.Backoff are exhausted
+	PhaseFailed SimplePhase = "Failed"
+)
+
+// Condition reasons reported in SimpleStatus.Conditions
+const (
+	// ReasonReconciling means the controller is processing the resource
+	ReasonReconciling = "Reconciling"
+	// ReasonReplied means the messages have been seen and logged
+	ReasonReplied = "Replied"
+	// ReasonReconcileFailed means a reconcile step returned an error
+	ReasonReconcileFailed = "ReconcileFailed"
+	// ReasonRenderFailed means a message template could not be rendered
+	ReasonRenderFailed = "RenderFailed"
+	// ReasonSuspended means Spec.Suspend is set
+	ReasonSuspended = "Suspended"
+	// ReasonPaused means the PausedAnnotation is set
+	ReasonPaused = "Paused"
+	// ReasonResumed means Spec.Suspend or the PausedAnnotation was cleared and reconciliation resumed
+	ReasonResumed = "Resumed"
+	// ReasonDelivered means a sink accepted the messages
+	ReasonDelivered = "Delivered"
+	// ReasonDeliveryFailed means a sink rejected the messages or could not be reached
+	ReasonDeliveryFailed = "DeliveryFailed"
+	// ReasonFetched means Spec.MessageURL or Spec.GitSource returned new content
+	ReasonFetched = "Fetched"
+	// ReasonNotModified means Spec.MessageURL or Spec.GitSource returned the content of the previous fetch
+	ReasonNotModified = "NotModified"
+	// ReasonFetchFailed means Spec.MessageURL or Spec.GitSource could not be fetched, the previous content is kept
+	ReasonFetchFailed = "FetchFailed"
+	// ReasonRetriesExhausted means the retries of Spec.Backoff are exhausted
+	ReasonRetriesExhausted = "RetriesExhausted"
+	// ReasonTimedOut means the reconcile exceeded Spec.TimeoutSeconds or the --reconcile-timeout
+	ReasonTimedOut = "TimedOut"
+	// ReasonCompleted means the reconcile finished within its deadline
+	ReasonCompleted = "Completed"
+	// ReasonSynced means every cluster of Spec.Clusters carries the current messages
+	ReasonSynced = "Synced"
+	// ReasonSyncFailed means a cluster of Spec.Clusters could not be reached or written to
+	ReasonSyncFailed = "SyncFailed"
+	// ReasonDependenciesNotReady means some Simples of Spec.DependsOn are missing or not Ready
+	ReasonDependenciesNotReady = "DependenciesNotReady"
+	// ReasonDependenciesReady means every Simple of Spec.DependsOn is Ready
+	ReasonDependenciesReady = "DependenciesReady"
+	// ReasonWorkloadReady means every echo replica is ready and the last run Job succeeded
+	ReasonWorkloadReady = "WorkloadReady"
+	// ReasonWorkloadNotReady means some echo replicas aren't ready or the last run Job didn't succeed
+	ReasonWorkloadNotReady = "WorkloadNotReady"
+	// ReasonThrottled means the edits of the Simple wait for its re-delivery rate limit
+	ReasonThrottled = "Throttled"
+	// ReasonDuplicateMessages means an older Simple of the namespace delivered the same messages
+	ReasonDuplicateMessages = "DuplicateMessages"
+	// ReasonUniqueMessages means no older Simple of the namespace delivered the same messages
+	ReasonUniqueMessages = "UniqueMessages"
+	// ReasonRolloutPending means the Deployment controller hasn't reported on
+	// the current version of the echo Deployment yet
+	ReasonRolloutPending = "RolloutPending"
+	// ReasonProbeFailed means Status.URL didn't answer with the messages
+	ReasonProbeFailed = "ProbeFailed"
+	// ReasonProbeSucceeded means Status.URL answered with the messages
+	ReasonProbeSucceeded = "ProbeSucceeded"
+	// ReasonEndpointUnavailable means the messages were delivered but
+	// Status.URL doesn't serve them yet
+	ReasonEndpointUnavailable = "EndpointUnavailable"
+	// ReasonDeprecatedFields means the spec sets fields due to be removed
+	ReasonDeprecatedFields = "DeprecatedFields"
+	// ReasonNoDeprecatedFields means the spec no longer sets deprecated fields
+	ReasonNoDeprecatedFields = "NoDeprecatedFields"
+)
+
+// MessageStatus records the delivery state of a single message
+type MessageStatus struct {
+	// Message is the delivered string
+	Message string `json:"message"`
+
+	// +optional
+	// Name is the name of the delivered message, if any
+	Name string `json:"name,omitempty"`
+
+	// Delivered indicates that the message has been logged
+	Delivered bool `json:"delivered"`
+
+	// +optional
+	// DeliveredTime is when the message was last delivered
+	DeliveredTime *metav1.Time `json:"deliveredTime,omitempty"`
+}
+
+// RunStatus reports the state of the last Job created for Spec.Run.
+type RunStatus struct {
+	// JobName is the name of the last Job
+	JobName string `json:"jobName"`
+
+	// +optional
+	// StartTime is when the last Job was created
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// +optional
+	// CompletionTime is when the last Job finished
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Enum=Running;Succeeded;Failed
+	// Result is the state of the last Job
+	Result string `json:"result,omitempty"`
+}
+
+// Results reported in RunStatus.Result
+const (
+	RunResultRunning   = "Running"
+	RunResultSucceeded = "Succeeded"
+	RunResultFailed    = "Failed"
+)
+
+// HistoryEntry records one delivery attempt of the messages.
+type HistoryEntry struct {
+	// Time is when the attempt was made
+	Time metav1.Time `json:"time"`
+
+	// MessageHash is the SHA-256 of the delivered messages, to tell when they changed
+	MessageHash string `json:"messageHash"`
+
+	// +kubebuilder:validation:Enum=Delivered;Failed
+	// Outcome is the result of the attempt
+	Outcome string `json:"outcome"`
+
+	// +optional
+	// Message explains failed attempts
+	Message string `json:"message,omitempty"`
+}
+
+// Outcomes reported in HistoryEntry.Outcome
+const (
+	HistoryOutcomeDelivered = "Delivered"
+	HistoryOutcomeFailed    = "Failed"
+)
+
+// MessageURLStatus caches the last response of Spec.MessageURL.
+type MessageURLStatus struct {
+	// URL is the fetched URL
+	URL string `json:"url"`
+
+	// +optional
+	// ETag is the entity tag of the response, sent back in If-None-Match
+	ETag string `json:"etag,omitempty"`
+
+	// +optional
+	// Message is the content of the response
+	Message string `json:"message,omitempty"`
+
+	// LastFetchTime is when the URL was last fetched
+	LastFetchTime metav1.Time `json:"lastFetchTime"`
+}
+
+// GitSourceStatus caches the last sync of Spec.GitSource.
+type GitSourceStatus struct {
+	// URL is the synced repository
+	URL string `json:"url"`
+
+	// +optional
+	// Ref is the synced ref, empty for the default branch
+	Ref string `json:"ref,omitempty"`
+
+	// Path is the file holding the message
+	Path string `json:"path"`
+
+	// Commit is the SHA the ref resolved to
+	Commit string `json:"commit"`
+
+	// +optional
+	// Message is the content of the file at Commit
+	Message string `json:"message,omitempty"`
+
+	// LastSyncTime is when the repository was last synced
+	LastSyncTime metav1.Time `json:"lastSyncTime"`
+}
+
+// LastError describes the last failed reconciliation of a Simple.
+type LastError struct {
+	// Message is the error returned by the reconciliation
+	Message string `json:"message"`
+
+	// Reason is the reason of the Degraded condition, e.g. RenderFailed
+	Reason string `json:"reason"`
+
+	// Time is when the error happened
+	Time metav1.Time `json:"time"`
+
+	// +optional
+	// RetryCount is the number of times the reconciliation was retried since
+	// it first failed
+	RetryCount int32 `json:"retryCount,omitempty"`
+}
+
+// SinkStatus reports the delivery state of a sink.
+type SinkStatus struct {
+	// Name identifies the sink, e.g. "slack"
+	Name string `json:"name"`
+
+	// Delivered is true once the current messages reached the sink
+	Delivered bool `json:"delivered"`
+
+	// +optional
+	// LastDeliveryTime is when the sink last received the messages
+	LastDeliveryTime *metav1.Time `json:"lastDeliveryTime,omitempty"`
+
+	// +optional
+	// LastAttemptTime is when the sink was last called
+	LastAttemptTime *metav1.Time `json:"lastAttemptTime,omitempty"`
+
+	// +optional
+	// Attempts is the number of attempts of the last delivery
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// +optional
+	// ResponseCode is the HTTP status code of the last attempt
+	ResponseCode int32 `json:"responseCode,omitempty"`
+
+	// +optional
+	// Ack is the acknowledgement of the last delivery for sinks that report one,
+	// e.g. the JetStream stream and sequence
+	Ack string `json:"ack,omitempty"`
+
+	// +optional
+	// LastError is the error of the last failed delivery attempt
+	LastError string `json:"lastError,omitempty"`
+
+	// +optional
+	// Recipients tracks the delivery to each recipient for sinks with several
+	Recipients []RecipientStatus `json:"recipients,omitempty"`
+}
+
+// RecipientStatus reports the delivery state of one recipient of a sink.
+type RecipientStatus struct {
+	// Address identifies the recipient
+	Address string `json:"address"`
+
+	// Delivered is true once the recipient accepted the messages
+	Delivered bool `json:"delivered"`
+
+	// +optional
+	// LastError is the error of the last failed delivery to the recipient
+	LastError string `json:"lastError,omitempty"`
+}
+
+// SimpleStatus defines the observed state
+type SimpleStatus struct {
+	// +optional
+	// Replied indicates that we’ve seen and logged the messages.
+	// Kept for compatibility, prefer the Ready condition.
+	Replied bool `json:"replied,omitempty"`
+
+	// +optional
+	// ObservedGeneration is the most recent metadata.generation the status reflects
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// Conditions represent the latest available observations of the Simple's state
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// +optional
+	// Messages tracks the delivery state of each message in Spec.AllMessages order
+	Messages []MessageStatus `json:"messages,omitempty"`
+
+	// +optional
+	// LastRepliedTime is when the messages were last delivered
+	LastRepliedTime *metav1.Time `json:"lastRepliedTime,omitempty"`
+
+	// +optional
+	// ReplyCount is the number of deliveries of the messages: one for the first,
+	// one for every change of the messages and one for every re-delivery of
+	// Spec.Interval or Spec.Schedule
+	ReplyCount int64 `json:"replyCount,omitempty"`
+
+	// +optional
+	// MessageHash is the SHA-256 of the delivered messages, as in Status.History
+	MessageHash string `json:"messageHash,omitempty"`
+
+	// +optional
+	// SpecHash is the SHA-256 of the fields of the spec making up the
+	// messages when they were last delivered. A change re-delivers them all.
+	SpecHash string `json:"specHash,omitempty"`
+
+	// +optional
+	// LastResend is the value of the ResendAnnotation the messages were last re-delivered for
+	LastResend string `json:"lastResend,omitempty"`
+
+	// +optional
+	// LastScheduleTime is the time of the last delivery made for Spec.Schedule
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// +optional
+	// NextReplyTime is when the messages will be delivered again, if Spec.Interval or Spec.Schedule is set
+	NextReplyTime *metav1.Time `json:"nextReplyTime,omitempty"`
+
+	// +optional
+	// Replicas is the number of echo server pods currently running
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// +optional
+	// ReadyReplicas is the number of echo server pods ready to serve the messages
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
+	// +optional
+	// Selector is the label selector of the echo server pods, used by the scale subresource
+	Selector string `json:"selector,omitempty"`
+
+	// +optional
+	// Phase summarizes the conditions into a single value
+	Phase SimplePhase `json:"phase,omitempty"`
+
+	// +optional
+	// MessagePreview is the first message, truncated for display
+	MessagePreview string `json:"messagePreview,omitempty"`
+
+	// +optional
+	// URL is where the echo server exposed by Spec.Expose serves the messages
+	URL string `json:"url,omitempty"`
+
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	// Sinks tracks the delivery state of each configured sink
+	Sinks []SinkStatus `json:"sinks,omitempty"`
+
+	// +optional
+	// Run tracks the last Job created for Spec.Run
+	Run *RunStatus `json:"run,omitempty"`
+
+	// +optional
+	// Output is the tail of the log of the last finished Job
+	Output string `json:"output,omitempty"`
+
+	// +optional
+	// History lists the last deliveries, oldest first, bounded by Spec.HistoryLimit.
+	// Consecutive identical failures are recorded once, at the first occurrence.
+	History []HistoryEntry `json:"history,omitempty"`
+
+	// +optional
+	// FailedAttempts is the number of consecutive failed reconciliations since
+	// the last success or spec change
+	FailedAttempts int32 `json:"failedAttempts,omitempty"`
+
+	// +optional
+	// LastError is the error of the last failed reconciliation, cleared once
+	// the Simple reconciles successfully
+	LastError *LastError `json:"lastError,omitempty"`
+
+	// +optional
+	// MessageURL caches the last response of Spec.MessageURL
+	MessageURL *MessageURLStatus `json:"messageURL,omitempty"`
+
+	// +optional
+	// GitSource caches the last sync of Spec.GitSource
+	GitSource *GitSourceStatus `json:"gitSource,omitempty"`
+
+	// +optional
+	// Locale is the locale of the catalog entry delivered for
+	// Spec.MessageKey, empty when it fell back to the entry without locale
+	Locale string `json:"locale,omitempty"`
+
+	// +optional
+	// FirstFailureTime is when the first of FailedAttempts happened
+	FirstFailureTime *metav1.Time `json:"firstFailureTime,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=namespace
+	// Targets reports the replication of the message ConfigMap to every
+	// namespace of Spec.TargetNamespaces and Spec.NamespaceSelector
+	Targets []NamespacePropagationStatus `json:"targets,omitempty"`
+
+	// +optional
+	// AnnotatedPods is the number of Pods selected by Spec.PodTarget carrying
+	// the current messages
+	AnnotatedPods int32 `json:"annotatedPods,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// Clusters reports the replication of the messages to every cluster of Spec.Clusters
+	Clusters []ClusterSyncStatus `json:"clusters,omitempty"`
+
+	// +optional
+	// DuplicateOf is the name of an older Simple of the namespace that
+	// delivered the same messages, when duplicate detection is enabled
+	DuplicateOf string `json:"duplicateOf,omitempty"`
+}
+
+// ClusterSyncStatus reports the replication of the messages to a remote cluster.
+type ClusterSyncStatus struct {
+	// Name is the name of the cluster in Spec.Clusters
+	Name string `json:"name"`
+
+	// Synced is true once the cluster carries the current messages
+	Synced bool `json:"synced"`
+
+	// +optional
+	// LastSyncTime is when the messages were last written to the cluster
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// +optional
+	// LastError is the error of the last failed sync, e.g. a connectivity error
+	LastError string `json:"lastError,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.selector
+// +kubebuilder:storageversion
+// +kubebuilder:resource:shortName=smp,categories=all
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Replied",type=boolean,JSONPath=`.status.replied`
+// +kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.messagePreview`
+// +kubebuilder:printcolumn:name="Replies",type=integer,JSONPath=`.status.replyCount`,priority=1
+// +kubebuilder:printcolumn:name="Ready",type=integer,JSONPath=`.status.readyReplicas`,priority=1
+// +kubebuilder:printcolumn:name="URL",type=string,JSONPath=`.status.url`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Simple is the Schema for the simples API
+type Simple struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	// spec defines the desired state of Simple
+	// +required
+	Spec SimpleSpec `json:"spec"`
+
+	// status defines the observed state of Simple
+	// +optional
+	Status SimpleStatus `json:"status,omitempty,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// SimpleList contains a list of Simple
+type SimpleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Simple `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Simple{}, &SimpleList{})
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// SimpleAuditSpec is one entry of the audit trail of a Simple. It is written
+// once by the controller and never changes.
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="audit entries are immutable"
+type SimpleAuditSpec struct {
+	// SimpleName is the name of the audited Simple, in the namespace of the entry
+	SimpleName string `json:"simpleName"`
+
+	// SimpleUID is the UID of the audited Simple, to tell apart the Simples
+	// recreated with the same name
+	SimpleUID types.UID `json:"simpleUID"`
+
+	// +kubebuilder:validation:Enum=SpecChanged;Delivery;Deleted
+	// Action is what happened to the Simple
+	Action AuditAction `json:"action"`
+
+	// Time is when it happened
+	Time metav1.Time `json:"time"`
+
+	// +optional
+	// Actor is the field manager of the last change of the spec, e.g.
+	// "kubectl-client-side-apply", for the spec changes, and the field manager
+	// of the operator for the deliveries
+	Actor string `json:"actor,omitempty"`
+
+	// Generation is the generation of the Simple
+	Generation int64 `json:"generation"`
+
+	// SpecHash is the SHA-256 of the fields of the spec making up the messages
+	SpecHash string `json:"specHash"`
+
+	// +optional
+	// MessageHash is the SHA-256 of the delivered messages
+	MessageHash string `json:"messageHash,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Enum=Delivered;Failed
+	// Outcome is the result of a delivery
+	Outcome string `json:"outcome,omitempty"`
+
+	// +optional
+	// Message explains failed deliveries
+	Message string `json:"message,omitempty"`
+
+	// +optional
+	// Sinks is the outcome of the delivery to each sink
+	Sinks []AuditSinkOutcome `json:"sinks,omitempty"`
+}
+
+// AuditAction is the kind of change recorded by a SimpleAudit
+type AuditAction string
+
+// Actions recorded in SimpleAuditSpec.Action
+const (
+	AuditActionSpecChanged AuditAction = "SpecChanged"
+	AuditActionDelivery    AuditAction = "Delivery"
+	AuditActionDeleted     AuditAction = "Deleted"
+)
+
+// AuditSinkOutcome is the outcome of a delivery to one sink.
+type AuditSinkOutcome struct {
+	// Name identifies the sink, e.g. "slack"
+	Name string `json:"name"`
+
+	// Delivered is true when the sink received the messages
+	Delivered bool `json:"delivered"`
+
+	// +optional
+	// Attempts is the number of attempts of the delivery
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// +optional
+	// ResponseCode is the HTTP status code of the last attempt
+	ResponseCode int32 `json:"responseCode,omitempty"`
+}
+
+// AuditSimpleLabel holds the name of the audited Simple on its SimpleAudits,
+// truncated and suffixed with its hash beyond 63 characters
+const AuditSimpleLabel = "simple.example.com/audited-simple"
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=smpaudit
+// +kubebuilder:printcolumn:name="Simple",type=string,JSONPath=`.spec.simpleName`
+// +kubebuilder:printcolumn:name="Action",type=string,JSONPath=`.spec.action`
+// +kubebuilder:printcolumn:name="Actor",type=string,JSONPath=`.spec.actor`
+// +kubebuilder:printcolumn:name="Outcome",type=string,JSONPath=`.spec.outcome`
+// +kubebuilder:printcolumn:name="Time",type=date,JSONPath=`.spec.time`
+
+// SimpleAudit is the Schema for the simpleaudits API. The controller appends
+// one for every spec change, delivery attempt and deletion of a Simple when
+// auditing is enabled, and prunes them after the retention period.
+type SimpleAudit struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	// spec is the audit entry
+	Spec SimpleAuditSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// SimpleAuditList contains a list of SimpleAudit
+type SimpleAuditList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SimpleAudit `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SimpleAudit{}, &SimpleAuditList{})
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SimpleDigestSpec defines the desired state of SimpleDigest
+type SimpleDigestSpec struct {
+	// +optional
+	// Selector selects the Simples of the namespace to aggregate. All of them
+	// are aggregated when unset.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:MaxLength=253
+	// ConfigMapName is the name of the digest ConfigMap, defaults to
+	// <name>-digest
+	ConfigMapName string `json:"configMapName,omitempty"`
+}
+
+// SimpleDigestStatus defines the observed state of SimpleDigest
+type SimpleDigestStatus struct {
+	// +optional
+	// ObservedGeneration is the generation last reconciled by the controller
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// Conditions represent the latest available observations of the SimpleDigest's state
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// +optional
+	// ConfigMapName is the name of the digest ConfigMap
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// +optional
+	// Simples is the number of Simples in the digest
+	Simples int32 `json:"simples,omitempty"`
+
+	// +optional
+	// Messages is the number of messages in the digest
+	Messages int32 `json:"messages,omitempty"`
+}
+
+// ReasonDigestUpdated means the digest ConfigMap holds the messages of every selected Simple
+const ReasonDigestUpdated = "DigestUpdated"
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=smpdigest
+// +kubebuilder:printcolumn:name="ConfigMap",type=string,JSONPath=`.status.configMapName`
+// +kubebuilder:printcolumn:name="Simples",type=integer,JSONPath=`.status.simples`
+// +kubebuilder:printcolumn:name="Messages",type=integer,JSONPath=`.status.messages`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// SimpleDigest is the Schema for the simpledigests API. It aggregates the
+// delivered messages of the Simples of its namespace in a single ConfigMap.
+type SimpleDigest struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	// spec defines the desired state of SimpleDigest
+	// +optional
+	Spec SimpleDigestSpec `json:"spec,omitempty,omitzero"`
+
+	// status defines the observed state of SimpleDigest
+	// +optional
+	Status SimpleDigestStatus `json:"status,omitempty,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// SimpleDigestList contains a list of SimpleDigest
+type SimpleDigestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SimpleDigest `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SimpleDigest{}, &SimpleDigestList{})
+}
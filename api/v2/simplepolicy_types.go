@@ -0,0 +1,82 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SimplePolicySpec defines the constraints on the Simples a SimplePolicy selects
+type SimplePolicySpec struct {
+	// +optional
+	// Selector selects the Simples the policy applies to by their labels.
+	// When unset the policy applies to every Simple.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// +optional
+	// AllowedNamespaces are the namespaces Simples may be created in. When
+	// empty Simples are allowed in every namespace.
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+
+	// +optional
+	// AllowedPatterns are regular expressions every message must match one of.
+	// When empty any message is allowed.
+	AllowedPatterns []string `json:"allowedPatterns,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// MaxMessageLength is the maximum length in bytes of a message
+	MaxMessageLength *int32 `json:"maxMessageLength,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:items:Enum=slack;http;nats;kafka;email
+	// AllowedSinks are the sinks Simples may deliver to. When empty every sink
+	// is allowed.
+	AllowedSinks []string `json:"allowedSinks,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=smppol
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// SimplePolicy is the Schema for the simplepolicies API. It lets cluster
+// admins constrain the Simples of the cluster; the validating webhook and the
+// controller reject Simples violating any policy that selects them.
+type SimplePolicy struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	// spec defines the constraints of SimplePolicy
+	// +required
+	Spec SimplePolicySpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// SimplePolicyList contains a list of SimplePolicy
+type SimplePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SimplePolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SimplePolicy{}, &SimplePolicyList{})
+}
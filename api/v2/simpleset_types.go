@@ -0,0 +1,157 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SimpleTemplate describes the Simples created by a SimpleSet
+type SimpleTemplate struct {
+	// +optional
+	// Labels are set on every Simple, along with the labels of its target
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// +optional
+	// Annotations are set on every Simple
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Spec is the spec of every Simple. Its messages may use the labels of a
+	// target through the {{ .Labels }} template data.
+	Spec SimpleSpec `json:"spec"`
+}
+
+// SimpleSetTarget is a Simple to create
+type SimpleSetTarget struct {
+	// +kubebuilder:validation:MinLength=1
+	// Name is the name of the Simple
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:MinLength=1
+	// Namespace is the namespace of the Simple
+	Namespace string `json:"namespace"`
+
+	// +optional
+	// Labels are added to the labels of the template
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// SimpleSetSpec defines the desired state of SimpleSet
+// +kubebuilder:validation:XValidation:rule="(has(self.targets) && size(self.targets) > 0) || has(self.namespaceSelector)",message="targets or namespaceSelector must be set"
+type SimpleSetSpec struct {
+	// Template is the Simple created for every target
+	Template SimpleTemplate `json:"template"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=namespace
+	// +listMapKey=name
+	// Targets are the Simples to create
+	Targets []SimpleSetTarget `json:"targets,omitempty"`
+
+	// +optional
+	// NamespaceSelector creates a Simple named after the SimpleSet in every
+	// matching namespace. An empty selector selects every namespace.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// SimpleSetChildStatus reports a Simple created by a SimpleSet
+type SimpleSetChildStatus struct {
+	// Name is the name of the Simple
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the Simple
+	Namespace string `json:"namespace"`
+
+	// +optional
+	// Phase is the phase of the Simple
+	Phase SimplePhase `json:"phase,omitempty"`
+
+	// Ready is true while the Simple is Ready
+	Ready bool `json:"ready"`
+}
+
+// SimpleSetStatus defines the observed state of SimpleSet
+type SimpleSetStatus struct {
+	// +optional
+	// ObservedGeneration is the generation last reconciled by the controller
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// Conditions represent the latest available observations of the SimpleSet's state
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// +optional
+	// Children reports every Simple of the SimpleSet
+	Children []SimpleSetChildStatus `json:"children,omitempty"`
+
+	// +optional
+	// Replicas is the number of Simples of the SimpleSet
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// +optional
+	// ReadyReplicas is the number of Ready Simples of the SimpleSet
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+}
+
+// Condition reasons reported in SimpleSetStatus.Conditions
+const (
+	// ReasonChildrenReady means every Simple of the SimpleSet is Ready
+	ReasonChildrenReady = "ChildrenReady"
+	// ReasonChildrenNotReady means some Simples of the SimpleSet are not Ready yet
+	ReasonChildrenNotReady = "ChildrenNotReady"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=smpset
+// +kubebuilder:printcolumn:name="Replicas",type=integer,JSONPath=`.status.replicas`
+// +kubebuilder:printcolumn:name="Ready",type=integer,JSONPath=`.status.readyReplicas`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// SimpleSet is the Schema for the simplesets API. It creates and owns a
+// Simple from its template for every target and selected namespace.
+type SimpleSet struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	// spec defines the desired state of SimpleSet
+	// +required
+	Spec SimpleSetSpec `json:"spec"`
+
+	// status defines the observed state of SimpleSet
+	// +optional
+	Status SimpleSetStatus `json:"status,omitempty,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// SimpleSetList contains a list of SimpleSet
+type SimpleSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SimpleSet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SimpleSet{}, &SimpleSetList{})
+}
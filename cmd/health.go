@@ -0,0 +1,159 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+
+	demov1 "github.com/leobip/demo-operator/api/v1"
+	demov2 "github.com/leobip/demo-operator/api/v2"
+	"github.com/leobip/demo-operator/internal/selfcheck"
+)
+
+// requiredCRDs are the CRDs the controllers of the operator watch
+var requiredCRDs = []string{
+	"simples.demo.demo.local",
+	"clustersimples.demo.demo.local",
+	"simplesets.demo.demo.local",
+	"simpledigests.demo.demo.local",
+}
+
+// expectedCRDs are the CRDs the self-check compares with the API types the
+// operator was built with
+var expectedCRDs = []selfcheck.CRD{
+	{
+		Name:           "simples.demo.demo.local",
+		StorageVersion: "v2",
+		Versions:       map[string]runtime.Object{"v1": &demov1.Simple{}, "v2": &demov2.Simple{}},
+	},
+	{
+		Name:           "clustersimples.demo.demo.local",
+		StorageVersion: "v2",
+		Versions:       map[string]runtime.Object{"v2": &demov2.ClusterSimple{}},
+	},
+	{
+		Name:           "simplesets.demo.demo.local",
+		StorageVersion: "v2",
+		Versions:       map[string]runtime.Object{"v2": &demov2.SimpleSet{}},
+	},
+	{
+		Name:           "simpledigests.demo.demo.local",
+		StorageVersion: "v2",
+		Versions:       map[string]runtime.Object{"v2": &demov2.SimpleDigest{}},
+	},
+	{
+		Name:           "simpleaudits.demo.demo.local",
+		StorageVersion: "v2",
+		Versions:       map[string]runtime.Object{"v2": &demov2.SimpleAudit{}},
+	},
+	{
+		Name:           "simplepolicies.demo.demo.local",
+		StorageVersion: "v2",
+		Versions:       map[string]runtime.Object{"v2": &demov2.SimplePolicy{}},
+	},
+}
+
+// cacheSyncChecker is ready once the informers of c are synced.
+func cacheSyncChecker(c cache.Cache) healthz.Checker {
+	var synced atomic.Bool
+	return func(req *http.Request) error {
+		if synced.Load() {
+			return nil
+		}
+		ctx, cancel := context.WithTimeout(req.Context(), time.Second)
+		defer cancel()
+		if !c.WaitForCacheSync(ctx) {
+			return errors.New("the informer caches are not synced yet")
+		}
+		synced.Store(true)
+		return nil
+	}
+}
+
+// webhookChecker is ready once the webhook server listening on port serves
+// a certificate that is currently valid.
+func webhookChecker(port int) healthz.Checker {
+	return func(_ *http.Request) error {
+		dialer := &net.Dialer{Timeout: time.Second}
+		// Only the validity period of the certificate is checked: it is
+		// issued for the webhook Service, not localhost
+		conn, err := tls.DialWithDialer(dialer, "tcp", net.JoinHostPort("localhost", strconv.Itoa(port)),
+			&tls.Config{InsecureSkipVerify: true}) //nolint:gosec
+		if err != nil {
+			return fmt.Errorf("webhook server not serving: %w", err)
+		}
+		defer func() { _ = conn.Close() }()
+		certs := conn.ConnectionState().PeerCertificates
+		if len(certs) == 0 {
+			return errors.New("the webhook server serves no certificate")
+		}
+		if now := time.Now(); now.Before(certs[0].NotBefore) || now.After(certs[0].NotAfter) {
+			return fmt.Errorf("the webhook certificate is only valid from %s to %s",
+				certs[0].NotBefore.Format(time.RFC3339), certs[0].NotAfter.Format(time.RFC3339))
+		}
+		return nil
+	}
+}
+
+// crdChecker is ready once the requiredCRDs are established.
+func crdChecker(reader client.Reader) healthz.Checker {
+	var established atomic.Bool
+	return func(req *http.Request) error {
+		if established.Load() {
+			return nil
+		}
+		for _, name := range requiredCRDs {
+			crd := &unstructured.Unstructured{}
+			crd.SetAPIVersion("apiextensions.k8s.io/v1")
+			crd.SetKind("CustomResourceDefinition")
+			if err := reader.Get(req.Context(), types.NamespacedName{Name: name}, crd); err != nil {
+				return fmt.Errorf("getting CRD %s: %w", name, err)
+			}
+			if !crdEstablished(crd) {
+				return fmt.Errorf("CRD %s is not established yet", name)
+			}
+		}
+		established.Store(true)
+		return nil
+	}
+}
+
+// crdEstablished reports whether the Established condition of crd is True.
+func crdEstablished(crd *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(crd.Object, "status", "conditions")
+	for _, c := range conditions {
+		if condition, ok := c.(map[string]any); ok && condition["type"] == "Established" {
+			return condition["status"] == "True"
+		}
+	}
+	return false
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// runCreate creates a Simple delivering the --message flags.
+func runCreate(ctx context.Context, args []string, out io.Writer) error {
+	var o clientOptions
+	fs := newFlagSet("create", &o)
+	var messages []string
+	fs.Func("message", "A message to deliver. May be repeated.", func(text string) error {
+		messages = append(messages, text)
+		return nil
+	})
+	interval := fs.Duration("interval", 0, "Deliver the messages again at this interval. Once by default.")
+	name, err := parseName(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(messages) == 0 {
+		return errors.New("--message is required")
+	}
+	c, namespace, err := o.client()
+	if err != nil {
+		return err
+	}
+
+	simple := &demov2.Simple{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	if len(messages) == 1 {
+		simple.Spec.Message = messages[0]
+	} else {
+		for _, text := range messages {
+			simple.Spec.Messages = append(simple.Spec.Messages, demov2.MessageSpec{Text: text})
+		}
+	}
+	if *interval > 0 {
+		simple.Spec.Interval = &metav1.Duration{Duration: *interval}
+	}
+	if err := c.Create(ctx, simple); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(out, "simple.%s/%s created\n", demov2.GroupVersion.Group, name)
+	return err
+}
+
+// runStatus prints the state of a Simple: its messages, conditions and history.
+func runStatus(ctx context.Context, args []string, out io.Writer) error {
+	var o clientOptions
+	name, err := parseName(newFlagSet("status", &o), args)
+	if err != nil {
+		return err
+	}
+	c, namespace, err := o.client()
+	if err != nil {
+		return err
+	}
+	var simple demov2.Simple
+	if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, &simple); err != nil {
+		return err
+	}
+	return printStatus(out, &simple, time.Now())
+}
+
+// printStatus writes the status of simple to out, with the ages relative to now.
+func printStatus(out io.Writer, simple *demov2.Simple, now time.Time) error {
+	age := func(t *metav1.Time) string {
+		if t == nil || t.IsZero() {
+			return "<none>"
+		}
+		if t.After(now) {
+			return "in " + duration.HumanDuration(t.Sub(now))
+		}
+		return duration.HumanDuration(now.Sub(t.Time)) + " ago"
+	}
+	status := simple.Status
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "Name:\t%s\n", simple.Name)
+	fmt.Fprintf(w, "Namespace:\t%s\n", simple.Namespace)
+	fmt.Fprintf(w, "Phase:\t%s\n", status.Phase)
+	fmt.Fprintf(w, "Replies:\t%d\n", status.ReplyCount)
+	fmt.Fprintf(w, "Last reply:\t%s\n", age(status.LastRepliedTime))
+	fmt.Fprintf(w, "Next reply:\t%s\n", age(status.NextReplyTime))
+	if resend, ok := simple.ResendRequested(); ok {
+		fmt.Fprintf(w, "Last resend:\t%s (%s pending)\n", orNone(status.LastResend), resend)
+	} else {
+		fmt.Fprintf(w, "Last resend:\t%s\n", orNone(status.LastResend))
+	}
+
+	if len(status.Messages) > 0 {
+		fmt.Fprintln(w, "\nMessages:")
+		fmt.Fprintln(w, "  NAME\tDELIVERED\tTIME\tMESSAGE")
+		for _, m := range status.Messages {
+			fmt.Fprintf(w, "  %s\t%t\t%s\t%s\n", orNone(m.Name), m.Delivered, age(m.DeliveredTime), m.Message)
+		}
+	}
+	if len(status.Conditions) > 0 {
+		fmt.Fprintln(w, "\nConditions:")
+		fmt.Fprintln(w, "  TYPE\tSTATUS\tREASON\tAGE\tMESSAGE")
+		for _, c := range status.Conditions {
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", c.Type, c.Status, c.Reason, age(&c.LastTransitionTime), c.Message)
+		}
+	}
+	if len(status.History) > 0 {
+		fmt.Fprintln(w, "\nHistory:")
+		fmt.Fprintln(w, "  TIME\tOUTCOME\tMESSAGE")
+		for _, h := range status.History {
+			fmt.Fprintf(w, "  %s\t%s\t%s\n", age(&h.Time), h.Outcome, h.Message)
+		}
+	}
+	return w.Flush()
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
+
+// runResend makes the operator deliver the messages of a Simple again, with
+// a new value of its ResendAnnotation.
+func runResend(ctx context.Context, args []string, out io.Writer) error {
+	var o clientOptions
+	name, err := parseName(newFlagSet("resend", &o), args)
+	if err != nil {
+		return err
+	}
+	c, namespace, err := o.client()
+	if err != nil {
+		return err
+	}
+	simple := &demov2.Simple{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`,
+		demov2.ResendAnnotation, time.Now().UTC().Format(time.RFC3339Nano))
+	if err := c.Patch(ctx, simple, client.RawPatch(types.MergePatchType, []byte(patch))); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(out, "simple.%s/%s resend requested\n", demov2.GroupVersion.Group, name)
+	return err
+}
+
+// runTail prints the Events of a Simple, then the new ones as they are
+// emitted, until interrupted.
+func runTail(ctx context.Context, args []string, out io.Writer) error {
+	var o clientOptions
+	name, err := parseName(newFlagSet("tail", &o), args)
+	if err != nil {
+		return err
+	}
+	config, namespace, err := o.restConfig()
+	if err != nil {
+		return err
+	}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	events := clientset.CoreV1().Events(namespace)
+	selector := fields.Set{"involvedObject.kind": "Simple", "involvedObject.name": name}.String()
+
+	list, err := events.List(ctx, metav1.ListOptions{FieldSelector: selector})
+	if err != nil {
+		return err
+	}
+	sort.Slice(list.Items, func(i, j int) bool {
+		return eventTime(&list.Items[i]).Before(eventTime(&list.Items[j]))
+	})
+	for i := range list.Items {
+		printEvent(out, &list.Items[i])
+	}
+
+	watcher, err := events.Watch(ctx, metav1.ListOptions{FieldSelector: selector, ResourceVersion: list.ResourceVersion})
+	if err != nil {
+		return err
+	}
+	defer watcher.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e, ok := <-watcher.ResultChan():
+			if !ok {
+				return errors.New("the watch of the Events ended, run tail again")
+			}
+			if event, isEvent := e.Object.(*corev1.Event); isEvent && (e.Type == watch.Added || e.Type == watch.Modified) {
+				printEvent(out, event)
+			}
+		}
+	}
+}
+
+// eventTime returns when event last occurred.
+func eventTime(event *corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+func printEvent(out io.Writer, event *corev1.Event) {
+	count := ""
+	if event.Count > 1 {
+		count = fmt.Sprintf(" (x%d)", event.Count)
+	}
+	fmt.Fprintf(out, "%s  %-7s  %s: %s%s\n", eventTime(event).Local().Format(time.RFC3339),
+		event.Type, event.Reason, strings.TrimSpace(event.Message), count)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+var _ = Describe("status", func() {
+	now := time.Date(2026, 10, 14, 8, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *metav1.Time {
+		t := metav1.NewTime(now.Add(d))
+		return &t
+	}
+
+	DescribeTable("printing the status of a Simple",
+		func(simple demov2.Simple, lines ...string) {
+			simple.Name = "alerts"
+			simple.Namespace = "team-a"
+			var out bytes.Buffer
+			Expect(printStatus(&out, &simple, now)).To(Succeed())
+			Expect(out.String()).To(ContainSubstring("Name:         alerts\n"))
+			Expect(out.String()).To(ContainSubstring("Namespace:    team-a\n"))
+			for _, line := range lines {
+				Expect(out.String()).To(ContainSubstring(line))
+			}
+		},
+		Entry("never replied", demov2.Simple{Status: demov2.SimpleStatus{Phase: demov2.PhasePending}},
+			"Phase:        Pending\n", "Replies:      0\n", "Last reply:   <none>\n", "Last resend:  <none>\n"),
+		Entry("replied", demov2.Simple{Status: demov2.SimpleStatus{
+			Phase:           demov2.PhaseReplied,
+			ReplyCount:      2,
+			LastRepliedTime: at(-5 * time.Minute),
+			NextReplyTime:   at(time.Hour),
+			Messages: []demov2.MessageStatus{
+				{Name: "greeting", Message: "hello", Delivered: true, DeliveredTime: at(-5 * time.Minute)},
+				{Message: "world"},
+			},
+			Conditions: []metav1.Condition{
+				{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Replied", LastTransitionTime: *at(-time.Minute), Message: "Delivered"},
+			},
+			History: []demov2.HistoryEntry{
+				{Time: *at(-5 * time.Minute), Outcome: demov2.HistoryOutcomeDelivered, Message: "Delivered 2 message(s)"},
+			},
+		}},
+			"Replies:      2\n", "Last reply:   5m ago\n", "Next reply:   in 60m\n",
+			"  greeting  true       5m ago  hello\n", "  <none>    false      <none>  world\n",
+			"  Ready  True    Replied  60s ago  Delivered\n",
+			"  5m ago  Delivered  Delivered 2 message(s)\n"),
+		Entry("resent", demov2.Simple{
+			ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{demov2.ResendAnnotation: "1"}},
+			Status:     demov2.SimpleStatus{LastResend: "1"},
+		}, "Last resend:  1\n"),
+		Entry("resend pending", demov2.Simple{
+			ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{demov2.ResendAnnotation: "2"}},
+			Status:     demov2.SimpleStatus{LastResend: "1"},
+		}, "Last resend:  1 (2 pending)\n"),
+	)
+
+	DescribeTable("parsing the name of a Simple",
+		func(args []string, name, namespace, errorMessage string) {
+			var o clientOptions
+			fs := newFlagSet("status", &o)
+			fs.SetOutput(io.Discard)
+			parsed, err := parseName(fs, args)
+			if errorMessage != "" {
+				Expect(err).To(MatchError(ContainSubstring(errorMessage)))
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed).To(Equal(name))
+			Expect(o.namespace).To(Equal(namespace))
+		},
+		Entry("a name", []string{"alerts"}, "alerts", "", ""),
+		Entry("flags before the name", []string{"-n", "team-a", "alerts"}, "alerts", "team-a", ""),
+		Entry("flags after the name", []string{"alerts", "--namespace", "team-a"}, "alerts", "team-a", ""),
+		Entry("no name", []string{"-n", "team-a"}, "", "", "expected the name of a Simple"),
+		Entry("two names", []string{"alerts", "other"}, "", "", "expected the name of a Simple"),
+		Entry("an unknown flag", []string{"alerts", "--unknown"}, "", "", "flag provided but not defined"),
+	)
+})
@@ -0,0 +1,165 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command kubectl-simple is a kubectl plugin making the Simples easier to
+// work with than their YAML. Installed on the PATH, it runs as:
+//
+//	kubectl simple create NAME --message TEXT [--message TEXT...] [--interval DURATION]
+//	kubectl simple status NAME
+//	kubectl simple resend NAME
+//	kubectl simple tail NAME
+//	kubectl simple migrate export [-A] [-l SELECTOR] [-o yaml|json] [--api-version v1|v2]
+//	kubectl simple migrate import -f FILE [--namespace-map OLD=NEW...] [--overwrite]
+//
+// Every command takes the -n/--namespace, --kubeconfig and --context flags of kubectl.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+const usage = `kubectl simple manages the Simples of the simple-operator.
+
+Usage:
+  kubectl simple create NAME --message TEXT [--message TEXT...] [--interval DURATION]
+  kubectl simple status NAME
+  kubectl simple resend NAME
+  kubectl simple tail NAME
+  kubectl simple migrate export [-A] [-l SELECTOR] [-o yaml|json] [--api-version v1|v2]
+  kubectl simple migrate import -f FILE [--namespace-map OLD=NEW...] [--overwrite]
+
+Flags of every command:
+  -n, --namespace   the namespace of the Simple, defaults to the one of the kubeconfig context
+  --kubeconfig      the kubeconfig file, defaults to $KUBECONFIG or ~/.kube/config
+  --context         the kubeconfig context, defaults to the current one
+`
+
+// commands are the subcommands, by name
+var commands = map[string]func(ctx context.Context, args []string, out io.Writer) error{
+	"create":  runCreate,
+	"status":  runStatus,
+	"resend":  runResend,
+	"tail":    runTail,
+	"migrate": runMigrate,
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := run(ctx, os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		stop()
+		os.Exit(1)
+	}
+}
+
+// run runs the subcommand of args.
+func run(ctx context.Context, args []string, out io.Writer) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		_, err := fmt.Fprint(out, usage)
+		return err
+	}
+	command, ok := commands[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown command %q, see kubectl simple --help", args[0])
+	}
+	return command(ctx, args[1:], out)
+}
+
+// clientOptions are the flags selecting the cluster and the namespace.
+type clientOptions struct {
+	namespace, kubeconfig, context string
+}
+
+// newFlagSet returns the flags of the subcommand name, with the client flags.
+func newFlagSet(name string, o *clientOptions) *flag.FlagSet {
+	fs := flag.NewFlagSet("kubectl simple "+name, flag.ContinueOnError)
+	fs.StringVar(&o.namespace, "namespace", "", "The namespace of the Simple.")
+	fs.StringVar(&o.namespace, "n", "", "The namespace of the Simple (shorthand).")
+	fs.StringVar(&o.kubeconfig, "kubeconfig", "", "The kubeconfig file.")
+	fs.StringVar(&o.context, "context", "", "The kubeconfig context.")
+	return fs
+}
+
+// parseName parses args with fs, flags being allowed after the name of the
+// Simple as with kubectl, and returns the name.
+func parseName(fs *flag.FlagSet, args []string) (string, error) {
+	var names []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return "", err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		names = append(names, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(names) != 1 {
+		return "", errors.New("expected the name of a Simple")
+	}
+	return names[0], nil
+}
+
+// restConfig returns the configuration of the cluster and the namespace of o.
+func (o *clientOptions) restConfig() (*rest.Config, string, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = o.kubeconfig
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: o.context}
+	overrides.Context.Namespace = o.namespace
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides)
+	config, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, "", err
+	}
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil {
+		return nil, "", err
+	}
+	return config, namespace, nil
+}
+
+// client returns a client of the Simples of the cluster of o, and the namespace of o.
+func (o *clientOptions) client() (client.Client, string, error) {
+	config, namespace, err := o.restConfig()
+	if err != nil {
+		return nil, "", err
+	}
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return nil, "", err
+	}
+	if err := demov2.AddToScheme(scheme); err != nil {
+		return nil, "", err
+	}
+	c, err := client.New(config, client.Options{Scheme: scheme})
+	return c, namespace, err
+}
@@ -0,0 +1,352 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	demov1 "github.com/leobip/demo-operator/api/v1"
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// simpleList is the document written by migrate export, a List of Simples
+// kubectl also reads.
+type simpleList struct {
+	metav1.TypeMeta `json:",inline"`
+	Items           []json.RawMessage `json:"items"`
+}
+
+// runMigrate runs migrate export or migrate import.
+func runMigrate(ctx context.Context, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("expected migrate export or migrate import")
+	}
+	switch args[0] {
+	case "export":
+		return runExport(ctx, args[1:], out)
+	case "import":
+		return runImport(ctx, args[1:], out)
+	}
+	return fmt.Errorf("unknown migrate command %q, expected export or import", args[0])
+}
+
+// runExport writes the Simples of a namespace, or of all of them, with their
+// status as a List to out.
+func runExport(ctx context.Context, args []string, out io.Writer) error {
+	var o clientOptions
+	fs := newFlagSet("migrate export", &o)
+	var allNamespaces bool
+	fs.BoolVar(&allNamespaces, "all-namespaces", false, "Export the Simples of every namespace.")
+	fs.BoolVar(&allNamespaces, "A", false, "Export the Simples of every namespace (shorthand).")
+	selector := fs.String("selector", "", "Only export the Simples matching this label selector.")
+	fs.StringVar(selector, "l", "", "Only export the Simples matching this label selector (shorthand).")
+	output := fs.String("output", "yaml", "The format of the export, yaml or json.")
+	fs.StringVar(output, "o", "yaml", "The format of the export, yaml or json (shorthand).")
+	apiVersion := fs.String("api-version", "v2", "The API version the Simples are exported as, v1 or v2.")
+	if err := parseNone(fs, args); err != nil {
+		return err
+	}
+	if *output != "yaml" && *output != "json" {
+		return fmt.Errorf("unknown --output %q, expected yaml or json", *output)
+	}
+	if *apiVersion != "v1" && *apiVersion != "v2" {
+		return fmt.Errorf("unknown --api-version %q, expected v1 or v2", *apiVersion)
+	}
+	labelSelector, err := labels.Parse(*selector)
+	if err != nil {
+		return fmt.Errorf("parsing --selector: %w", err)
+	}
+	c, namespace, err := o.client()
+	if err != nil {
+		return err
+	}
+	if allNamespaces {
+		namespace = ""
+	}
+
+	var simples demov2.SimpleList
+	if err := c.List(ctx, &simples, client.InNamespace(namespace),
+		client.MatchingLabelsSelector{Selector: labelSelector}); err != nil {
+		return err
+	}
+	data, err := exportSimples(simples.Items, *apiVersion, *output)
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(data)
+	return err
+}
+
+// exportSimples encodes simples as a List of apiVersion in the format
+// output, sorted by namespace and name. Only the metadata meaningful in
+// another cluster are kept: the name, namespace, labels and annotations.
+func exportSimples(simples []demov2.Simple, apiVersion, output string) ([]byte, error) {
+	slices.SortFunc(simples, func(a, b demov2.Simple) int {
+		return strings.Compare(a.Namespace+"/"+a.Name, b.Namespace+"/"+b.Name)
+	})
+	list := simpleList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "List"}, Items: []json.RawMessage{}}
+	for _, simple := range simples {
+		exported := demov2.Simple{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        simple.Name,
+				Namespace:   simple.Namespace,
+				Labels:      simple.Labels,
+				Annotations: simple.Annotations,
+			},
+			Spec:   simple.Spec,
+			Status: simple.Status,
+		}
+		var object any = &exported
+		exported.SetGroupVersionKind(demov2.GroupVersion.WithKind("Simple"))
+		if apiVersion == "v1" {
+			var v1 demov1.Simple
+			if err := v1.ConvertFrom(&exported); err != nil {
+				return nil, fmt.Errorf("converting Simple %s/%s to v1: %w", simple.Namespace, simple.Name, err)
+			}
+			v1.SetGroupVersionKind(demov1.GroupVersion.WithKind("Simple"))
+			object = &v1
+		}
+		item, err := json.Marshal(object)
+		if err != nil {
+			return nil, err
+		}
+		list.Items = append(list.Items, item)
+	}
+	if output == "json" {
+		data, err := json.MarshalIndent(list, "", "  ")
+		return append(data, '\n'), err
+	}
+	return yaml.Marshal(list)
+}
+
+// runImport creates, or with --overwrite updates, the Simples of a file
+// written by migrate export, and restores their status.
+func runImport(ctx context.Context, args []string, out io.Writer) error {
+	var o clientOptions
+	fs := newFlagSet("migrate import", &o)
+	filename := fs.String("filename", "", "The file written by migrate export, - for the standard input.")
+	fs.StringVar(filename, "f", "", "The file written by migrate export, - for the standard input (shorthand).")
+	namespaces := map[string]string{}
+	fs.Func("namespace-map", "Import the Simples of namespace OLD into namespace NEW, as OLD=NEW. May be repeated.",
+		func(mapping string) error {
+			from, to, ok := strings.Cut(mapping, "=")
+			if !ok || from == "" || to == "" {
+				return fmt.Errorf("expected OLD=NEW, got %q", mapping)
+			}
+			namespaces[from] = to
+			return nil
+		})
+	overwrite := fs.Bool("overwrite", false, "Update the Simples that already exist instead of skipping them.")
+	if err := parseNone(fs, args); err != nil {
+		return err
+	}
+	if *filename == "" {
+		return errors.New("--filename is required")
+	}
+	var data []byte
+	var err error
+	if *filename == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(*filename)
+	}
+	if err != nil {
+		return err
+	}
+	c, namespace, err := o.client()
+	if err != nil {
+		return err
+	}
+
+	simples, err := decodeSimples(data)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for i := range simples {
+		simple := &simples[i]
+		simple.Namespace = importNamespace(simple.Namespace, namespace, namespaces)
+		result, err := importSimple(ctx, c, simple, *overwrite)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("importing Simple %s/%s: %w", simple.Namespace, simple.Name, err))
+			continue
+		}
+		fmt.Fprintf(out, "simple.%s/%s %s in namespace %s\n", demov2.GroupVersion.Group, simple.Name, result, simple.Namespace)
+	}
+	return errors.Join(errs...)
+}
+
+// importNamespace returns the namespace a Simple exported from namespace is
+// imported into: defaultNamespace if it has none, then mapped by namespaces.
+func importNamespace(namespace, defaultNamespace string, namespaces map[string]string) string {
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	if to, ok := namespaces[namespace]; ok {
+		return to
+	}
+	return namespace
+}
+
+// decodeSimples decodes the Simples of a List, or a single Simple, in YAML
+// or JSON, converting the v1 ones to v2.
+func decodeSimples(data []byte) ([]demov2.Simple, error) {
+	data, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	var list simpleList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	items := list.Items
+	if list.Kind != "List" {
+		items = []json.RawMessage{data}
+	}
+
+	simples := make([]demov2.Simple, 0, len(items))
+	for i, item := range items {
+		var meta metav1.TypeMeta
+		if err := json.Unmarshal(item, &meta); err != nil {
+			return nil, fmt.Errorf("decoding item %d: %w", i, err)
+		}
+		var simple demov2.Simple
+		switch {
+		case meta.Kind != "Simple":
+			return nil, fmt.Errorf("item %d is a %s, not a Simple", i, meta.Kind)
+		case meta.APIVersion == demov2.GroupVersion.String():
+			if err := json.Unmarshal(item, &simple); err != nil {
+				return nil, fmt.Errorf("decoding item %d: %w", i, err)
+			}
+		case meta.APIVersion == demov1.GroupVersion.String():
+			var v1 demov1.Simple
+			if err := json.Unmarshal(item, &v1); err != nil {
+				return nil, fmt.Errorf("decoding item %d: %w", i, err)
+			}
+			if err := v1.ConvertTo(&simple); err != nil {
+				return nil, fmt.Errorf("converting item %d to v2: %w", i, err)
+			}
+		default:
+			return nil, fmt.Errorf("item %d has the unknown apiVersion %q", i, meta.APIVersion)
+		}
+		simples = append(simples, simple)
+	}
+	return simples, nil
+}
+
+// importSimple creates simple, or updates it if overwrite is set, then
+// restores its status. The Simple is paused meanwhile so the operator doesn't
+// deliver the messages already delivered in the other cluster. It stays
+// paused if it was paused in the other cluster, or already was in this one.
+func importSimple(ctx context.Context, c client.Client, simple *demov2.Simple, overwrite bool) (string, error) {
+	status := simple.Status
+	paused := simple.Annotations[demov2.PausedAnnotation] == "true"
+	imported := &demov2.Simple{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        simple.Name,
+			Namespace:   simple.Namespace,
+			Labels:      simple.Labels,
+			Annotations: map[string]string{demov2.PausedAnnotation: "true"},
+		},
+		Spec: simple.Spec,
+	}
+	for k, v := range simple.Annotations {
+		if k != demov2.PausedAnnotation {
+			imported.Annotations[k] = v
+		}
+	}
+
+	result := "created"
+	err := c.Create(ctx, imported)
+	switch {
+	case apierrors.IsAlreadyExists(err) && !overwrite:
+		return "skipped, it already exists", nil
+	case apierrors.IsAlreadyExists(err):
+		result = "configured"
+		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			var existing demov2.Simple
+			if err := c.Get(ctx, client.ObjectKeyFromObject(imported), &existing); err != nil {
+				return err
+			}
+			if existing.Annotations[demov2.PausedAnnotation] == "true" {
+				paused = true
+			}
+			existing.Labels = imported.Labels
+			existing.Annotations = imported.Annotations
+			existing.Spec = imported.Spec
+			if err := c.Update(ctx, &existing); err != nil {
+				return err
+			}
+			imported = &existing
+			return nil
+		})
+	}
+	if err != nil {
+		return "", err
+	}
+
+	// The status describes the imported generation, whatever it was in the other cluster
+	if status.ObservedGeneration != 0 {
+		status.ObservedGeneration = imported.Generation
+	}
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var current demov2.Simple
+		if err := c.Get(ctx, client.ObjectKeyFromObject(imported), &current); err != nil {
+			return err
+		}
+		current.Status = status
+		return c.Status().Update(ctx, &current)
+	})
+	if err != nil {
+		return "", fmt.Errorf("restoring the status: %w", err)
+	}
+
+	if !paused {
+		patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:null}}}`, demov2.PausedAnnotation)
+		if err := c.Patch(ctx, imported, client.RawPatch(types.MergePatchType, []byte(patch))); err != nil {
+			return "", fmt.Errorf("resuming the Simple: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// parseNone parses args with fs, which must not hold any other argument.
+func parseNone(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	demov1 "github.com/leobip/demo-operator/api/v1"
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+var _ = Describe("migrate", func() {
+	newSimple := func(namespace, name string) demov2.Simple {
+		return demov2.Simple{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   namespace,
+				Labels:      map[string]string{"team": "a"},
+				Annotations: map[string]string{"note": "kept"},
+				UID:         "uid",
+			},
+			Spec: demov2.SimpleSpec{
+				Messages: []demov2.MessageSpec{{Name: "greeting", Text: "hello"}, {Text: "world"}},
+			},
+			Status: demov2.SimpleStatus{
+				Replied:            true,
+				ObservedGeneration: 3,
+				Messages:           []demov2.MessageStatus{{Message: "hello", Delivered: true}},
+			},
+		}
+	}
+
+	It("should round trip the Simples through v1 and v2", func() {
+		for _, apiVersion := range []string{"v1", "v2"} {
+			for _, output := range []string{"yaml", "json"} {
+				By("exporting as " + apiVersion + " in " + output)
+				simples := []demov2.Simple{newSimple("team-b", "foo"), newSimple("team-a", "bar")}
+				data, err := exportSimples(simples, apiVersion, output)
+				Expect(err).NotTo(HaveOccurred())
+
+				decoded, err := decodeSimples(data)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decoded).To(HaveLen(2))
+				Expect(decoded[0].Namespace + "/" + decoded[0].Name).To(Equal("team-a/bar"))
+				Expect(decoded[1].Namespace + "/" + decoded[1].Name).To(Equal("team-b/foo"))
+				for _, simple := range decoded {
+					// The spec hash is computed from the spec only
+					Expect(simple.Spec).To(Equal(newSimple("", "").Spec))
+					Expect(simple.Labels).To(Equal(map[string]string{"team": "a"}))
+					Expect(simple.Annotations).To(Equal(map[string]string{"note": "kept"}))
+					Expect(simple.UID).To(BeEmpty())
+					Expect(simple.Status.Messages).To(Equal(newSimple("", "").Status.Messages))
+				}
+			}
+		}
+	})
+
+	It("should decode a single Simple as well as a List", func() {
+		single := []byte(`apiVersion: ` + demov1.GroupVersion.String() + `
+kind: Simple
+metadata:
+  name: foo
+spec:
+  messages: [hello]
+`)
+		decoded, err := decodeSimples(single)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decoded).To(HaveLen(1))
+		Expect(decoded[0].Name).To(Equal("foo"))
+		Expect(decoded[0].Spec.Messages).To(Equal([]demov2.MessageSpec{{Text: "hello"}}))
+
+		data, err := exportSimples(nil, "v2", "yaml")
+		Expect(err).NotTo(HaveOccurred())
+		decoded, err = decodeSimples(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decoded).To(BeEmpty())
+
+		_, err = decodeSimples([]byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: foo\n"))
+		Expect(err).To(MatchError(ContainSubstring("not a Simple")))
+		_, err = decodeSimples([]byte("apiVersion: demo.demo.local/v3\nkind: Simple\n"))
+		Expect(err).To(MatchError(ContainSubstring("unknown apiVersion")))
+	})
+
+	It("should map the namespaces of the imported Simples", func() {
+		namespaces := map[string]string{"team-a": "team-a-prod", "default": "team-c"}
+		Expect(importNamespace("team-a", "default", namespaces)).To(Equal("team-a-prod"))
+		Expect(importNamespace("team-b", "default", namespaces)).To(Equal("team-b"))
+		Expect(importNamespace("", "team-b", namespaces)).To(Equal("team-b"))
+		Expect(importNamespace("", "default", namespaces)).To(Equal("team-c"))
+	})
+
+	Context("When importing a Simple", func() {
+		ctx := context.Background()
+		var c client.Client
+
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			Expect(demov2.AddToScheme(scheme)).To(Succeed())
+			c = fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&demov2.Simple{}).Build()
+		})
+
+		It("should restore the status and resume the Simple", func() {
+			simple := newSimple("default", "foo")
+			result, err := importSimple(ctx, c, &simple, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal("created"))
+
+			var imported demov2.Simple
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(&simple), &imported)).To(Succeed())
+			Expect(imported.Annotations).To(Equal(map[string]string{"note": "kept"}))
+			Expect(imported.Status.Messages).To(Equal(simple.Status.Messages))
+
+			By("skipping it once it exists")
+			result, err = importSimple(ctx, c, &simple, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(ContainSubstring("skipped"))
+		})
+
+		It("should keep the pause of an overwritten Simple", func() {
+			existing := newSimple("default", "foo")
+			existing.UID = ""
+			existing.Annotations = map[string]string{demov2.PausedAnnotation: "true"}
+			existing.Status = demov2.SimpleStatus{}
+			Expect(c.Create(ctx, &existing)).To(Succeed())
+
+			simple := newSimple("default", "foo")
+			result, err := importSimple(ctx, c, &simple, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal("configured"))
+
+			var imported demov2.Simple
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(&simple), &imported)).To(Succeed())
+			Expect(imported.Annotations).To(HaveKeyWithValue(demov2.PausedAnnotation, "true"))
+			Expect(imported.Annotations).To(HaveKeyWithValue("note", "kept"))
+			Expect(imported.Status.Messages).To(Equal(simple.Status.Messages))
+		})
+	})
+})
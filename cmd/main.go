@@ -17,29 +17,45 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	demov1 "github.com/leobip/demo-operator/api/v1"
+	demov2 "github.com/leobip/demo-operator/api/v2"
+	"github.com/leobip/demo-operator/internal/bulk"
+	"github.com/leobip/demo-operator/internal/certrotator"
 	"github.com/leobip/demo-operator/internal/controller"
+	"github.com/leobip/demo-operator/internal/selfcheck"
+	"github.com/leobip/demo-operator/internal/tracing"
+	webhookv2 "github.com/leobip/demo-operator/internal/webhook/v2"
 
 	// +kubebuilder:scaffold:imports
 	metricslibs "github.com/leobip/metrics-libs/libs"
@@ -54,45 +70,25 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(demov1.AddToScheme(scheme))
+	utilruntime.Must(demov2.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
 // nolint:gocyclo
 func main() {
-	var metricsAddr string
-	var metricsCertPath, metricsCertName, metricsCertKey string
-	var webhookCertPath, webhookCertName, webhookCertKey string
-	var enableLeaderElection bool
-	var probeAddr string
-	var secureMetrics bool
-	var enableHTTP2 bool
 	var tlsOpts []func(*tls.Config)
-	//flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
-	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metrics endpoint binds to. "+
-		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
-	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
-	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
-		"Enable leader election for controller manager. "+
-			"Enabling this will ensure there is only one active controller manager.")
-	//flag.BoolVar(&secureMetrics, "metrics-secure", true,
-	flag.BoolVar(&secureMetrics, "metrics-secure", false,
-		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
-	flag.StringVar(&webhookCertPath, "webhook-cert-path", "", "The directory that contains the webhook certificate.")
-	flag.StringVar(&webhookCertName, "webhook-cert-name", "tls.crt", "The name of the webhook certificate file.")
-	flag.StringVar(&webhookCertKey, "webhook-cert-key", "tls.key", "The name of the webhook key file.")
-	flag.StringVar(&metricsCertPath, "metrics-cert-path", "",
-		"The directory that contains the metrics server certificate.")
-	flag.StringVar(&metricsCertName, "metrics-cert-name", "tls.crt", "The name of the metrics server certificate file.")
-	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
-	flag.BoolVar(&enableHTTP2, "enable-http2", false,
-		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
-	opts := zap.Options{
-		Development: true,
-	}
-	opts.BindFlags(flag.CommandLine)
+	o := &options{}
+	o.bindFlags(flag.CommandLine)
 	flag.Parse()
 
-	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	o.syncLogLevel()
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&o.zap)))
+
+	if err := o.applyConfigFile(flag.CommandLine); err != nil {
+		setupLog.Error(err, "invalid --config")
+		os.Exit(1)
+	}
+	o.syncLogLevel()
 
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
@@ -105,24 +101,55 @@ func main() {
 		c.NextProtos = []string{"http/1.1"}
 	}
 
-	if !enableHTTP2 {
+	if !o.enableHTTP2 {
 		tlsOpts = append(tlsOpts, disableHTTP2)
 	}
 
+	// Create a new manager to provide shared dependencies and start components
+	restConfig := ctrl.GetConfigOrDie()
+	restConfig.QPS = float32(o.kubeAPIQPS)
+	restConfig.Burst = o.kubeAPIBurst
+
+	// Generate the webhook certificate before the webhook server reads it
+	var certRotator *certrotator.Rotator
+	if o.webhookCertRotation && o.dryRun {
+		setupLog.Info("Not rotating the webhook certificate in dry-run mode, the webhook server uses --webhook-cert-path")
+	}
+	if o.webhookCertRotation && !o.dryRun {
+		namespace, err := operatorNamespace()
+		if err != nil {
+			setupLog.Error(err, "unable to find the namespace of the webhook certificate")
+			os.Exit(1)
+		}
+		// The manager cache may not see the operator namespace, use a direct client
+		rotatorClient, err := client.New(restConfig, client.Options{Scheme: scheme})
+		if err != nil {
+			setupLog.Error(err, "unable to create the client of the webhook certificate rotator")
+			os.Exit(1)
+		}
+		certRotator = o.certRotator(rotatorClient, namespace)
+		if err := certRotator.Ensure(context.Background()); err != nil {
+			setupLog.Error(err, "unable to generate the webhook certificate")
+			os.Exit(1)
+		}
+		o.webhookCertPath = certRotator.CertDir
+	}
+
 	// Create watchers for metrics and webhooks certificates
 	var metricsCertWatcher, webhookCertWatcher *certwatcher.CertWatcher
 
 	// Initial webhook TLS options
 	webhookTLSOpts := tlsOpts
 
-	if len(webhookCertPath) > 0 {
+	if len(o.webhookCertPath) > 0 {
 		setupLog.Info("Initializing webhook certificate watcher using provided certificates",
-			"webhook-cert-path", webhookCertPath, "webhook-cert-name", webhookCertName, "webhook-cert-key", webhookCertKey)
+			"webhook-cert-path", o.webhookCertPath, "webhook-cert-name", o.webhookCertName,
+			"webhook-cert-key", o.webhookCertKey)
 
 		var err error
 		webhookCertWatcher, err = certwatcher.New(
-			filepath.Join(webhookCertPath, webhookCertName),
-			filepath.Join(webhookCertPath, webhookCertKey),
+			filepath.Join(o.webhookCertPath, o.webhookCertName),
+			filepath.Join(o.webhookCertPath, o.webhookCertKey),
 		)
 		if err != nil {
 			setupLog.Error(err, "Failed to initialize webhook certificate watcher")
@@ -143,17 +170,32 @@ func main() {
 	// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.21.0/pkg/metrics/server
 	// - https://book.kubebuilder.io/reference/metrics.html
 	metricsServerOptions := metricsserver.Options{
-		BindAddress:   metricsAddr,
-		SecureServing: secureMetrics,
+		BindAddress:   o.metricsAddr,
+		SecureServing: o.secureMetrics,
 		TLSOpts:       tlsOpts,
+		// GET returns the log level, PUT {"level":"debug"} changes it
+		ExtraHandlers: map[string]http.Handler{"/debug/loglevel": o.logLevel},
+	}
+	// POST applies a batch of Simples, the writes are authorized as the caller
+	bulkHandler := &bulk.Handler{
+		Config:       restConfig,
+		Scheme:       scheme,
+		FieldManager: o.fieldManager,
+		MaxItems:     o.bulkApplyMaxItems,
+	}
+	if o.bulkApply {
+		metricsServerOptions.ExtraHandlers[bulk.Path] = bulkHandler
 	}
 
-	if secureMetrics {
+	if o.metricsAuth {
 		// FilterProvider is used to protect the metrics endpoint with authn/authz.
 		// These configurations ensure that only authorized users and service accounts
 		// can access the metrics endpoint. The RBAC are configured in 'config/rbac/kustomization.yaml'. More info:
 		// https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.21.0/pkg/metrics/filters#WithAuthenticationAndAuthorization
 		metricsServerOptions.FilterProvider = filters.WithAuthenticationAndAuthorization
+		if !o.secureMetrics {
+			setupLog.Info("The metrics endpoint is served over HTTP, its bearer tokens are sent in clear text")
+		}
 	}
 
 	// If the certificate is not specified, controller-runtime will automatically
@@ -164,14 +206,15 @@ func main() {
 	// - [METRICS-WITH-CERTS] at config/default/kustomization.yaml to generate and use certificates
 	// managed by cert-manager for the metrics server.
 	// - [PROMETHEUS-WITH-CERTS] at config/prometheus/kustomization.yaml for TLS certification.
-	if len(metricsCertPath) > 0 {
+	if len(o.metricsCertPath) > 0 {
 		setupLog.Info("Initializing metrics certificate watcher using provided certificates",
-			"metrics-cert-path", metricsCertPath, "metrics-cert-name", metricsCertName, "metrics-cert-key", metricsCertKey)
+			"metrics-cert-path", o.metricsCertPath, "metrics-cert-name", o.metricsCertName,
+			"metrics-cert-key", o.metricsCertKey)
 
 		var err error
 		metricsCertWatcher, err = certwatcher.New(
-			filepath.Join(metricsCertPath, metricsCertName),
-			filepath.Join(metricsCertPath, metricsCertKey),
+			filepath.Join(o.metricsCertPath, o.metricsCertName),
+			filepath.Join(o.metricsCertPath, o.metricsCertKey),
 		)
 		if err != nil {
 			setupLog.Error(err, "to initialize metrics certificate watcher", "error", err)
@@ -183,53 +226,265 @@ func main() {
 		})
 	}
 
-	// Determine if operator should run in single-namespace or cluster-wide mode
-	// If watchNamespace is empty, the manager will watch for resources in all namespaces (cluster-wide mode)
-	// If watchNamespace has a value, the manager will only watch resources in that namespace (namespace-scoped mode)
-	var watchNamespace string
-	if ns := os.Getenv("WATCH_NAMESPACE"); ns != "" {
-		watchNamespace = ns
+	// Determine if operator should run in namespace-scoped or cluster-wide mode
+	// If --watch-namespaces is empty, the manager will watch for resources in all namespaces (cluster-wide mode)
+	// If --watch-namespaces has a value, the manager will only watch resources in those namespaces (namespace-scoped mode)
+	// The WATCH_NAMESPACE environment variable is used when the flag is not set
+	if o.watchNamespaces == "" {
+		o.watchNamespaces = os.Getenv("WATCH_NAMESPACE")
 	}
-	cacheOptions := cache.Options{}
-	if watchNamespace != "" {
-		cacheOptions.DefaultNamespaces = map[string]cache.Config{
-			watchNamespace: {},
+	// Keep the fields the controllers don't read out of the cache
+	cacheOptions := cache.Options{DefaultTransform: controller.TrimCachedObject}
+	for _, ns := range strings.Split(o.watchNamespaces, ",") {
+		if ns = strings.TrimSpace(ns); ns == "" {
+			continue
 		}
+		if cacheOptions.DefaultNamespaces == nil {
+			cacheOptions.DefaultNamespaces = map[string]cache.Config{}
+		}
+		cacheOptions.DefaultNamespaces[ns] = cache.Config{}
 	}
-	// Create a new manager to provide shared dependencies and start components
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-
-		Scheme:                 scheme,
-		Metrics:                metricsServerOptions,
-		WebhookServer:          webhookServer,
-		HealthProbeBindAddress: probeAddr,
-		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "40e0c83c.demo.local",
-		Cache:                  cacheOptions,
+	// The label selector only applies to Simples, the ConfigMaps and Secrets they reference aren't labeled
+	if o.watchLabelSelector != "" {
+		selector, err := labels.Parse(o.watchLabelSelector)
+		if err != nil {
+			setupLog.Error(err, "invalid --watch-label-selector")
+			os.Exit(1)
+		}
+		cacheOptions.ByObject = map[client.Object]cache.ByObject{
+			&demov2.Simple{}: {Label: selector},
+		}
+	}
+	// Each shard elects its own leader, the other replicas of a shard stand by
+	shard, err := o.shard()
+	if err != nil {
+		setupLog.Error(err, "invalid sharding")
+		os.Exit(1)
+	}
+	leaderElectionID := o.leaderElection.id
+	if shard.Enabled() {
+		leaderElectionID += "-shard-" + strconv.Itoa(shard.Index)
+		setupLog.Info("Reconciling a shard of the Simples", "shard", shard.Index, "shards", shard.Count)
+	}
+	// The drained reconciliations still write their status, and the other
+	// runnables stop after them
+	gracefulShutdownTimeout := o.drainTimeout + 15*time.Second
+	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
+
+		Scheme:                  scheme,
+		Metrics:                 metricsServerOptions,
+		WebhookServer:           webhookServer,
+		HealthProbeBindAddress:  o.probeAddr,
+		LeaderElection:          o.enableLeaderElection,
+		LeaderElectionID:        leaderElectionID,
+		LeaderElectionNamespace: o.leaderElection.namespace,
+		LeaseDuration:           &o.leaderElection.leaseDuration,
+		RenewDeadline:           &o.leaderElection.renewDeadline,
+		RetryPeriod:             &o.leaderElection.retryPeriod,
+		Cache:                   cacheOptions,
+		GracefulShutdownTimeout: &gracefulShutdownTimeout,
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
 		// speeds up voluntary leader transitions as the new leader don't have to wait
 		// LeaseDuration time first.
 		//
-		// In the default scaffold provided, the program ends immediately after
-		// the manager stops, so would be fine to enable this option. However,
-		// if you are doing or is intended to do any operation such as perform cleanups
-		// after the manager stops then its usage might be unsafe.
-		// LeaderElectionReleaseOnCancel: true,
+		// The program ends as soon as mgr.Start returns, keep it that way: the
+		// cleanups must run as Runnables of the manager, before it releases the Lease.
+		LeaderElectionReleaseOnCancel: o.leaderElection.releaseOnCancel,
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
+	bulkHandler.Mapper = mgr.GetRESTMapper()
+
+	if certRotator != nil {
+		if err := mgr.Add(certRotator); err != nil {
+			setupLog.Error(err, "unable to add the webhook certificate rotator to manager")
+			os.Exit(1)
+		}
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), o.tracing)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+	// Flush the pending spans when the manager stops
+	if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return shutdownTracing(shutdownCtx)
+	})); err != nil {
+		setupLog.Error(err, "unable to add the tracing shutdown to manager")
+		os.Exit(1)
+	}
+
+	clusterInfo := controller.ClusterInfo{Name: o.clusterName}
+	if discoveryClient, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig()); err != nil {
+		setupLog.Error(err, "unable to create discovery client, .Cluster.Version will be empty")
+	} else if version, err := discoveryClient.ServerVersion(); err != nil {
+		setupLog.Error(err, "unable to get the server version, .Cluster.Version will be empty")
+	} else {
+		clusterInfo.Version = version.GitVersion
+	}
+
+	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create clientset")
+		os.Exit(1)
+	}
 
-	if err := (&controller.SimpleReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-	}).SetupWithManager(mgr); err != nil {
+	// In dry-run mode the writes of the reconcilers are validated by the API
+	// server but not persisted, and the deliveries are skipped
+	reconcilerClient := client.WithFieldOwner(mgr.GetClient(), o.fieldManager)
+	recorder := mgr.GetEventRecorderFor("simple-controller")
+	var dryRunLog *controller.DryRunLog
+	if o.dryRun {
+		setupLog.Info("Running in dry-run mode, the cluster is left untouched and no message is delivered")
+		dryRunLog = controller.NewDryRunLog()
+		reconcilerClient = controller.NewDryRunClient(reconcilerClient, dryRunLog)
+		recorder = controller.NewDryRunRecorder(dryRunLog, mgr.GetScheme())
+	}
+	simpleReconciler := &controller.SimpleReconciler{
+		Client:                  reconcilerClient,
+		Scheme:                  mgr.GetScheme(),
+		Recorder:                recorder,
+		HTTPClient:              &http.Client{Timeout: o.httpTimeout},
+		FieldManager:            o.fieldManager,
+		DefaultLocale:           o.defaultLocale,
+		ClusterInfo:             clusterInfo,
+		Clientset:               clientset,
+		MaxConcurrentReconciles: o.maxConcurrentReconciles,
+		RateLimiter:             controller.NewRateLimiter(o.rateLimiter),
+		MaxDeliveriesPerMinute:  o.maxDeliveriesPerMinute,
+		DebounceWindow:          o.debounceWindow,
+		ReconcileOnStatusChange: o.reconcileOnStatusChange,
+		DetectDuplicates:        o.detectDuplicates,
+		DefaultChildLabels:      splitList(o.defaultChildLabels),
+		RESTConfig:              mgr.GetConfig(),
+		RequireServiceAccount:   o.requireServiceAccount,
+		ProbeEndpoints:          o.probeEndpoints,
+		ReconcileTimeout:        o.reconcileTimeout,
+		DrainTimeout:            o.drainTimeout,
+		DryRun:                  dryRunLog,
+		Shard:                   shard,
+	}
+	if o.directAPIReads {
+		simpleReconciler.APIReader = mgr.GetAPIReader()
+	}
+	auditStream, err := o.auditStream()
+	if err != nil {
+		setupLog.Error(err, "unable to set up the audit stream")
+		os.Exit(1)
+	}
+	if auditStream != nil {
+		simpleReconciler.Stream = auditStream
+		if err := mgr.Add(auditStream); err != nil {
+			setupLog.Error(err, "unable to add the audit stream to manager")
+			os.Exit(1)
+		}
+	}
+	if o.audit {
+		simpleReconciler.Audit = &controller.Auditor{
+			Client:    reconcilerClient,
+			Reader:    mgr.GetAPIReader(),
+			Retention: o.auditRetention,
+		}
+	}
+	if err := simpleReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Simple")
 		os.Exit(1)
 	}
+	if o.pprofAddr != "" {
+		if err := mgr.Add(&debugServer{addr: o.pprofAddr, simples: simpleReconciler.DebugHandler(), dryRun: dryRunLog}); err != nil {
+			setupLog.Error(err, "unable to add the debug server to manager")
+			os.Exit(1)
+		}
+	}
+	// The ClusterSimples, SimpleSets and SimpleDigests aren't sharded, the
+	// first shard reconciles all of them, prunes the SimpleAudits and sweeps
+	// the orphaned copies
+	if shard.Index == 0 {
+		if err := (&controller.ClusterSimpleReconciler{
+			Client:       reconcilerClient,
+			Scheme:       mgr.GetScheme(),
+			FieldManager: o.fieldManager,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ClusterSimple")
+			os.Exit(1)
+		}
+		if err := (&controller.SimpleSetReconciler{
+			Client:       reconcilerClient,
+			Scheme:       mgr.GetScheme(),
+			FieldManager: o.fieldManager,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "SimpleSet")
+			os.Exit(1)
+		}
+		if err := (&controller.SimpleDigestReconciler{
+			Client:       reconcilerClient,
+			Scheme:       mgr.GetScheme(),
+			FieldManager: o.fieldManager,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "SimpleDigest")
+			os.Exit(1)
+		}
+		if simpleReconciler.Audit != nil {
+			if err := mgr.Add(simpleReconciler.Audit); err != nil {
+				setupLog.Error(err, "unable to add the SimpleAudit pruning to manager")
+				os.Exit(1)
+			}
+		}
+		if err := mgr.Add(&controller.OrphanSweeper{
+			Client:   reconcilerClient,
+			Reader:   mgr.GetAPIReader(),
+			Interval: o.orphanSweepInterval,
+		}); err != nil {
+			setupLog.Error(err, "unable to add the orphan sweeper to manager")
+			os.Exit(1)
+		}
+	}
+	// nolint:goconst
+	webhooksEnabled := o.enableWebhooks && os.Getenv("ENABLE_WEBHOOKS") != "false"
+	if webhooksEnabled {
+		webhookOpts := webhookv2.NewLiveOptions(o.webhook)
+		if err := webhookv2.SetupSimpleWebhookWithManager(mgr, webhookOpts); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Simple")
+			os.Exit(1)
+		}
+		// The webhook settings of the --config file are reloaded when it changes
+		if o.configFile != "" {
+			if err := mgr.Add(o.configWatcher(webhookOpts)); err != nil {
+				setupLog.Error(err, "unable to add the configuration file watcher to manager")
+				os.Exit(1)
+			}
+		}
+	}
+
+	selfChecker := &selfcheck.Checker{
+		Reader:   mgr.GetAPIReader(),
+		CRDs:     expectedCRDs,
+		Interval: o.selfCheckInterval,
+	}
+	if webhooksEnabled {
+		selfChecker.MutatingWebhooks = []string{webhookConfigPrefix + "mutating-webhook-configuration"}
+		selfChecker.ValidatingWebhooks = []string{webhookConfigPrefix + "validating-webhook-configuration"}
+	}
+	if o.failOnSelfCheck {
+		if err := selfChecker.CheckCRDs(context.Background()); err != nil {
+			setupLog.Error(err, "the installed CRDs don't match the operator")
+			os.Exit(1)
+		}
+	}
+	if o.selfCheckInterval > 0 {
+		if err := mgr.Add(selfChecker); err != nil {
+			setupLog.Error(err, "unable to add the self-check to manager")
+			os.Exit(1)
+		}
+	}
 
 	// Start metrics from library
 	go func() {
@@ -240,12 +495,6 @@ func main() {
 		}
 	}()
 
-	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
-		setupLog.Error(err, "problem running manager")
-		os.Exit(1)
-	}
-
 	// +kubebuilder:scaffold:builder
 
 	if metricsCertWatcher != nil {
@@ -268,9 +517,26 @@ func main() {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
 	}
-	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
-		setupLog.Error(err, "unable to set up ready check")
-		os.Exit(1)
+	if o.workqueueStallTimeout > 0 {
+		if err := mgr.AddHealthzCheck("workqueue", simpleReconciler.QueueHealthz(o.workqueueStallTimeout)); err != nil {
+			setupLog.Error(err, "unable to set up workqueue health check")
+			os.Exit(1)
+		}
+	}
+	// The replica is ready once it can reconcile and admit Simples
+	readyChecks := map[string]healthz.Checker{
+		"readyz":    healthz.Ping,
+		"informers": cacheSyncChecker(mgr.GetCache()),
+		"crds":      crdChecker(mgr.GetAPIReader()),
+	}
+	if webhooksEnabled {
+		readyChecks["webhook"] = webhookChecker(webhook.DefaultPort)
+	}
+	for name, check := range readyChecks {
+		if err := mgr.AddReadyzCheck(name, check); err != nil {
+			setupLog.Error(err, "unable to set up ready check", "check", name)
+			os.Exit(1)
+		}
 	}
 
 	setupLog.Info("starting manager")
@@ -0,0 +1,528 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	uberzap "go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+	"github.com/leobip/demo-operator/internal/auditstream"
+	"github.com/leobip/demo-operator/internal/bulk"
+	"github.com/leobip/demo-operator/internal/certrotator"
+	"github.com/leobip/demo-operator/internal/config"
+	"github.com/leobip/demo-operator/internal/controller"
+	"github.com/leobip/demo-operator/internal/tracing"
+	webhookv2 "github.com/leobip/demo-operator/internal/webhook/v2"
+)
+
+// options are the settings of the operator, from the command line and the
+// --config file.
+type options struct {
+	configFile                                       string
+	metricsAddr                                      string
+	metricsCertPath, metricsCertName, metricsCertKey string
+	webhookCertPath, webhookCertName, webhookCertKey string
+	webhookCertRotation                              bool
+	webhookCertSecret, webhookService                string
+	enableLeaderElection                             bool
+	leaderElection                                   leaderElectionOptions
+	probeAddr                                        string
+	pprofAddr                                        string
+	secureMetrics                                    bool
+	metricsAuth                                      bool
+	bulkApply                                        bool
+	bulkApplyMaxItems                                int
+	enableHTTP2                                      bool
+	enableWebhooks                                   bool
+	webhook                                          webhookv2.Options
+	clusterName                                      string
+	maxConcurrentReconciles                          int
+	kubeAPIQPS                                       float64
+	kubeAPIBurst                                     int
+	maxDeliveriesPerMinute                           int
+	debounceWindow                                   time.Duration
+	rateLimiter                                      controller.RateLimiterOptions
+	reconcileOnStatusChange                          bool
+	detectDuplicates                                 bool
+	directAPIReads                                   bool
+	requireServiceAccount                            bool
+	probeEndpoints                                   bool
+	shards, shardIndex                               int
+	workqueueStallTimeout                            time.Duration
+	drainTimeout                                     time.Duration
+	dryRun                                           bool
+	audit                                            bool
+	auditRetention                                   time.Duration
+	orphanSweepInterval                              time.Duration
+	selfCheckInterval                                time.Duration
+	failOnSelfCheck                                  bool
+	auditStreamURL, auditStreamFile                  string
+	auditStreamBufferSize                            int
+	reconcileTimeout                                 time.Duration
+	watchNamespaces, watchLabelSelector              string
+	fieldManager                                     string
+	defaultLocale                                    string
+	defaultChildLabels                               string
+	httpTimeout                                      time.Duration
+	tracing                                          tracing.Options
+	zap                                              zap.Options
+	logLevel                                         uberzap.AtomicLevel
+}
+
+// bindFlags registers the command line flags of o in fs.
+func (o *options) bindFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.configFile, "config", "",
+		"A configuration file of kind "+config.Kind+". The flags set on the command line override it.")
+	fs.StringVar(&o.metricsAddr, "metrics-bind-address", ":8443", "The address the metrics endpoint binds to. "+
+		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
+	fs.StringVar(&o.probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	fs.StringVar(&o.pprofAddr, "pprof-bind-address", "",
+		"The address the pprof, expvar and /debug/simples endpoints bind to. Leave empty to disable them.")
+	fs.BoolVar(&o.enableLeaderElection, "leader-elect", false,
+		"Enable leader election for controller manager. "+
+			"Enabling this will ensure there is only one active controller manager.")
+	fs.StringVar(&o.leaderElection.id, "leader-elect-id", "40e0c83c.demo.local",
+		"The name of the Lease of the leader election.")
+	fs.StringVar(&o.leaderElection.namespace, "leader-elect-namespace", "",
+		"The namespace of the Lease of the leader election. Defaults to the namespace of the operator.")
+	fs.DurationVar(&o.leaderElection.leaseDuration, "leader-elect-lease-duration", 15*time.Second,
+		"How long the other replicas wait before taking over the Lease of a leader that stopped renewing it.")
+	fs.DurationVar(&o.leaderElection.renewDeadline, "leader-elect-renew-deadline", 10*time.Second,
+		"How long the leader retries renewing the Lease before it gives up leading.")
+	fs.DurationVar(&o.leaderElection.retryPeriod, "leader-elect-retry-period", 2*time.Second,
+		"How often the replicas try to acquire or renew the Lease.")
+	fs.BoolVar(&o.leaderElection.releaseOnCancel, "leader-elect-release-on-cancel", true,
+		"If set, the leader releases the Lease when it shuts down, so that another replica takes over "+
+			"within --leader-elect-retry-period instead of --leader-elect-lease-duration.")
+	fs.BoolVar(&o.secureMetrics, "metrics-secure", true,
+		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
+	fs.BoolVar(&o.metricsAuth, "metrics-auth", true,
+		"If set, requests to the metrics endpoint need a bearer token authorized by RBAC for the requested path. "+
+			"Use --metrics-auth=false to allow anonymous scraping.")
+	fs.BoolVar(&o.bulkApply, "bulk-apply", false,
+		"If set, the metrics endpoint serves "+bulk.Path+", where a POST applies a batch of Simples "+
+			"with the bearer token of the caller.")
+	fs.IntVar(&o.bulkApplyMaxItems, "bulk-apply-max-items", 1000,
+		"The most Simples a request to "+bulk.Path+" may hold.")
+	fs.StringVar(&o.webhookCertPath, "webhook-cert-path", "", "The directory that contains the webhook certificate.")
+	fs.StringVar(&o.webhookCertName, "webhook-cert-name", "tls.crt", "The name of the webhook certificate file.")
+	fs.StringVar(&o.webhookCertKey, "webhook-cert-key", "tls.key", "The name of the webhook key file.")
+	fs.BoolVar(&o.webhookCertRotation, "webhook-cert-rotation", false,
+		"If set, the operator generates and renews the webhook certificate and injects its CA into the webhook "+
+			"configurations, instead of cert-manager. --webhook-cert-path is then ignored.")
+	fs.StringVar(&o.webhookCertSecret, "webhook-cert-secret", "simple-operator-webhook-server-cert",
+		"The Secret of the operator namespace holding the certificate generated by --webhook-cert-rotation.")
+	fs.StringVar(&o.webhookService, "webhook-service", "simple-operator-webhook-service",
+		"The Service of the operator namespace in front of the webhook server, named by the certificate.")
+	fs.StringVar(&o.metricsCertPath, "metrics-cert-path", "",
+		"The directory that contains the metrics server certificate.")
+	fs.StringVar(&o.metricsCertName, "metrics-cert-name", "tls.crt", "The name of the metrics server certificate file.")
+	fs.StringVar(&o.metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
+	fs.BoolVar(&o.enableHTTP2, "enable-http2", false,
+		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	fs.IntVar(&o.maxConcurrentReconciles, "max-concurrent-reconciles", 1,
+		"The number of Simples reconciled in parallel.")
+	fs.Float64Var(&o.kubeAPIQPS, "kube-api-qps", 20,
+		"The maximum queries per second from the manager to the Kubernetes API server.")
+	fs.IntVar(&o.kubeAPIBurst, "kube-api-burst", 30,
+		"The maximum burst of queries from the manager to the Kubernetes API server.")
+	fs.DurationVar(&o.rateLimiter.BaseDelay, "rate-limiter-base-delay", 5*time.Millisecond,
+		"The delay before retrying a Simple after its first failure, doubled on every further failure.")
+	fs.DurationVar(&o.rateLimiter.MaxDelay, "rate-limiter-max-delay", 5*time.Minute,
+		"The maximum delay before retrying a failing Simple.")
+	fs.Float64Var(&o.rateLimiter.QPS, "rate-limiter-qps", 10,
+		"The maximum number of requeues per second over all Simples.")
+	fs.IntVar(&o.rateLimiter.Burst, "rate-limiter-burst", 100,
+		"The maximum burst of requeues over all Simples.")
+	fs.IntVar(&o.maxDeliveriesPerMinute, "max-deliveries-per-minute", 0,
+		"The maximum number of times per minute the edits of a Simple are re-delivered, coalescing the edits "+
+			"made in between, or fewer if its simple.example.com/max-deliveries-per-minute annotation says so. "+
+			"0 means no limit.")
+	fs.DurationVar(&o.debounceWindow, "debounce-window", 0,
+		"How long the updates of a Simple and its children must stop before the Simple is reconciled, "+
+			"so a burst of updates is reconciled once. 0 reconciles on every update.")
+	fs.BoolVar(&o.reconcileOnStatusChange, "reconcile-on-status-change", false,
+		"If set, Simples are reconciled on every update, including status-only updates and resyncs.")
+	fs.BoolVar(&o.detectDuplicates, "detect-duplicates", false,
+		"If set, Simples delivering the same messages as an older Simple of their namespace are flagged "+
+			"with status.duplicateOf and the Duplicate condition.")
+	fs.BoolVar(&o.directAPIReads, "direct-api-reads", false,
+		"If set, the children of Simples are read before they are applied, and the referenced Secrets loaded, "+
+			"from the API server instead of the cache.")
+	fs.BoolVar(&o.probeEndpoints, "probe-endpoints", false,
+		"If set, the Simples exposing their messages are only Ready once their status.url serves them. "+
+			"Each reconciliation of these Simples then waits up to 2s for the endpoint.")
+	fs.BoolVar(&o.requireServiceAccount, "require-service-account", false,
+		"If set, Simples without spec.serviceAccountName are rejected, so no child is written with the "+
+			"permissions of the operator.")
+	fs.BoolVar(&o.dryRun, "dry-run", false,
+		"If set, the writes of the controllers are only validated by the API server and the messages aren't "+
+			"delivered. What would have been done is logged, and served on /debug/dryrun with --pprof-bind-address.")
+	fs.BoolVar(&o.audit, "audit", false,
+		"If set, the spec changes, delivery attempts and deletions of the Simples are recorded as SimpleAudits.")
+	fs.DurationVar(&o.auditRetention, "audit-retention", 90*24*time.Hour,
+		"How long the SimpleAudits are kept before they are deleted. Use 0 to keep them forever.")
+	fs.DurationVar(&o.orphanSweepInterval, "orphan-sweep-interval", 10*time.Minute,
+		"How often the copies of the message ConfigMaps and Secrets in other namespaces are deleted once their "+
+			"Simple no longer exists. Use 0 to disable.")
+	fs.DurationVar(&o.selfCheckInterval, "self-check-interval", time.Minute,
+		"How often the installed CRDs and the webhook configurations are checked against what the operator "+
+			"expects, failures being reported by the simple_operator_degraded metric. Use 0 to disable.")
+	fs.BoolVar(&o.failOnSelfCheck, "fail-on-self-check", false,
+		"If set, the operator exits at startup when the installed CRDs don't match the ones it was built for.")
+	fs.StringVar(&o.auditStreamURL, "audit-stream-url", "",
+		"An HTTP endpoint the changes of the Simples and the outcomes of their deliveries are POSTed to, "+
+			"as batches of JSON lines.")
+	fs.StringVar(&o.auditStreamFile, "audit-stream-file", "",
+		"A file the changes of the Simples and the outcomes of their deliveries are appended to, as JSON lines.")
+	fs.IntVar(&o.auditStreamBufferSize, "audit-stream-buffer-size", 10000,
+		"The number of records of the audit stream buffered while its destination is slow or unavailable. "+
+			"Once it is full, the reconciliations slow down and then drop records.")
+	fs.DurationVar(&o.drainTimeout, "drain-timeout", 20*time.Second,
+		"How long the deliveries in flight on shutdown may take before they are aborted. "+
+			"The leader election Lease is released once they are done. Use 0 to abort them at once.")
+	fs.DurationVar(&o.workqueueStallTimeout, "workqueue-stall-timeout", 10*time.Minute,
+		"The /healthz check fails once Simples have waited this long in the queue without any being reconciled. "+
+			"Use 0 to disable the check.")
+	fs.IntVar(&o.shards, "shards", 1,
+		"The number of shards the Simples are split between. Each replica reconciles the Simples of its shard.")
+	fs.IntVar(&o.shardIndex, "shard-index", -1,
+		"The shard of this replica, from 0 to --shards minus 1. "+
+			"Defaults to the ordinal of the StatefulSet Pod the operator runs in, from the hostname.")
+	fs.DurationVar(&o.reconcileTimeout, "reconcile-timeout", 2*time.Minute,
+		"The maximum duration of a reconciliation, unless the Simple sets spec.timeoutSeconds. Use 0 to disable.")
+	fs.StringVar(&o.watchNamespaces, "watch-namespaces", "",
+		"A comma-separated list of namespaces to watch. Defaults to WATCH_NAMESPACE, or all namespaces if unset.")
+	fs.StringVar(&o.watchLabelSelector, "watch-label-selector", "",
+		"A label selector restricting the Simples the operator sees, e.g. tenant=team-a.")
+	fs.StringVar(&o.fieldManager, "field-manager", "simple-operator",
+		"The field manager the operator writes objects with.")
+	fs.StringVar(&o.defaultLocale, "default-locale", "en",
+		"The locale of the Simples with spec.messageKey but no spec.locale, also searched when their locale "+
+			"isn't in their catalog.")
+	fs.StringVar(&o.defaultChildLabels, "default-child-labels", "",
+		"Comma-separated keys of the labels of every Simple copied onto the objects created for it, "+
+			"e.g. team,cost-center. A key ending with * selects every key with that prefix.")
+	fs.StringVar(&o.clusterName, "cluster-name", "",
+		"The name of the cluster, exposed to message templates as .Cluster.Name.")
+	fs.DurationVar(&o.httpTimeout, "http-timeout", 10*time.Second,
+		"The timeout of the HTTP requests of the sinks and the deletion notifications.")
+	fs.StringVar(&o.tracing.Endpoint, "tracing-endpoint", "",
+		"The host:port of the OTLP gRPC collector receiving the traces. "+
+			"Defaults to OTEL_EXPORTER_OTLP_ENDPOINT, tracing is disabled if both are unset.")
+	fs.BoolVar(&o.tracing.Insecure, "tracing-insecure", false,
+		"If set, the traces are exported to the collector without TLS.")
+	fs.Float64Var(&o.tracing.SamplingRatio, "tracing-sampling-ratio", 1,
+		"The share of reconciliations traced, between 0 and 1.")
+	fs.BoolVar(&o.enableWebhooks, "enable-webhooks", true,
+		"If set, the admission webhooks are served. ENABLE_WEBHOOKS=false also disables them.")
+	fs.DurationVar(&o.webhook.DefaultInterval, "webhook-default-interval", 0,
+		"The interval the defaulting webhook sets on Simples without one. Use 0 to keep delivering once.")
+	fs.IntVar(&o.webhook.MaxMessageLength, "webhook-max-message-length", 1024,
+		"The maximum length in bytes of a Simple message accepted by the validating webhook. Use 0 to disable.")
+	fs.IntVar(&o.webhook.WarnMessageLength, "webhook-warn-message-length", 512,
+		"The length in bytes of a Simple message above which the validating webhook admits it with a warning. "+
+			"Use 0 to disable.")
+	fs.IntVar(&o.webhook.MaxSimplesPerNamespace, "webhook-max-simples-per-namespace", 0,
+		"The maximum number of Simples the validating webhook admits in a namespace. Use 0 to disable.")
+	fs.Func("webhook-quota-configmap",
+		"A namespace/name ConfigMap overriding --webhook-max-simples-per-namespace, per namespace or as a whole.",
+		func(value string) error {
+			namespace, name, ok := strings.Cut(value, "/")
+			if !ok || namespace == "" || name == "" {
+				return fmt.Errorf("expected namespace/name, got %q", value)
+			}
+			o.webhook.QuotaConfigMap = types.NamespacedName{Namespace: namespace, Name: name}
+			return nil
+		})
+	o.webhook.DefaultResourceRequests = corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("10m"),
+		corev1.ResourceMemory: resource.MustParse("32Mi"),
+	}
+	fs.Var(resourceListValue{&o.webhook.DefaultResourceRequests}, "webhook-default-resource-requests",
+		"Comma-separated name=quantity requests the defaulting webhook sets on the echo and run containers "+
+			"of Simples not requesting these resources. Leave empty to disable.")
+	fs.Func("webhook-message-allow-pattern",
+		"A regular expression every Simple message must match. May be repeated, a message must match one of them.",
+		func(pattern string) error {
+			re, err := regexp.Compile(pattern)
+			o.webhook.AllowPatterns = append(o.webhook.AllowPatterns, re)
+			return err
+		})
+	fs.Func("webhook-message-deny-pattern",
+		"A regular expression no Simple message may match. May be repeated.",
+		func(pattern string) error {
+			re, err := regexp.Compile(pattern)
+			o.webhook.DenyPatterns = append(o.webhook.DenyPatterns, re)
+			return err
+		})
+	o.zap = zap.Options{
+		Development: true,
+	}
+	o.zap.BindFlags(fs)
+	o.logLevel = uberzap.NewAtomicLevel()
+}
+
+// syncLogLevel makes the runtime-adjustable o.logLevel the level of the
+// logger, starting at the level of --zap-log-level. It must be called again
+// after the flags changed.
+func (o *options) syncLogLevel() {
+	switch level := o.zap.Level.(type) {
+	case uberzap.AtomicLevel:
+		if level != o.logLevel {
+			o.logLevel.SetLevel(level.Level())
+		}
+	case nil:
+		if o.zap.Development {
+			o.logLevel.SetLevel(zapcore.DebugLevel)
+		} else {
+			o.logLevel.SetLevel(zapcore.InfoLevel)
+		}
+	}
+	o.zap.Level = o.logLevel
+}
+
+// leaderElectionOptions tune the leader election, see --leader-elect.
+type leaderElectionOptions struct {
+	id, namespace                             string
+	leaseDuration, renewDeadline, retryPeriod time.Duration
+	releaseOnCancel                           bool
+}
+
+// shard returns the shard of the Simples this replica reconciles.
+func (o *options) shard() (controller.Shard, error) {
+	shard := controller.Shard{Count: o.shards, Index: o.shardIndex}
+	if !shard.Enabled() {
+		return controller.Shard{}, nil
+	}
+	if shard.Index < 0 {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return shard, fmt.Errorf("set --shard-index: %w", err)
+		}
+		ordinal := hostname[strings.LastIndex(hostname, "-")+1:]
+		if shard.Index, err = strconv.Atoi(ordinal); err != nil {
+			return shard, fmt.Errorf("set --shard-index, hostname %q has no StatefulSet ordinal", hostname)
+		}
+	}
+	if shard.Index >= shard.Count {
+		return shard, fmt.Errorf("--shard-index %d is not lower than --shards %d", shard.Index, shard.Count)
+	}
+	return shard, nil
+}
+
+// splitList returns the non-empty items of the comma-separated list s.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// auditStream returns the audit stream of --audit-stream-url or
+// --audit-stream-file, nil if neither is set.
+func (o *options) auditStream() (*auditstream.Stream, error) {
+	var writer auditstream.Writer
+	switch {
+	case o.auditStreamURL != "" && o.auditStreamFile != "":
+		return nil, fmt.Errorf("--audit-stream-url and --audit-stream-file are mutually exclusive")
+	case o.auditStreamURL != "":
+		writer = &auditstream.HTTPWriter{URL: o.auditStreamURL, HTTPClient: &http.Client{Timeout: o.httpTimeout}}
+	case o.auditStreamFile != "":
+		fileWriter, err := auditstream.NewFileWriter(o.auditStreamFile)
+		if err != nil {
+			return nil, err
+		}
+		writer = fileWriter
+	default:
+		return nil, nil
+	}
+	return &auditstream.Stream{Writer: writer, BufferSize: o.auditStreamBufferSize}, nil
+}
+
+// webhookConfigPrefix is the name prefix kustomize gives the webhook configurations and Service
+const webhookConfigPrefix = "simple-operator-"
+
+// certRotator returns the rotator of the webhook certificate in namespace.
+func (o *options) certRotator(c client.Client, namespace string) *certrotator.Rotator {
+	return &certrotator.Rotator{
+		Client: c,
+		Secret: types.NamespacedName{Namespace: namespace, Name: o.webhookCertSecret},
+		DNSNames: []string{
+			o.webhookService + "." + namespace + ".svc",
+			o.webhookService + "." + namespace + ".svc.cluster.local",
+		},
+		CertDir:            filepath.Join(os.TempDir(), "k8s-webhook-server", "rotated-certs"),
+		CertName:           o.webhookCertName,
+		KeyName:            o.webhookCertKey,
+		MutatingWebhooks:   []string{webhookConfigPrefix + "mutating-webhook-configuration"},
+		ValidatingWebhooks: []string{webhookConfigPrefix + "validating-webhook-configuration"},
+		CRDs:               []string{"simples." + demov2.GroupVersion.Group},
+	}
+}
+
+// operatorNamespace returns the namespace the operator runs in, from
+// POD_NAMESPACE or the service account of the Pod.
+func operatorNamespace() (string, error) {
+	if namespace := os.Getenv("POD_NAMESPACE"); namespace != "" {
+		return namespace, nil
+	}
+	namespace, err := os.ReadFile("/var/run/secrets/kubernetes.io/serviceaccount/namespace")
+	if err != nil {
+		return "", fmt.Errorf("set POD_NAMESPACE outside of a Pod: %w", err)
+	}
+	return strings.TrimSpace(string(namespace)), nil
+}
+
+// applyConfigFile sets the flags of fs from the --config file, if any.
+func (o *options) applyConfigFile(fs *flag.FlagSet) error {
+	if o.configFile == "" {
+		return nil
+	}
+	cfg, err := config.Load(o.configFile)
+	if err != nil {
+		return fmt.Errorf("loading %s: %w", o.configFile, err)
+	}
+	return applyConfig(fs, cfg)
+}
+
+// applyConfig sets the flags of fs from cfg, except those set on the
+// command line, which take precedence.
+func applyConfig(fs *flag.FlagSet, cfg *config.Config) error {
+	explicit := sets.New[string]()
+	fs.Visit(func(f *flag.Flag) { explicit.Insert(f.Name) })
+	for _, f := range cfg.Flags() {
+		if explicit.Has(f.Name) {
+			continue
+		}
+		if err := fs.Set(f.Name, f.Value); err != nil {
+			return fmt.Errorf("configuration of --%s: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+// reloadWebhookOptions returns the webhook options resulting from the
+// command line args and cfg, the new content of the --config file.
+func reloadWebhookOptions(args []string, cfg *config.Config) (webhookv2.Options, error) {
+	fs := flag.NewFlagSet("reload", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	o := &options{}
+	o.bindFlags(fs)
+	// Accept the flags registered by libraries, such as --kubeconfig, without applying them
+	flag.CommandLine.VisitAll(func(f *flag.Flag) {
+		if fs.Lookup(f.Name) == nil {
+			fs.Var(ignoredValue{f.Value}, f.Name, f.Usage)
+		}
+	})
+	if err := fs.Parse(args); err != nil {
+		return webhookv2.Options{}, err
+	}
+	if err := applyConfig(fs, cfg); err != nil {
+		return webhookv2.Options{}, err
+	}
+	return o.webhook, nil
+}
+
+// configWatcher returns a Runnable reloading the webhook settings of the
+// --config file into live when it changes.
+func (o *options) configWatcher(live *webhookv2.LiveOptions) *config.Watcher {
+	return &config.Watcher{
+		Path: o.configFile,
+		OnChange: func(cfg *config.Config) {
+			opts, err := reloadWebhookOptions(os.Args[1:], cfg)
+			if err != nil {
+				setupLog.Error(err, "unable to reload the configuration file, keeping the previous webhook settings")
+				return
+			}
+			live.Store(opts)
+			setupLog.Info("Reloaded the webhook settings of the configuration file")
+		},
+	}
+}
+
+// resourceListValue is a flag.Value holding a comma-separated list of
+// name=quantity resources, e.g. cpu=10m,memory=32Mi
+type resourceListValue struct {
+	list *corev1.ResourceList
+}
+
+func (v resourceListValue) String() string {
+	if v.list == nil {
+		return ""
+	}
+	items := make([]string, 0, len(*v.list))
+	for _, name := range slices.Sorted(maps.Keys(*v.list)) {
+		quantity := (*v.list)[name]
+		items = append(items, string(name)+"="+quantity.String())
+	}
+	return strings.Join(items, ",")
+}
+
+func (v resourceListValue) Set(value string) error {
+	list := corev1.ResourceList{}
+	for _, item := range splitList(value) {
+		name, quantity, ok := strings.Cut(item, "=")
+		if !ok {
+			return fmt.Errorf("expected name=quantity, got %q", item)
+		}
+		q, err := resource.ParseQuantity(quantity)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		list[corev1.ResourceName(name)] = q
+	}
+	*v.list = list
+	return nil
+}
+
+// ignoredValue is a flag.Value discarding the values it is set to
+type ignoredValue struct {
+	flag.Value
+}
+
+func (ignoredValue) Set(string) error {
+	return nil
+}
+
+func (v ignoredValue) IsBoolFlag() bool {
+	b, ok := v.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
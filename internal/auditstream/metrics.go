@@ -0,0 +1,49 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditstream
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	recordsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "simple_audit_stream_records_total",
+			Help: "Number of records of the audit stream, by result: sent or dropped",
+		},
+		[]string{"result"},
+	)
+	writeErrorsTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "simple_audit_stream_write_errors_total",
+			Help: "Number of failed writes of batches of the audit stream",
+		},
+	)
+	buffered = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "simple_audit_stream_buffered",
+			Help: "Number of records of the audit stream waiting to be written",
+		},
+	)
+)
+
+func init() {
+	// Register audit stream metrics with the global prometheus registry
+	metrics.Registry.MustRegister(recordsTotal, writeErrorsTotal, buffered)
+}
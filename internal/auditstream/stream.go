@@ -0,0 +1,235 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package auditstream streams the changes of the Simples and the outcomes of
+// their deliveries as JSON lines to an external endpoint or file, e.g. for a
+// SIEM, independently of the sinks of each Simple.
+package auditstream
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// Events reported in Record.Event
+const (
+	EventCreated  = "created"
+	EventUpdated  = "updated"
+	EventDeleted  = "deleted"
+	EventDelivery = "delivery"
+)
+
+const (
+	// defaultBufferSize is used when BufferSize is unset
+	defaultBufferSize = 10000
+	// defaultBlockTimeout is used when BlockTimeout is unset
+	defaultBlockTimeout = time.Second
+	// defaultBatchSize is used when BatchSize is unset
+	defaultBatchSize = 100
+	// defaultFlushInterval is used when FlushInterval is unset
+	defaultFlushInterval = time.Second
+	// flushTimeout bounds the write of the records still buffered on shutdown
+	flushTimeout = 5 * time.Second
+)
+
+// Record is one line of the stream.
+type Record struct {
+	Time            time.Time                 `json:"time"`
+	Event           string                    `json:"event"`
+	Namespace       string                    `json:"namespace"`
+	Name            string                    `json:"name"`
+	UID             types.UID                 `json:"uid"`
+	Generation      int64                     `json:"generation,omitempty"`
+	ResourceVersion string                    `json:"resourceVersion,omitempty"`
+	SpecHash        string                    `json:"specHash,omitempty"`
+	MessageHash     string                    `json:"messageHash,omitempty"`
+	Outcome         string                    `json:"outcome,omitempty"`
+	Message         string                    `json:"message,omitempty"`
+	Sinks           []demov2.AuditSinkOutcome `json:"sinks,omitempty"`
+	DryRun          bool                      `json:"dryRun,omitempty"`
+}
+
+// Writer receives the batches of records of a Stream.
+type Writer interface {
+	// Write writes records, in order. A batch that failed is retried.
+	Write(ctx context.Context, records []Record) error
+	// Close releases the writer once the stream is done.
+	Close() error
+}
+
+// Stream buffers the records emitted by the controllers and writes them in
+// batches. While the writer is slow or failing, the records pile up in the
+// buffer; once it is full, Emit blocks for up to BlockTimeout, slowing down
+// the emitters, and then drops the record. It is a Runnable writing the
+// batches on the leader, which emits the records.
+type Stream struct {
+	// Writer receives the batches
+	Writer Writer
+	// BufferSize is the number of records buffered, defaults to 10000
+	BufferSize int
+	// BlockTimeout is how long Emit waits for room in a full buffer, defaults to 1s
+	BlockTimeout time.Duration
+	// BatchSize is the maximum number of records of a batch, defaults to 100
+	BatchSize int
+	// FlushInterval is how long a record waits for a full batch, defaults to 1s
+	FlushInterval time.Duration
+
+	once    sync.Once
+	records chan Record
+	stopped atomic.Bool
+}
+
+func (s *Stream) buffer() chan Record {
+	s.once.Do(func() {
+		size := s.BufferSize
+		if size <= 0 {
+			size = defaultBufferSize
+		}
+		s.records = make(chan Record, size)
+	})
+	return s.records
+}
+
+// Emit adds record to the stream. It blocks while the buffer is full, for up
+// to BlockTimeout or until ctx is done, and then drops the record.
+func (s *Stream) Emit(ctx context.Context, record Record) {
+	if record.Time.IsZero() {
+		record.Time = time.Now()
+	}
+	if s.stopped.Load() {
+		recordsTotal.WithLabelValues("dropped").Inc()
+		return
+	}
+	records := s.buffer()
+	select {
+	case records <- record:
+		return
+	default:
+	}
+
+	timeout := s.BlockTimeout
+	if timeout <= 0 {
+		timeout = defaultBlockTimeout
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case records <- record:
+	case <-timer.C:
+		recordsTotal.WithLabelValues("dropped").Inc()
+	case <-ctx.Done():
+		recordsTotal.WithLabelValues("dropped").Inc()
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, the leader
+// emits the records.
+func (s *Stream) NeedLeaderElection() bool {
+	return true
+}
+
+// Start implements manager.Runnable, writing the batches until ctx is done.
+// The records buffered by then are written once more before it returns.
+func (s *Stream) Start(ctx context.Context) error {
+	defer func() {
+		if err := s.Writer.Close(); err != nil {
+			log.FromContext(ctx).Error(err, "failed to close the audit stream")
+		}
+	}()
+	records := s.buffer()
+	batchSize := s.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	interval := s.FlushInterval
+	if interval <= 0 {
+		interval = defaultFlushInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	batch := make([]Record, 0, batchSize)
+	for {
+		select {
+		case record := <-records:
+			batch = append(batch, record)
+			if len(batch) < batchSize {
+				continue
+			}
+		case <-ticker.C:
+			if len(batch) == 0 {
+				continue
+			}
+		case <-ctx.Done():
+			s.stopped.Store(true)
+			s.flush(ctx, batch, batchSize)
+			return nil
+		}
+		s.write(ctx, batch)
+		buffered.Set(float64(len(records)))
+		batch = batch[:0]
+	}
+}
+
+// write writes batch, retrying with an exponential backoff until it succeeds
+// or ctx is done.
+func (s *Stream) write(ctx context.Context, batch []Record) {
+	backoff := wait.Backoff{Duration: time.Second, Factor: 2, Steps: 6, Cap: 30 * time.Second}
+	for {
+		err := s.Writer.Write(ctx, batch)
+		if err == nil {
+			recordsTotal.WithLabelValues("sent").Add(float64(len(batch)))
+			return
+		}
+		writeErrorsTotal.Inc()
+		log.FromContext(ctx).Error(err, "failed to write to the audit stream, retrying", "records", len(batch))
+		select {
+		case <-ctx.Done():
+			recordsTotal.WithLabelValues("dropped").Add(float64(len(batch)))
+			return
+		case <-time.After(backoff.Step()):
+		}
+	}
+}
+
+// flush writes batch and the records left in the buffer, once, within flushTimeout.
+func (s *Stream) flush(ctx context.Context, batch []Record, batchSize int) {
+	flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), flushTimeout)
+	defer cancel()
+	for {
+		for len(batch) < batchSize && len(s.records) > 0 {
+			batch = append(batch, <-s.records)
+		}
+		if len(batch) == 0 {
+			return
+		}
+		if err := s.Writer.Write(flushCtx, batch); err != nil {
+			log.FromContext(ctx).Error(err, "failed to flush the audit stream", "records", len(batch)+len(s.records))
+			recordsTotal.WithLabelValues("dropped").Add(float64(len(batch) + len(s.records)))
+			return
+		}
+		recordsTotal.WithLabelValues("sent").Add(float64(len(batch)))
+		batch = batch[:0]
+	}
+}
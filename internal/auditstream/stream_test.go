@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditstream
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// memoryWriter keeps the batches written to it.
+type memoryWriter struct {
+	mu      sync.Mutex
+	batches [][]Record
+	closed  bool
+}
+
+func (w *memoryWriter) Write(_ context.Context, records []Record) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.batches = append(w.batches, append([]Record(nil), records...))
+	return nil
+}
+
+func (w *memoryWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.closed = true
+	return nil
+}
+
+func (w *memoryWriter) records() []Record {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var records []Record
+	for _, batch := range w.batches {
+		records = append(records, batch...)
+	}
+	return records
+}
+
+var _ = Describe("Audit stream", func() {
+	It("should write the records in batches and flush them on shutdown", func() {
+		writer := &memoryWriter{}
+		stream := &Stream{Writer: writer, BatchSize: 2, FlushInterval: time.Hour}
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() { done <- stream.Start(ctx) }()
+
+		for _, name := range []string{"a", "b", "c"} {
+			stream.Emit(ctx, Record{Event: EventCreated, Namespace: "default", Name: name})
+		}
+		Eventually(writer.records).Should(HaveLen(2))
+		cancel()
+		Eventually(done).Should(Receive(BeNil()))
+
+		Expect(writer.records()).To(HaveEach(HaveField("Time", Not(BeZero()))))
+		Expect(writer.records()).To(HaveExactElements(
+			HaveField("Name", "a"), HaveField("Name", "b"), HaveField("Name", "c"),
+		))
+		Expect(writer.closed).To(BeTrue())
+	})
+
+	It("should drop the records once the buffer stays full", func() {
+		stream := &Stream{Writer: &memoryWriter{}, BufferSize: 1, BlockTimeout: 10 * time.Millisecond}
+		dropped := testutil.ToFloat64(recordsTotal.WithLabelValues("dropped"))
+		stream.Emit(context.Background(), Record{Name: "a"})
+		start := time.Now()
+		stream.Emit(context.Background(), Record{Name: "b"})
+		Expect(time.Since(start)).To(BeNumerically(">=", 10*time.Millisecond))
+		Expect(testutil.ToFloat64(recordsTotal.WithLabelValues("dropped"))).To(Equal(dropped + 1))
+	})
+
+	It("should POST the batches as JSON lines", func() {
+		var lines []Record
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			defer GinkgoRecover()
+			Expect(req.Header.Get("Content-Type")).To(Equal("application/x-ndjson"))
+			scanner := bufio.NewScanner(req.Body)
+			for scanner.Scan() {
+				var record Record
+				Expect(json.Unmarshal(scanner.Bytes(), &record)).To(Succeed())
+				lines = append(lines, record)
+			}
+		}))
+		DeferCleanup(server.Close)
+
+		writer := &HTTPWriter{URL: server.URL, HTTPClient: server.Client()}
+		Expect(writer.Write(context.Background(), []Record{
+			{Event: EventDelivery, Name: "a", Outcome: "Delivered"},
+			{Event: EventDeleted, Name: "b"},
+		})).To(Succeed())
+		Expect(lines).To(HaveExactElements(HaveField("Outcome", "Delivered"), HaveField("Event", EventDeleted)))
+
+		server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+		Expect(writer.Write(context.Background(), []Record{{Name: "a"}})).To(MatchError(ContainSubstring("503")))
+	})
+
+	It("should append the batches to the file", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "audit.jsonl")
+		for _, name := range []string{"a", "b"} {
+			writer, err := NewFileWriter(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(writer.Write(context.Background(), []Record{{Name: name}})).To(Succeed())
+			Expect(writer.Close()).To(Succeed())
+		}
+		data, err := os.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(MatchRegexp(`^\{.*"name":"a".*\}\n\{.*"name":"b".*\}\n$`))
+	})
+})
@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditstream
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+)
+
+// encode returns records as newline-delimited JSON.
+func encode(records []Record) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// HTTPWriter POSTs the batches to URL as newline-delimited JSON.
+type HTTPWriter struct {
+	URL        string
+	HTTPClient *http.Client
+}
+
+// Write implements Writer. Any answer but a 2xx fails the batch.
+func (w *HTTPWriter) Write(ctx context.Context, records []Record) error {
+	body, err := encode(records)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("building the audit stream request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	resp, err := w.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("audit stream endpoint returned %s", resp.Status)
+	}
+	return nil
+}
+
+// Close implements Writer.
+func (w *HTTPWriter) Close() error {
+	return nil
+}
+
+// FileWriter appends the batches to a file as newline-delimited JSON.
+type FileWriter struct {
+	file *os.File
+}
+
+// NewFileWriter opens the file at path for appending, creating it if needed.
+func NewFileWriter(path string) (*FileWriter, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("opening the audit stream file: %w", err)
+	}
+	return &FileWriter{file: file}, nil
+}
+
+// Write implements Writer.
+func (w *FileWriter) Write(_ context.Context, records []Record) error {
+	data, err := encode(records)
+	if err != nil {
+		return err
+	}
+	_, err = w.file.Write(data)
+	return err
+}
+
+// Close implements Writer.
+func (w *FileWriter) Close() error {
+	return w.file.Close()
+}
@@ -0,0 +1,306 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bulk serves the bulk apply endpoint, which applies a batch of
+// Simples in a single HTTP call. High-volume producers send one request
+// instead of one per Simple, and the writes reach the API server through a
+// single rate-limited client.
+package bulk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/flowcontrol"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// Path is where the Handler is served on the metrics endpoint
+const Path = "/bulk/simples"
+
+const (
+	// defaultMaxItems is used when MaxItems is unset
+	defaultMaxItems = 1000
+	// maxBodyBytes bounds the size of a request
+	maxBodyBytes = 32 << 20
+	// maxFieldManagerLength is the longest field manager the API server accepts
+	maxFieldManagerLength = 128
+	// workers is how many Simples of a request are applied concurrently
+	workers = 10
+)
+
+// Request is the body of a bulk apply.
+type Request struct {
+	// Items are the Simples to apply. Only their name, namespace, labels,
+	// annotations and spec are applied.
+	Items []demov2.Simple `json:"items"`
+}
+
+// Response is the outcome of a bulk apply, with a Result per item in the
+// order of the request.
+type Response struct {
+	Applied int      `json:"applied"`
+	Failed  int      `json:"failed"`
+	Items   []Result `json:"items"`
+}
+
+// Result is the outcome of applying one Simple.
+type Result struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Generation is the generation of the applied Simple
+	Generation int64 `json:"generation,omitempty"`
+	// Error is why the Simple wasn't applied, Reason and Code being the ones
+	// of the API server if it rejected it
+	Error  string              `json:"error,omitempty"`
+	Reason metav1.StatusReason `json:"reason,omitempty"`
+	Code   int32               `json:"code,omitempty"`
+}
+
+// Handler applies the Simples of a Request with server-side apply, as the
+// caller: the writes are sent with the bearer token of the request, so the
+// API server authenticates and authorizes each of them like a kubectl apply
+// of the caller, and the operator needs no permission on the Simples of the
+// producers.
+type Handler struct {
+	// Config is the configuration of the API server, its credentials are
+	// replaced by the bearer token of each request
+	Config *rest.Config
+	Scheme *runtime.Scheme
+	Mapper meta.RESTMapper
+	// FieldManager is the field manager of the operator. The callers apply
+	// with their own, so that the fields one leaves out of its applies
+	// aren't removed from those the others or the controllers set.
+	FieldManager string
+	// MaxItems is the most Simples a request may hold, defaults to 1000
+	MaxItems int
+
+	// newClient returns the client of the caller with token, replaced by the tests
+	newClient func(token string) (client.Client, error)
+	// limiter is shared by the clients of all callers, bounding the load of
+	// the endpoint on the API server to the QPS and burst of Config
+	limiter     flowcontrol.RateLimiter
+	limiterOnce sync.Once
+}
+
+// ServeHTTP implements http.Handler. The Simples are applied with the field
+// manager of the fieldManager query parameter, and the conflicts with other
+// managers are only forced with force=true, as with kubectl apply
+// --server-side. The Simples without a namespace are applied in the
+// namespace query parameter.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "only POST is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		http.Error(w, "a bearer token is required", http.StatusUnauthorized)
+		return
+	}
+
+	var request Request
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxBodyBytes)).Decode(&request); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "decoding the request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	maxItems := h.MaxItems
+	if maxItems <= 0 {
+		maxItems = defaultMaxItems
+	}
+	if len(request.Items) > maxItems {
+		http.Error(w, fmt.Sprintf("the request holds %d Simples, more than %d", len(request.Items), maxItems),
+			http.StatusRequestEntityTooLarge)
+		return
+	}
+	query := req.URL.Query()
+	opts, err := h.patchOptions(query.Get("fieldManager"), query.Get("force"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	namespace := query.Get("namespace")
+	seen := map[types.NamespacedName]bool{}
+	for i := range request.Items {
+		simple := &request.Items[i]
+		if simple.Namespace == "" {
+			simple.Namespace = namespace
+		}
+		if err := validateKey(simple); err != nil {
+			http.Error(w, fmt.Sprintf("items[%d]: %v", i, err), http.StatusBadRequest)
+			return
+		}
+		// The applies of a request run concurrently, the last one wouldn't be known
+		key := client.ObjectKeyFromObject(simple)
+		if seen[key] {
+			http.Error(w, fmt.Sprintf("items[%d]: Simple %s is listed twice", i, key), http.StatusBadRequest)
+			return
+		}
+		seen[key] = true
+	}
+
+	newClient := h.newClient
+	if newClient == nil {
+		newClient = h.callerClient
+	}
+	c, err := newClient(token)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := h.apply(req.Context(), c, request.Items, opts)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// validateKey checks the name and namespace of simple.
+func validateKey(simple *demov2.Simple) error {
+	if simple.Namespace == "" {
+		return errors.New("metadata.namespace is required, or the namespace query parameter")
+	}
+	if errs := validation.IsDNS1123Subdomain(simple.Name); len(errs) > 0 {
+		return fmt.Errorf("metadata.name %q: %s", simple.Name, strings.Join(errs, ", "))
+	}
+	if errs := validation.IsDNS1123Label(simple.Namespace); len(errs) > 0 {
+		return fmt.Errorf("metadata.namespace %q: %s", simple.Namespace, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// patchOptions returns the options of the applies of the fieldManager and
+// force query parameters.
+func (h *Handler) patchOptions(fieldManager, force string) ([]client.PatchOption, error) {
+	switch {
+	case fieldManager == "":
+		return nil, errors.New("the fieldManager query parameter is required")
+	case fieldManager == h.FieldManager:
+		return nil, fmt.Errorf("the field manager %q is the operator's own", fieldManager)
+	case len(fieldManager) > maxFieldManagerLength:
+		return nil, fmt.Errorf("the field manager is longer than %d characters", maxFieldManagerLength)
+	}
+	opts := []client.PatchOption{client.FieldOwner(fieldManager)}
+	if force == "" {
+		return opts, nil
+	}
+	forced, err := strconv.ParseBool(force)
+	if err != nil {
+		return nil, fmt.Errorf("the force query parameter %q isn't a boolean", force)
+	}
+	if forced {
+		opts = append(opts, client.ForceOwnership)
+	}
+	return opts, nil
+}
+
+// callerClient returns a client authenticated with token.
+func (h *Handler) callerClient(token string) (client.Client, error) {
+	h.limiterOnce.Do(func() {
+		qps, burst := h.Config.QPS, h.Config.Burst
+		if qps <= 0 {
+			qps, burst = rest.DefaultQPS, rest.DefaultBurst
+		}
+		h.limiter = flowcontrol.NewTokenBucketRateLimiter(qps, burst)
+	})
+	config := rest.AnonymousClientConfig(h.Config)
+	config.BearerToken = token
+	config.RateLimiter = h.limiter
+	return client.New(config, client.Options{Scheme: h.Scheme, Mapper: h.Mapper})
+}
+
+// apply applies simples with c and opts, workers at a time.
+func (h *Handler) apply(ctx context.Context, c client.Client, simples []demov2.Simple,
+	opts []client.PatchOption) Response {
+	results := make([]Result, len(simples))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range min(workers, len(simples)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = h.applySimple(ctx, c, &simples[i], opts)
+			}
+		}()
+	}
+	for i := range simples {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	response := Response{Items: results}
+	for _, result := range results {
+		if result.Error != "" {
+			response.Failed++
+		} else {
+			response.Applied++
+		}
+	}
+	applied.WithLabelValues("applied").Add(float64(response.Applied))
+	applied.WithLabelValues("failed").Add(float64(response.Failed))
+	log.FromContext(ctx).V(1).Info("Applied a batch of Simples", "applied", response.Applied, "failed", response.Failed)
+	return response
+}
+
+// applySimple applies the name, namespace, labels, annotations and spec of simple.
+func (h *Handler) applySimple(ctx context.Context, c client.Client, simple *demov2.Simple,
+	opts []client.PatchOption) Result {
+	obj := &demov2.Simple{
+		TypeMeta: metav1.TypeMeta{APIVersion: demov2.GroupVersion.String(), Kind: "Simple"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        simple.Name,
+			Namespace:   simple.Namespace,
+			Labels:      simple.Labels,
+			Annotations: simple.Annotations,
+		},
+		Spec: simple.Spec,
+	}
+	result := Result{Namespace: simple.Namespace, Name: simple.Name}
+	if err := c.Patch(ctx, obj, client.Apply, opts...); err != nil {
+		result.Error = err.Error()
+		var status apierrors.APIStatus
+		if errors.As(err, &status) {
+			result.Reason = status.Status().Reason
+			result.Code = status.Status().Code
+		}
+		return result
+	}
+	result.Generation = obj.Generation
+	return result
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bulk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+var _ = Describe("Handler", func() {
+	var (
+		handler *Handler
+		mu      sync.Mutex
+		applied map[string]*demov2.Simple
+		options map[string]*client.PatchOptions
+		tokens  []string
+	)
+
+	BeforeEach(func() {
+		applied = map[string]*demov2.Simple{}
+		options = map[string]*client.PatchOptions{}
+		tokens = nil
+		scheme := runtime.NewScheme()
+		Expect(demov2.AddToScheme(scheme)).To(Succeed())
+		// The fake client doesn't support server-side apply, the applies are recorded instead
+		c := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(_ context.Context, _ client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				Expect(patch).To(Equal(client.Apply))
+				if obj.GetNamespace() == "denied" {
+					return apierrors.NewForbidden(schema.GroupResource{Group: demov2.GroupVersion.Group, Resource: "simples"},
+						obj.GetName(), nil)
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				obj.SetGeneration(1)
+				applied[obj.GetNamespace()+"/"+obj.GetName()] = obj.(*demov2.Simple).DeepCopy()
+				options[obj.GetNamespace()+"/"+obj.GetName()] = (&client.PatchOptions{}).ApplyOptions(opts)
+				return nil
+			},
+		}).Build()
+		handler = &Handler{FieldManager: "bulk-test", MaxItems: 3}
+		handler.newClient = func(token string) (client.Client, error) {
+			tokens = append(tokens, token)
+			return c, nil
+		}
+	})
+
+	post := func(path, body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	It("should apply every Simple as the caller and report each of them", func() {
+		recorder := post(Path+"?namespace=team-a&fieldManager=producer", `{"items": [
+			{"metadata": {"name": "disk", "labels": {"team": "a"}}, "spec": {"message": "Disk almost full"}},
+			{"metadata": {"name": "cpu", "namespace": "team-b"}, "spec": {"message": "CPU throttled"}},
+			{"metadata": {"name": "secret", "namespace": "denied"}, "spec": {"message": "Not allowed"}}
+		]}`, "caller-token")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(tokens).To(Equal([]string{"caller-token"}))
+
+		var response Response
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &response)).To(Succeed())
+		Expect(response.Applied).To(Equal(2))
+		Expect(response.Failed).To(Equal(1))
+		Expect(response.Items).To(HaveLen(3))
+		Expect(response.Items[0]).To(Equal(Result{Namespace: "team-a", Name: "disk", Generation: 1}))
+		Expect(response.Items[1]).To(Equal(Result{Namespace: "team-b", Name: "cpu", Generation: 1}))
+		Expect(response.Items[2].Reason).To(Equal(metav1.StatusReasonForbidden))
+		Expect(response.Items[2].Code).To(Equal(int32(http.StatusForbidden)))
+
+		Expect(applied).To(HaveKey("team-a/disk"))
+		Expect(applied["team-a/disk"].Labels).To(HaveKeyWithValue("team", "a"))
+		Expect(applied["team-a/disk"].Spec.Message).To(Equal("Disk almost full"))
+		Expect(applied["team-a/disk"].APIVersion).To(Equal(demov2.GroupVersion.String()))
+		Expect(options["team-a/disk"].FieldManager).To(Equal("producer"))
+		Expect(options["team-a/disk"].Force).To(BeNil())
+	})
+
+	It("should only force the conflicts on request", func() {
+		Expect(post(Path+"?namespace=a&fieldManager=producer&force=true", `{"items": [{"metadata": {"name": "disk"}}]}`,
+			"t").Code).To(Equal(http.StatusOK))
+		Expect(options["a/disk"].Force).To(HaveValue(BeTrue()))
+	})
+
+	It("should reject the requests without a bearer token", func() {
+		Expect(post(Path, `{"items": []}`, "").Code).To(Equal(http.StatusUnauthorized))
+		Expect(tokens).To(BeEmpty())
+	})
+
+	It("should reject invalid batches without applying any Simple", func() {
+		By("requiring POST")
+		req := httptest.NewRequest(http.MethodGet, Path, nil)
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		Expect(recorder.Code).To(Equal(http.StatusMethodNotAllowed))
+
+		By("requiring a field manager of the caller")
+		Expect(post(Path+"?namespace=a", `{"items": [{"metadata": {"name": "disk"}}]}`, "t").Code).To(Equal(http.StatusBadRequest))
+		Expect(post(Path+"?namespace=a&fieldManager=bulk-test", `{"items": [{"metadata": {"name": "disk"}}]}`,
+			"t").Code).To(Equal(http.StatusBadRequest))
+		Expect(post(Path+"?namespace=a&fieldManager=producer&force=maybe", `{"items": [{"metadata": {"name": "disk"}}]}`,
+			"t").Code).To(Equal(http.StatusBadRequest))
+
+		By("requiring a namespace")
+		Expect(post(Path+"?fieldManager=producer", `{"items": [{"metadata": {"name": "disk"}}]}`, "t").Code).To(Equal(http.StatusBadRequest))
+
+		By("rejecting a Simple listed twice")
+		Expect(post(Path+"?namespace=a&fieldManager=producer", `{"items": [{"metadata": {"name": "disk"}}, {"metadata": {"name": "disk"}}]}`,
+			"t").Code).To(Equal(http.StatusBadRequest))
+
+		By("limiting the size of the batch")
+		Expect(post(Path+"?namespace=a&fieldManager=producer", `{"items": [{"metadata": {"name": "a"}}, {"metadata": {"name": "b"}},
+			{"metadata": {"name": "c"}}, {"metadata": {"name": "d"}}]}`, "t").Code).To(Equal(http.StatusRequestEntityTooLarge))
+
+		Expect(applied).To(BeEmpty())
+	})
+})
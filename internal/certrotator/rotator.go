@@ -0,0 +1,406 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package certrotator generates and renews the serving certificate of the
+// webhooks, and injects its CA into the webhook configurations, so that the
+// operator can be installed without cert-manager.
+package certrotator
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// The Secret is written with a Role of the operator namespace rather than
+// the ClusterRole. Its create can't be limited to a name, its update can.
+// +kubebuilder:rbac:groups=core,namespace=system,resources=secrets,verbs=create
+// +kubebuilder:rbac:groups=core,namespace=system,resources=secrets,resourceNames=simple-operator-webhook-server-cert,verbs=update
+// +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations;validatingwebhookconfigurations,verbs=get;patch
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;patch
+
+const (
+	// CACertKey is the key of the CA certificate in the Secret
+	CACertKey = "ca.crt"
+	// CAKeyKey is the key of the CA private key in the Secret
+	CAKeyKey = "ca.key"
+
+	// caValidity is the lifetime of the generated CA
+	caValidity = 10 * 365 * 24 * time.Hour
+	// defaultCertValidity is the lifetime of the serving certificate when CertValidity is unset
+	defaultCertValidity = 90 * 24 * time.Hour
+	// defaultRenewBefore is used when RenewBefore is unset
+	defaultRenewBefore = 30 * 24 * time.Hour
+	// defaultInterval is used when Interval is unset
+	defaultInterval = time.Hour
+)
+
+// Rotator keeps a self-signed serving certificate of the webhooks in a
+// Secret, renews it before it expires, writes it to CertDir for the webhook
+// server and injects its CA into the webhook configurations and the
+// conversion webhooks of the CRDs. Every replica runs it: they share the
+// certificate through the Secret.
+type Rotator struct {
+	// Client reads and writes the Secret and the webhook configurations
+	Client client.Client
+	// Secret holds the certificates, it is created when missing
+	Secret types.NamespacedName
+	// DNSNames are the names the API server reaches the webhook service by
+	DNSNames []string
+	// CertDir is the directory the certificate is written to, as CertName and KeyName
+	CertDir           string
+	CertName, KeyName string
+
+	// MutatingWebhooks and ValidatingWebhooks are the names of the webhook
+	// configurations the CA is injected into. Missing ones are skipped.
+	MutatingWebhooks, ValidatingWebhooks []string
+	// CRDs are the names of the CustomResourceDefinitions whose conversion webhook the CA is injected into
+	CRDs []string
+
+	// CertValidity is the lifetime of the serving certificate, defaults to 90 days
+	CertValidity time.Duration
+	// RenewBefore is how long before expiring a certificate is renewed, defaults to 30 days
+	RenewBefore time.Duration
+	// Interval is the period of the checks, defaults to 1h
+	Interval time.Duration
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, every replica serves the webhooks.
+func (r *Rotator) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements manager.Runnable, renewing the certificate until ctx is done.
+func (r *Rotator) Start(ctx context.Context) error {
+	interval := r.Interval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := r.Ensure(ctx); err != nil {
+				log.FromContext(ctx).Error(err, "unable to rotate the webhook certificate, will retry",
+					"secret", r.Secret)
+			}
+		}
+	}
+}
+
+// Ensure makes sure the Secret holds a valid certificate, writes it to
+// CertDir and injects its CA. It must succeed once before the webhook server
+// starts.
+func (r *Rotator) Ensure(ctx context.Context) error {
+	secret, err := r.ensureSecret(ctx)
+	if err != nil {
+		return err
+	}
+	if err := r.writeFile(r.certName(), secret.Data[corev1.TLSCertKey]); err != nil {
+		return err
+	}
+	if err := r.writeFile(r.keyName(), secret.Data[corev1.TLSPrivateKeyKey]); err != nil {
+		return err
+	}
+	return r.injectCA(ctx, secret.Data[CACertKey])
+}
+
+// ensureSecret returns the Secret, generating or renewing its certificates as needed.
+func (r *Rotator) ensureSecret(ctx context.Context) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	err := r.Client.Get(ctx, r.Secret, secret)
+	if apierrors.IsNotFound(err) {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: r.Secret.Name, Namespace: r.Secret.Namespace},
+			Type:       corev1.SecretTypeTLS,
+		}
+		if _, err := r.refresh(secret, time.Now()); err != nil {
+			return nil, err
+		}
+		err = r.Client.Create(ctx, secret)
+		if !apierrors.IsAlreadyExists(err) {
+			if err != nil {
+				return nil, fmt.Errorf("creating the Secret %s: %w", r.Secret, err)
+			}
+			log.FromContext(ctx).Info("Generated the webhook certificate", "secret", r.Secret)
+			return secret, nil
+		}
+		// Another replica created it first
+		if err := r.Client.Get(ctx, r.Secret, secret); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("reading the Secret %s: %w", r.Secret, err)
+	}
+
+	changed, err := r.refresh(secret, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		if err := r.Client.Update(ctx, secret); err != nil {
+			return nil, fmt.Errorf("renewing the certificate in the Secret %s: %w", r.Secret, err)
+		}
+		log.FromContext(ctx).Info("Renewed the webhook certificate", "secret", r.Secret)
+	}
+	return secret, nil
+}
+
+// refresh generates the certificates of secret that are missing, invalid or
+// expiring, and reports whether it changed.
+func (r *Rotator) refresh(secret *corev1.Secret, now time.Time) (bool, error) {
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	renewBefore := r.RenewBefore
+	if renewBefore <= 0 {
+		renewBefore = defaultRenewBefore
+	}
+
+	changed := false
+	ca, caKey, err := parsePair(secret.Data[CACertKey], secret.Data[CAKeyKey])
+	if err != nil || now.Add(renewBefore).After(ca.NotAfter) {
+		ca, caKey, err = r.generate(nil, nil, now, caValidity)
+		if err != nil {
+			return false, err
+		}
+		if secret.Data[CACertKey], secret.Data[CAKeyKey], err = encodePair(ca, caKey); err != nil {
+			return false, err
+		}
+		changed = true
+	}
+
+	cert, _, err := parsePair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if changed || err != nil || now.Add(renewBefore).After(cert.NotAfter) ||
+		cert.CheckSignatureFrom(ca) != nil || !slices.Equal(cert.DNSNames, r.DNSNames) {
+		validity := r.CertValidity
+		if validity <= 0 {
+			validity = defaultCertValidity
+		}
+		cert, key, err := r.generate(ca, caKey, now, validity)
+		if err != nil {
+			return false, err
+		}
+		if secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey], err = encodePair(cert, key); err != nil {
+			return false, err
+		}
+		changed = true
+	}
+	return changed, nil
+}
+
+// generate returns a new certificate valid from now for validity, signed by
+// ca, or a self-signed CA when ca is nil.
+func (r *Rotator) generate(ca *x509.Certificate, caKey crypto.Signer, now time.Time,
+	validity time.Duration) (*x509.Certificate, crypto.Signer, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(validity),
+	}
+	if ca == nil {
+		template.Subject = pkix.Name{CommonName: "simple-operator-webhook-ca"}
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+		ca, caKey = template, key
+	} else {
+		template.Subject = pkix.Name{CommonName: r.DNSNames[0]}
+		template.DNSNames = r.DNSNames
+		template.KeyUsage = x509.KeyUsageDigitalSignature
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, key.Public(), caKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("signing the certificate: %w", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	return cert, key, err
+}
+
+// parsePair decodes a PEM certificate and its PKCS #8 private key.
+func parsePair(certPEM, keyPEM []byte) (*x509.Certificate, crypto.Signer, error) {
+	certBlock, _ := pem.Decode(certPEM)
+	keyBlock, _ := pem.Decode(keyPEM)
+	if certBlock == nil || keyBlock == nil {
+		return nil, nil, errors.New("missing certificate or key")
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, nil, errors.New("unsupported private key")
+	}
+	return cert, signer, nil
+}
+
+// encodePair encodes cert and key as PEM.
+func encodePair(cert *x509.Certificate, key crypto.Signer) (certPEM, keyPEM []byte, err error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+func (r *Rotator) certName() string {
+	if r.CertName == "" {
+		return corev1.TLSCertKey
+	}
+	return r.CertName
+}
+
+func (r *Rotator) keyName() string {
+	if r.KeyName == "" {
+		return corev1.TLSPrivateKeyKey
+	}
+	return r.KeyName
+}
+
+// writeFile replaces the file name of CertDir with data at once, unless it
+// already holds it, so that the certificate watcher never reads it half written.
+func (r *Rotator) writeFile(name string, data []byte) error {
+	path := filepath.Join(r.CertDir, name)
+	if current, err := os.ReadFile(path); err == nil && bytes.Equal(current, data) {
+		return nil
+	}
+	if err := os.MkdirAll(r.CertDir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(r.CertDir, "."+name)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// injectCA sets caBundle on the webhook configurations and the conversion webhooks of the CRDs.
+func (r *Rotator) injectCA(ctx context.Context, caBundle []byte) error {
+	var errs []error
+	for _, name := range r.MutatingWebhooks {
+		var config admissionregistrationv1.MutatingWebhookConfiguration
+		errs = append(errs, r.patch(ctx, name, &config, func() bool {
+			changed := false
+			for i := range config.Webhooks {
+				changed = setBundle(&config.Webhooks[i].ClientConfig.CABundle, caBundle) || changed
+			}
+			return changed
+		}))
+	}
+	for _, name := range r.ValidatingWebhooks {
+		var config admissionregistrationv1.ValidatingWebhookConfiguration
+		errs = append(errs, r.patch(ctx, name, &config, func() bool {
+			changed := false
+			for i := range config.Webhooks {
+				changed = setBundle(&config.Webhooks[i].ClientConfig.CABundle, caBundle) || changed
+			}
+			return changed
+		}))
+	}
+	for _, name := range r.CRDs {
+		crd := &unstructured.Unstructured{}
+		crd.SetAPIVersion("apiextensions.k8s.io/v1")
+		crd.SetKind("CustomResourceDefinition")
+		errs = append(errs, r.patch(ctx, name, crd, func() bool {
+			if strategy, _, _ := unstructured.NestedString(crd.Object, "spec", "conversion", "strategy"); strategy != "Webhook" {
+				return false
+			}
+			encoded := base64.StdEncoding.EncodeToString(caBundle)
+			path := []string{"spec", "conversion", "webhook", "clientConfig", "caBundle"}
+			if current, _, _ := unstructured.NestedString(crd.Object, path...); current == encoded {
+				return false
+			}
+			return unstructured.SetNestedField(crd.Object, encoded, path...) == nil
+		}))
+	}
+	return errors.Join(errs...)
+}
+
+// patch reads the cluster-scoped object name into obj and patches it if mutate changed it.
+func (r *Rotator) patch(ctx context.Context, name string, obj client.Object, mutate func() bool) error {
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: name}, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("reading %s: %w", name, err)
+	}
+	base := obj.DeepCopyObject().(client.Object)
+	if !mutate() {
+		return nil
+	}
+	if err := r.Client.Patch(ctx, obj, client.MergeFrom(base)); err != nil {
+		return fmt.Errorf("injecting the CA into %s: %w", name, err)
+	}
+	return nil
+}
+
+// setBundle sets *bundle to caBundle and reports whether it changed.
+func setBundle(bundle *[]byte, caBundle []byte) bool {
+	if bytes.Equal(*bundle, caBundle) {
+		return false
+	}
+	*bundle = caBundle
+	return true
+}
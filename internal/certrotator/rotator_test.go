@@ -0,0 +1,123 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certrotator
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Rotator", func() {
+	var (
+		ctx     context.Context
+		c       client.Client
+		rotator *Rotator
+		key     = types.NamespacedName{Namespace: "system", Name: "webhook-server-cert"}
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		crd := &unstructured.Unstructured{}
+		crd.SetAPIVersion("apiextensions.k8s.io/v1")
+		crd.SetKind("CustomResourceDefinition")
+		crd.SetName("simples.demo.demo.local")
+		Expect(unstructured.SetNestedField(crd.Object, "Webhook", "spec", "conversion", "strategy")).To(Succeed())
+		c = fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(
+			&admissionregistrationv1.ValidatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "validating-webhook-configuration"},
+				Webhooks:   []admissionregistrationv1.ValidatingWebhook{{Name: "vsimple-v2.kb.io"}},
+			},
+			crd,
+		).Build()
+		rotator = &Rotator{
+			Client:             c,
+			Secret:             key,
+			DNSNames:           []string{"webhook-service.system.svc"},
+			CertDir:            GinkgoT().TempDir(),
+			MutatingWebhooks:   []string{"mutating-webhook-configuration"},
+			ValidatingWebhooks: []string{"validating-webhook-configuration"},
+			CRDs:               []string{"simples.demo.demo.local"},
+		}
+	})
+
+	It("should generate the certificate and inject its CA", func() {
+		Expect(rotator.Ensure(ctx)).To(Succeed())
+
+		var secret corev1.Secret
+		Expect(c.Get(ctx, key, &secret)).To(Succeed())
+		Expect(secret.Type).To(Equal(corev1.SecretTypeTLS))
+
+		By("serving a certificate for the Service signed by the CA")
+		pair, err := tls.LoadX509KeyPair(filepath.Join(rotator.CertDir, "tls.crt"), filepath.Join(rotator.CertDir, "tls.key"))
+		Expect(err).NotTo(HaveOccurred())
+		cert, err := x509.ParseCertificate(pair.Certificate[0])
+		Expect(err).NotTo(HaveOccurred())
+		pool := x509.NewCertPool()
+		Expect(pool.AppendCertsFromPEM(secret.Data[CACertKey])).To(BeTrue())
+		_, err = cert.Verify(x509.VerifyOptions{DNSName: "webhook-service.system.svc", Roots: pool})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("injecting the CA into the webhook configurations and the CRD")
+		var webhooks admissionregistrationv1.ValidatingWebhookConfiguration
+		Expect(c.Get(ctx, types.NamespacedName{Name: "validating-webhook-configuration"}, &webhooks)).To(Succeed())
+		Expect(webhooks.Webhooks[0].ClientConfig.CABundle).To(Equal(secret.Data[CACertKey]))
+		crd := &unstructured.Unstructured{}
+		crd.SetAPIVersion("apiextensions.k8s.io/v1")
+		crd.SetKind("CustomResourceDefinition")
+		Expect(c.Get(ctx, types.NamespacedName{Name: "simples.demo.demo.local"}, crd)).To(Succeed())
+		bundle, _, _ := unstructured.NestedString(crd.Object, "spec", "conversion", "webhook", "clientConfig", "caBundle")
+		Expect(bundle).To(Equal(base64.StdEncoding.EncodeToString(secret.Data[CACertKey])))
+	})
+
+	It("should keep a valid certificate and renew an expiring one", func() {
+		Expect(rotator.Ensure(ctx)).To(Succeed())
+		var first corev1.Secret
+		Expect(c.Get(ctx, key, &first)).To(Succeed())
+
+		Expect(rotator.Ensure(ctx)).To(Succeed())
+		var second corev1.Secret
+		Expect(c.Get(ctx, key, &second)).To(Succeed())
+		Expect(second.Data).To(Equal(first.Data))
+
+		// The certificate is valid for 90 days, the CA for 10 years
+		rotator.RenewBefore = 100 * 24 * time.Hour
+		Expect(rotator.Ensure(ctx)).To(Succeed())
+		var renewed corev1.Secret
+		Expect(c.Get(ctx, key, &renewed)).To(Succeed())
+		Expect(renewed.Data[corev1.TLSCertKey]).NotTo(Equal(first.Data[corev1.TLSCertKey]))
+		Expect(renewed.Data[CACertKey]).To(Equal(first.Data[CACertKey]))
+		served, err := os.ReadFile(filepath.Join(rotator.CertDir, "tls.crt"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(served).To(Equal(renewed.Data[corev1.TLSCertKey]))
+	})
+})
@@ -0,0 +1,391 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package config loads the configuration file of the operator.
+package config
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// The type of the configuration file
+const (
+	APIVersion = "config.demo.demo.local/v1alpha1"
+	Kind       = "OperatorConfig"
+)
+
+// Config is the configuration file of the operator, passed with --config.
+// Every field mirrors a command line flag, which overrides it when set.
+// Unset fields keep the default of their flag.
+type Config struct {
+	metav1.TypeMeta `json:",inline"`
+
+	Metrics        MetricsConfig        `json:"metrics,omitempty"`
+	Health         HealthConfig         `json:"health,omitempty"`
+	LeaderElection LeaderElectionConfig `json:"leaderElection,omitempty"`
+	KubeAPI        KubeAPIConfig        `json:"kubeAPI,omitempty"`
+	Controller     ControllerConfig     `json:"controller,omitempty"`
+	Watch          WatchConfig          `json:"watch,omitempty"`
+	Sinks          SinksConfig          `json:"sinks,omitempty"`
+	AuditStream    AuditStreamConfig    `json:"auditStream,omitempty"`
+	Tracing        TracingConfig        `json:"tracing,omitempty"`
+	Logging        LoggingConfig        `json:"logging,omitempty"`
+	Debug          DebugConfig          `json:"debug,omitempty"`
+	Webhook        WebhookConfig        `json:"webhook,omitempty"`
+}
+
+// MetricsConfig configures the metrics endpoint.
+type MetricsConfig struct {
+	// BindAddress is --metrics-bind-address
+	BindAddress string `json:"bindAddress,omitempty"`
+	// Secure is --metrics-secure
+	Secure *bool `json:"secure,omitempty"`
+	// Auth is --metrics-auth
+	Auth *bool `json:"auth,omitempty"`
+	// BulkApply is --bulk-apply
+	BulkApply *bool `json:"bulkApply,omitempty"`
+	// BulkApplyMaxItems is --bulk-apply-max-items
+	BulkApplyMaxItems *int `json:"bulkApplyMaxItems,omitempty"`
+	// CertPath is --metrics-cert-path
+	CertPath string `json:"certPath,omitempty"`
+	// CertName is --metrics-cert-name
+	CertName string `json:"certName,omitempty"`
+	// CertKey is --metrics-cert-key
+	CertKey string `json:"certKey,omitempty"`
+}
+
+// HealthConfig configures the health probes.
+type HealthConfig struct {
+	// BindAddress is --health-probe-bind-address
+	BindAddress string `json:"bindAddress,omitempty"`
+}
+
+// LeaderElectionConfig configures the leader election.
+type LeaderElectionConfig struct {
+	// Enabled is --leader-elect
+	Enabled *bool `json:"enabled,omitempty"`
+	// ID is --leader-elect-id
+	ID string `json:"id,omitempty"`
+	// Namespace is --leader-elect-namespace
+	Namespace string `json:"namespace,omitempty"`
+	// LeaseDuration is --leader-elect-lease-duration
+	LeaseDuration *metav1.Duration `json:"leaseDuration,omitempty"`
+	// RenewDeadline is --leader-elect-renew-deadline
+	RenewDeadline *metav1.Duration `json:"renewDeadline,omitempty"`
+	// RetryPeriod is --leader-elect-retry-period
+	RetryPeriod *metav1.Duration `json:"retryPeriod,omitempty"`
+	// ReleaseOnCancel is --leader-elect-release-on-cancel
+	ReleaseOnCancel *bool `json:"releaseOnCancel,omitempty"`
+}
+
+// KubeAPIConfig configures the client of the Kubernetes API.
+type KubeAPIConfig struct {
+	// QPS is --kube-api-qps
+	QPS *float64 `json:"qps,omitempty"`
+	// Burst is --kube-api-burst
+	Burst *int `json:"burst,omitempty"`
+}
+
+// ControllerConfig configures the controllers.
+type ControllerConfig struct {
+	// MaxConcurrentReconciles is --max-concurrent-reconciles
+	MaxConcurrentReconciles *int `json:"maxConcurrentReconciles,omitempty"`
+	// MaxDeliveriesPerMinute is --max-deliveries-per-minute
+	MaxDeliveriesPerMinute *int `json:"maxDeliveriesPerMinute,omitempty"`
+	// DebounceWindow is --debounce-window
+	DebounceWindow *metav1.Duration `json:"debounceWindow,omitempty"`
+	// ReconcileOnStatusChange is --reconcile-on-status-change
+	ReconcileOnStatusChange *bool `json:"reconcileOnStatusChange,omitempty"`
+	// DetectDuplicates is --detect-duplicates
+	DetectDuplicates *bool `json:"detectDuplicates,omitempty"`
+	// DirectAPIReads is --direct-api-reads
+	DirectAPIReads *bool `json:"directAPIReads,omitempty"`
+	// ProbeEndpoints is --probe-endpoints
+	ProbeEndpoints *bool `json:"probeEndpoints,omitempty"`
+	// RequireServiceAccount is --require-service-account
+	RequireServiceAccount *bool `json:"requireServiceAccount,omitempty"`
+	// DryRun is --dry-run
+	DryRun *bool `json:"dryRun,omitempty"`
+	// Audit is --audit
+	Audit *bool `json:"audit,omitempty"`
+	// AuditRetention is --audit-retention
+	AuditRetention *metav1.Duration `json:"auditRetention,omitempty"`
+	// OrphanSweepInterval is --orphan-sweep-interval
+	OrphanSweepInterval *metav1.Duration `json:"orphanSweepInterval,omitempty"`
+	// SelfCheckInterval is --self-check-interval
+	SelfCheckInterval *metav1.Duration `json:"selfCheckInterval,omitempty"`
+	// FailOnSelfCheck is --fail-on-self-check
+	FailOnSelfCheck *bool `json:"failOnSelfCheck,omitempty"`
+	// DrainTimeout is --drain-timeout
+	DrainTimeout *metav1.Duration `json:"drainTimeout,omitempty"`
+	// WorkqueueStallTimeout is --workqueue-stall-timeout
+	WorkqueueStallTimeout *metav1.Duration `json:"workqueueStallTimeout,omitempty"`
+	// Shards is --shards
+	Shards *int `json:"shards,omitempty"`
+	// ShardIndex is --shard-index
+	ShardIndex *int `json:"shardIndex,omitempty"`
+	// ReconcileTimeout is --reconcile-timeout
+	ReconcileTimeout *metav1.Duration `json:"reconcileTimeout,omitempty"`
+	// DefaultChildLabels is --default-child-labels
+	DefaultChildLabels []string `json:"defaultChildLabels,omitempty"`
+	// FieldManager is --field-manager
+	FieldManager string `json:"fieldManager,omitempty"`
+	// ClusterName is --cluster-name
+	ClusterName string `json:"clusterName,omitempty"`
+	// DefaultLocale is --default-locale
+	DefaultLocale string `json:"defaultLocale,omitempty"`
+	// RateLimiter configures the retries of failing Simples
+	RateLimiter RateLimiterConfig `json:"rateLimiter,omitempty"`
+}
+
+// RateLimiterConfig configures the rate limiter of the Simple controller.
+type RateLimiterConfig struct {
+	// BaseDelay is --rate-limiter-base-delay
+	BaseDelay *metav1.Duration `json:"baseDelay,omitempty"`
+	// MaxDelay is --rate-limiter-max-delay
+	MaxDelay *metav1.Duration `json:"maxDelay,omitempty"`
+	// QPS is --rate-limiter-qps
+	QPS *float64 `json:"qps,omitempty"`
+	// Burst is --rate-limiter-burst
+	Burst *int `json:"burst,omitempty"`
+}
+
+// WatchConfig restricts the resources the operator watches.
+type WatchConfig struct {
+	// Namespaces is --watch-namespaces
+	Namespaces []string `json:"namespaces,omitempty"`
+	// LabelSelector is --watch-label-selector
+	LabelSelector string `json:"labelSelector,omitempty"`
+}
+
+// SinksConfig holds the defaults of the sinks.
+type SinksConfig struct {
+	// HTTPTimeout is --http-timeout
+	HTTPTimeout *metav1.Duration `json:"httpTimeout,omitempty"`
+}
+
+// AuditStreamConfig configures the stream of the changes and deliveries of the Simples.
+type AuditStreamConfig struct {
+	// URL is --audit-stream-url
+	URL string `json:"url,omitempty"`
+	// File is --audit-stream-file
+	File string `json:"file,omitempty"`
+	// BufferSize is --audit-stream-buffer-size
+	BufferSize *int `json:"bufferSize,omitempty"`
+}
+
+// TracingConfig configures the export of the OpenTelemetry spans.
+type TracingConfig struct {
+	// Endpoint is --tracing-endpoint
+	Endpoint string `json:"endpoint,omitempty"`
+	// Insecure is --tracing-insecure
+	Insecure *bool `json:"insecure,omitempty"`
+	// SamplingRatio is --tracing-sampling-ratio
+	SamplingRatio *float64 `json:"samplingRatio,omitempty"`
+}
+
+// LoggingConfig configures the logs of the operator.
+type LoggingConfig struct {
+	// Level is --zap-log-level, e.g. info, debug or the verbosity 2
+	Level string `json:"level,omitempty"`
+}
+
+// DebugConfig configures the debug endpoints.
+type DebugConfig struct {
+	// BindAddress is --pprof-bind-address
+	BindAddress string `json:"bindAddress,omitempty"`
+}
+
+// WebhookConfig configures the admission webhooks. Its settings are
+// reloaded when the file changes.
+type WebhookConfig struct {
+	// Enabled is --enable-webhooks
+	Enabled *bool `json:"enabled,omitempty"`
+	// DefaultInterval is --webhook-default-interval
+	DefaultInterval *metav1.Duration `json:"defaultInterval,omitempty"`
+	// MaxMessageLength is --webhook-max-message-length
+	MaxMessageLength *int `json:"maxMessageLength,omitempty"`
+	// WarnMessageLength is --webhook-warn-message-length
+	WarnMessageLength *int `json:"warnMessageLength,omitempty"`
+	// MaxSimplesPerNamespace is --webhook-max-simples-per-namespace
+	MaxSimplesPerNamespace *int `json:"maxSimplesPerNamespace,omitempty"`
+	// QuotaConfigMap is --webhook-quota-configmap
+	QuotaConfigMap string `json:"quotaConfigMap,omitempty"`
+	// MessageAllowPatterns are the --webhook-message-allow-pattern flags
+	MessageAllowPatterns []string `json:"messageAllowPatterns,omitempty"`
+	// MessageDenyPatterns are the --webhook-message-deny-pattern flags
+	MessageDenyPatterns []string `json:"messageDenyPatterns,omitempty"`
+	// DefaultResourceRequests is --webhook-default-resource-requests, an
+	// empty map disables it
+	DefaultResourceRequests map[string]string `json:"defaultResourceRequests,omitempty"`
+}
+
+// Load reads the configuration file at path. Unknown fields are rejected.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse decodes a configuration file. Unknown fields are rejected.
+func Parse(data []byte) (*Config, error) {
+	var cfg Config
+	if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+		return nil, fmt.Errorf("decoding the configuration: %w", err)
+	}
+	if cfg.APIVersion != "" && cfg.APIVersion != APIVersion {
+		return nil, fmt.Errorf("unsupported apiVersion %q, expected %s", cfg.APIVersion, APIVersion)
+	}
+	if cfg.Kind != "" && cfg.Kind != Kind {
+		return nil, fmt.Errorf("unsupported kind %q, expected %s", cfg.Kind, Kind)
+	}
+	return &cfg, nil
+}
+
+// Flag is the value of a command line flag set by the configuration file.
+type Flag struct {
+	Name  string
+	Value string
+}
+
+// Flags returns the command line flags set by the configuration, in the
+// order of the file. Repeatable flags are returned once per value.
+func (c *Config) Flags() []Flag {
+	var f flags
+	f.str("metrics-bind-address", c.Metrics.BindAddress)
+	f.boolean("metrics-secure", c.Metrics.Secure)
+	f.boolean("metrics-auth", c.Metrics.Auth)
+	f.boolean("bulk-apply", c.Metrics.BulkApply)
+	f.integer("bulk-apply-max-items", c.Metrics.BulkApplyMaxItems)
+	f.str("metrics-cert-path", c.Metrics.CertPath)
+	f.str("metrics-cert-name", c.Metrics.CertName)
+	f.str("metrics-cert-key", c.Metrics.CertKey)
+	f.str("health-probe-bind-address", c.Health.BindAddress)
+	f.boolean("leader-elect", c.LeaderElection.Enabled)
+	f.str("leader-elect-id", c.LeaderElection.ID)
+	f.str("leader-elect-namespace", c.LeaderElection.Namespace)
+	f.duration("leader-elect-lease-duration", c.LeaderElection.LeaseDuration)
+	f.duration("leader-elect-renew-deadline", c.LeaderElection.RenewDeadline)
+	f.duration("leader-elect-retry-period", c.LeaderElection.RetryPeriod)
+	f.boolean("leader-elect-release-on-cancel", c.LeaderElection.ReleaseOnCancel)
+	f.float("kube-api-qps", c.KubeAPI.QPS)
+	f.integer("kube-api-burst", c.KubeAPI.Burst)
+	f.integer("max-concurrent-reconciles", c.Controller.MaxConcurrentReconciles)
+	f.integer("max-deliveries-per-minute", c.Controller.MaxDeliveriesPerMinute)
+	f.duration("debounce-window", c.Controller.DebounceWindow)
+	f.boolean("reconcile-on-status-change", c.Controller.ReconcileOnStatusChange)
+	f.boolean("detect-duplicates", c.Controller.DetectDuplicates)
+	f.boolean("direct-api-reads", c.Controller.DirectAPIReads)
+	f.boolean("probe-endpoints", c.Controller.ProbeEndpoints)
+	f.boolean("require-service-account", c.Controller.RequireServiceAccount)
+	f.boolean("dry-run", c.Controller.DryRun)
+	f.boolean("audit", c.Controller.Audit)
+	f.duration("audit-retention", c.Controller.AuditRetention)
+	f.duration("orphan-sweep-interval", c.Controller.OrphanSweepInterval)
+	f.duration("self-check-interval", c.Controller.SelfCheckInterval)
+	f.boolean("fail-on-self-check", c.Controller.FailOnSelfCheck)
+	f.duration("drain-timeout", c.Controller.DrainTimeout)
+	f.duration("workqueue-stall-timeout", c.Controller.WorkqueueStallTimeout)
+	f.integer("shards", c.Controller.Shards)
+	f.integer("shard-index", c.Controller.ShardIndex)
+	f.duration("reconcile-timeout", c.Controller.ReconcileTimeout)
+	if len(c.Controller.DefaultChildLabels) > 0 {
+		f.str("default-child-labels", strings.Join(c.Controller.DefaultChildLabels, ","))
+	}
+	f.str("field-manager", c.Controller.FieldManager)
+	f.str("cluster-name", c.Controller.ClusterName)
+	f.str("default-locale", c.Controller.DefaultLocale)
+	f.duration("rate-limiter-base-delay", c.Controller.RateLimiter.BaseDelay)
+	f.duration("rate-limiter-max-delay", c.Controller.RateLimiter.MaxDelay)
+	f.float("rate-limiter-qps", c.Controller.RateLimiter.QPS)
+	f.integer("rate-limiter-burst", c.Controller.RateLimiter.Burst)
+	if len(c.Watch.Namespaces) > 0 {
+		f.str("watch-namespaces", strings.Join(c.Watch.Namespaces, ","))
+	}
+	f.str("watch-label-selector", c.Watch.LabelSelector)
+	f.duration("http-timeout", c.Sinks.HTTPTimeout)
+	f.str("audit-stream-url", c.AuditStream.URL)
+	f.str("audit-stream-file", c.AuditStream.File)
+	f.integer("audit-stream-buffer-size", c.AuditStream.BufferSize)
+	f.str("tracing-endpoint", c.Tracing.Endpoint)
+	f.boolean("tracing-insecure", c.Tracing.Insecure)
+	f.float("tracing-sampling-ratio", c.Tracing.SamplingRatio)
+	f.str("zap-log-level", c.Logging.Level)
+	f.str("pprof-bind-address", c.Debug.BindAddress)
+	f.boolean("enable-webhooks", c.Webhook.Enabled)
+	f.duration("webhook-default-interval", c.Webhook.DefaultInterval)
+	f.integer("webhook-max-message-length", c.Webhook.MaxMessageLength)
+	f.integer("webhook-warn-message-length", c.Webhook.WarnMessageLength)
+	f.integer("webhook-max-simples-per-namespace", c.Webhook.MaxSimplesPerNamespace)
+	f.str("webhook-quota-configmap", c.Webhook.QuotaConfigMap)
+	for _, pattern := range c.Webhook.MessageAllowPatterns {
+		f.str("webhook-message-allow-pattern", pattern)
+	}
+	for _, pattern := range c.Webhook.MessageDenyPatterns {
+		f.str("webhook-message-deny-pattern", pattern)
+	}
+	if requests := c.Webhook.DefaultResourceRequests; requests != nil {
+		items := make([]string, 0, len(requests))
+		for _, name := range slices.Sorted(maps.Keys(requests)) {
+			items = append(items, name+"="+requests[name])
+		}
+		f = append(f, Flag{"webhook-default-resource-requests", strings.Join(items, ",")})
+	}
+	return f
+}
+
+// flags collects the flags set by a Config, skipping unset fields
+type flags []Flag
+
+func (f *flags) str(name, value string) {
+	if value != "" {
+		*f = append(*f, Flag{name, value})
+	}
+}
+
+func (f *flags) boolean(name string, value *bool) {
+	if value != nil {
+		*f = append(*f, Flag{name, strconv.FormatBool(*value)})
+	}
+}
+
+func (f *flags) integer(name string, value *int) {
+	if value != nil {
+		*f = append(*f, Flag{name, strconv.Itoa(*value)})
+	}
+}
+
+func (f *flags) float(name string, value *float64) {
+	if value != nil {
+		*f = append(*f, Flag{name, strconv.FormatFloat(*value, 'g', -1, 64)})
+	}
+}
+
+func (f *flags) duration(name string, value *metav1.Duration) {
+	if value != nil {
+		*f = append(*f, Flag{name, value.Duration.String()})
+	}
+}
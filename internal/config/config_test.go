@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Config", func() {
+	It("should map the file to the command line flags", func() {
+		cfg, err := Parse([]byte(`
+apiVersion: config.demo.demo.local/v1alpha1
+kind: OperatorConfig
+leaderElection:
+  enabled: true
+  retryPeriod: 1s
+controller:
+  maxConcurrentReconciles: 4
+  detectDuplicates: true
+  directAPIReads: true
+  shards: 4
+  rateLimiter:
+    maxDelay: 1m
+watch:
+  namespaces: [team-a, team-b]
+logging:
+  level: debug
+webhook:
+  maxMessageLength: 0
+  messageDenyPatterns: ["password", "token"]
+`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Flags()).To(Equal([]Flag{
+			{"leader-elect", "true"},
+			{"leader-elect-retry-period", "1s"},
+			{"max-concurrent-reconciles", "4"},
+			{"detect-duplicates", "true"},
+			{"direct-api-reads", "true"},
+			{"shards", "4"},
+			{"rate-limiter-max-delay", "1m0s"},
+			{"watch-namespaces", "team-a,team-b"},
+			{"zap-log-level", "debug"},
+			{"webhook-max-message-length", "0"},
+			{"webhook-message-deny-pattern", "password"},
+			{"webhook-message-deny-pattern", "token"},
+		}))
+	})
+
+	It("should reject unknown fields and kinds", func() {
+		_, err := Parse([]byte("controller:\n  maxConcurrentReconcile: 4\n"))
+		Expect(err).To(HaveOccurred())
+
+		_, err = Parse([]byte("apiVersion: v1\nkind: ConfigMap\n"))
+		Expect(err).To(MatchError(ContainSubstring("unsupported apiVersion")))
+	})
+
+	It("should notify changes of the file", func() {
+		dir := GinkgoT().TempDir()
+		path := filepath.Join(dir, "config.yaml")
+		// Replace the file at once like the kubelet does, so it is never read half written
+		write := func(content string) {
+			tmp := filepath.Join(dir, "config.tmp")
+			Expect(os.WriteFile(tmp, []byte(content), 0o600)).To(Succeed())
+			Expect(os.Rename(tmp, path)).To(Succeed())
+		}
+		write("kind: OperatorConfig\n")
+
+		changes := make(chan *Config, 1)
+		watcher := &Watcher{Path: path, Interval: 10 * time.Millisecond, OnChange: func(cfg *Config) { changes <- cfg }}
+		ctx, cancel := context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+		go func() {
+			defer GinkgoRecover()
+			Expect(watcher.Start(ctx)).To(Succeed())
+		}()
+
+		By("Skipping invalid content")
+		write("unknown: true\n")
+		Consistently(changes, 100*time.Millisecond).ShouldNot(Receive())
+
+		write("webhook:\n  maxMessageLength: 64\n")
+		var cfg *Config
+		Eventually(changes).Should(Receive(&cfg))
+		Expect(*cfg.Webhook.MaxMessageLength).To(Equal(64))
+	})
+})
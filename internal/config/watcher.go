@@ -0,0 +1,88 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"time"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// defaultWatchInterval is used when Watcher.Interval is unset
+const defaultWatchInterval = 10 * time.Second
+
+// Watcher calls OnChange whenever the content of the configuration file
+// changes. It polls the file rather than watching it, which also notices
+// the symlink swaps of a mounted ConfigMap.
+type Watcher struct {
+	// Path is the configuration file
+	Path string
+	// Interval is the delay between two reads of the file, defaults to 10s
+	Interval time.Duration
+	// OnChange receives the new configuration. Files failing to load are
+	// logged and skipped.
+	OnChange func(*Config)
+}
+
+// Start polls the file until ctx is done. It implements manager.Runnable.
+func (w *Watcher) Start(ctx context.Context) error {
+	log := logf.FromContext(ctx).WithName("config").WithValues("path", w.Path)
+	interval := w.Interval
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	last, err := os.ReadFile(w.Path)
+	if err != nil {
+		log.Error(err, "unable to read the configuration file")
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		data, err := os.ReadFile(w.Path)
+		if err != nil {
+			log.Error(err, "unable to read the configuration file")
+			continue
+		}
+		if bytes.Equal(data, last) {
+			continue
+		}
+		last = data
+		cfg, err := Parse(data)
+		if err != nil {
+			log.Error(err, "ignoring invalid configuration file")
+			continue
+		}
+		log.Info("Configuration file changed, reloading")
+		w.OnChange(cfg)
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, the webhooks
+// of every replica use the configuration.
+func (w *Watcher) NeedLeaderElection() bool {
+	return false
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TrimCachedObject is the transform of the objects cached by the manager. It
+// drops the fields no controller reads: the configuration last applied by
+// kubectl and the managed fields, except on the children of Simples, whose
+// managed fields tell apply when it last changed them.
+func TrimCachedObject(obj any) (any, error) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		// Not an object, e.g. the tombstone of a deleted one
+		return obj, nil
+	}
+	if annotations := accessor.GetAnnotations(); annotations != nil {
+		if _, ok := annotations[corev1.LastAppliedConfigAnnotation]; ok {
+			delete(annotations, corev1.LastAppliedConfigAnnotation)
+			accessor.SetAnnotations(annotations)
+		}
+	}
+	if _, ok := accessor.GetLabels()[ownerNameLabel]; !ok {
+		accessor.SetManagedFields(nil)
+	}
+	return obj, nil
+}
+
+// metadataOnlyChildren are the kinds of the children of Simples the
+// controller only reads the metadata of. They are watched and cached without
+// their spec and status.
+var metadataOnlyChildren = map[schema.GroupKind]bool{
+	{Group: "apps", Kind: "Deployment"}:           true,
+	{Group: "", Kind: "Service"}:                  true,
+	{Group: "networking.k8s.io", Kind: "Ingress"}: true,
+}
+
+// currentObject returns the object apply reads the current state of the
+// child obj of kind gvk into: its metadata only for metadataOnlyChildren,
+// so it comes from the same cache as their watch.
+func currentObject(obj client.Object, gvk schema.GroupVersionKind) client.Object {
+	if !metadataOnlyChildren[gvk.GroupKind()] {
+		return obj.DeepCopyObject().(client.Object)
+	}
+	current := &metav1.PartialObjectMetadata{}
+	current.SetGroupVersionKind(gvk)
+	return current
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+var _ = Describe("Cache transform", func() {
+	managedFields := []metav1.ManagedFieldsEntry{{Manager: defaultFieldManager, Operation: metav1.ManagedFieldsOperationApply}}
+
+	It("should drop the managed fields and the last applied configuration", func() {
+		simple := &demov2.Simple{ObjectMeta: metav1.ObjectMeta{
+			Name:          "test",
+			Annotations:   map[string]string{corev1.LastAppliedConfigAnnotation: "{}", "team": "a"},
+			ManagedFields: managedFields,
+		}}
+		obj, err := TrimCachedObject(simple)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obj).To(BeIdenticalTo(simple))
+		Expect(simple.Annotations).To(Equal(map[string]string{"team": "a"}))
+		Expect(simple.ManagedFields).To(BeNil())
+	})
+
+	It("should keep the managed fields of the children of Simples", func() {
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name:          "test-message",
+			Labels:        map[string]string{ownerNameLabel: "test"},
+			ManagedFields: managedFields,
+		}}
+		Expect(TrimCachedObject(cm)).Error().NotTo(HaveOccurred())
+		Expect(cm.ManagedFields).To(Equal(managedFields))
+	})
+
+	It("should pass the tombstones of deleted objects through", func() {
+		tombstone := cache.DeletedFinalStateUnknown{Key: "default/test"}
+		Expect(TrimCachedObject(tombstone)).To(Equal(tombstone))
+	})
+})
@@ -0,0 +1,240 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// clusterSimpleLabel marks the ConfigMaps propagated for a ClusterSimple, so
+// the ones of namespaces that are no longer selected are found
+const clusterSimpleLabel = "simple.example.com/cluster-simple"
+
+// ClusterSimpleReconciler reconciles a ClusterSimple object
+type ClusterSimpleReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// FieldManager is the field manager the ConfigMaps are applied with, defaults to defaultFieldManager
+	FieldManager string
+}
+
+// clusterMessageConfigMapName returns the name of the ConfigMap propagated for cs.
+func clusterMessageConfigMapName(cs *demov2.ClusterSimple) string {
+	return cs.Name + "-cluster-message"
+}
+
+// +kubebuilder:rbac:groups=demo.demo.local,resources=clustersimples,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=demo.demo.local,resources=clustersimples/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=demo.demo.local,resources=clustersimples/finalizers,verbs=update
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+
+// Reconcile propagates the messages of a ClusterSimple as a ConfigMap to every
+// selected namespace and removes the ConfigMaps of namespaces no longer selected.
+// The ConfigMaps are owned by the ClusterSimple and go away with it.
+func (r *ClusterSimpleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	// 1. Fetch the ClusterSimple instance
+	var cs demov2.ClusterSimple
+	if err := r.Get(ctx, req.NamespacedName, &cs); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !cs.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	base := cs.DeepCopy()
+	original := &base.Status
+
+	// 2. Find the selected namespaces
+	selector, err := metav1.LabelSelectorAsSelector(&cs.Spec.NamespaceSelector)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("%w: spec.namespaceSelector: %v", errInvalidSpec, err)
+	}
+	var namespaces corev1.NamespaceList
+	if err := r.List(ctx, &namespaces, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("listing namespaces: %w", err)
+	}
+
+	// 3. Write the ConfigMap to every selected namespace
+	previous := map[string]demov2.NamespacePropagationStatus{}
+	for _, ns := range cs.Status.Namespaces {
+		previous[ns.Namespace] = ns
+	}
+	selected := map[string]bool{}
+	var statuses []demov2.NamespacePropagationStatus
+	var errs []error
+	for _, ns := range namespaces.Items {
+		if ns.Status.Phase == corev1.NamespaceTerminating {
+			continue
+		}
+		selected[ns.Name] = true
+		status := previous[ns.Name]
+		status.Namespace = ns.Name
+		if err := r.propagate(ctx, &cs, ns.Name); err != nil {
+			status.Propagated = false
+			status.LastError = err.Error()
+			errs = append(errs, err)
+		} else {
+			if !status.Propagated || cs.Status.ObservedGeneration != cs.Generation {
+				now := metav1.Now()
+				status.LastPropagatedTime = &now
+			}
+			status.Propagated = true
+			status.LastError = ""
+		}
+		statuses = append(statuses, status)
+	}
+
+	// 4. Remove the ConfigMaps of namespaces that are no longer selected
+	if err := r.prune(ctx, &cs, selected); err != nil {
+		errs = append(errs, err)
+	}
+
+	// 5. Record the outcome in the status
+	cs.Status.Namespaces = statuses
+	cs.Status.Propagated = 0
+	for _, status := range statuses {
+		if status.Propagated {
+			cs.Status.Propagated++
+		}
+	}
+	reconcileErr := errors.Join(errs...)
+	if reconcileErr != nil {
+		log.Error(reconcileErr, "failed to propagate", "name", cs.Name)
+		meta.SetStatusCondition(&cs.Status.Conditions, metav1.Condition{
+			Type:               demov2.ConditionReady,
+			Status:             metav1.ConditionFalse,
+			Reason:             demov2.ReasonPropagationFailed,
+			Message:            reconcileErr.Error(),
+			ObservedGeneration: cs.Generation,
+		})
+	} else {
+		meta.SetStatusCondition(&cs.Status.Conditions, metav1.Condition{
+			Type:               demov2.ConditionReady,
+			Status:             metav1.ConditionTrue,
+			Reason:             demov2.ReasonPropagated,
+			Message:            fmt.Sprintf("Propagated to %d namespaces", cs.Status.Propagated),
+			ObservedGeneration: cs.Generation,
+		})
+	}
+	cs.Status.ObservedGeneration = cs.Generation
+
+	if !equality.Semantic.DeepEqual(original, &cs.Status) {
+		if err := patchStatus(ctx, r.Client, &cs, base); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{}, reconcileErr
+}
+
+// propagate applies the messages ConfigMap of cs to namespace.
+func (r *ClusterSimpleReconciler) propagate(ctx context.Context, cs *demov2.ClusterSimple, namespace string) error {
+	texts := make([]string, 0, len(cs.Spec.Messages))
+	data := map[string]string{}
+	for _, message := range cs.Spec.Messages {
+		texts = append(texts, message.Text)
+		if message.Name != "" {
+			data[message.Name] = message.Text
+		}
+	}
+	data[messageConfigMapKey] = strings.Join(texts, "\n")
+
+	cm := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clusterMessageConfigMapName(cs),
+			Namespace: namespace,
+			Labels:    map[string]string{clusterSimpleLabel: cs.Name},
+		},
+		Data: data,
+	}
+	if err := controllerutil.SetControllerReference(cs, cm, r.Scheme); err != nil {
+		return err
+	}
+	if err := r.Patch(ctx, cm, client.Apply, client.FieldOwner(fieldManagerOrDefault(r.FieldManager)),
+		client.ForceOwnership); err != nil {
+		return fmt.Errorf("namespace %s: applying ConfigMap %s: %w", namespace, cm.Name, err)
+	}
+	return nil
+}
+
+// prune deletes the ConfigMaps of cs outside of the selected namespaces.
+func (r *ClusterSimpleReconciler) prune(ctx context.Context, cs *demov2.ClusterSimple, selected map[string]bool) error {
+	var cms corev1.ConfigMapList
+	if err := r.List(ctx, &cms, client.MatchingLabels{clusterSimpleLabel: cs.Name}); err != nil {
+		return fmt.Errorf("listing propagated ConfigMaps: %w", err)
+	}
+	var errs []error
+	for i := range cms.Items {
+		cm := &cms.Items[i]
+		if selected[cm.Namespace] || !metav1.IsControlledBy(cm, cs) {
+			continue
+		}
+		if err := r.Delete(ctx, cm); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("namespace %s: deleting ConfigMap %s: %w", cm.Namespace, cm.Name, err))
+			continue
+		}
+		log.FromContext(ctx).Info("Deleted ConfigMap of unselected namespace", "namespace", cm.Namespace, "configMap", cm.Name)
+	}
+	return errors.Join(errs...)
+}
+
+// clusterSimplesForNamespace enqueues every ClusterSimple, as any of them may
+// select or stop selecting a namespace when it is created or relabeled.
+func (r *ClusterSimpleReconciler) clusterSimplesForNamespace(ctx context.Context, _ client.Object) []reconcile.Request {
+	var list demov2.ClusterSimpleList
+	if err := r.List(ctx, &list); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list ClusterSimples")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(list.Items))
+	for _, cs := range list.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&cs)})
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterSimpleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&demov2.ClusterSimple{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Owns(&corev1.ConfigMap{}).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.clusterSimplesForNamespace),
+			builder.WithPredicates(predicate.LabelChangedPredicate{})).
+		Named("clustersimple").
+		Complete(r)
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+var _ = Describe("ClusterSimple Controller", func() {
+	Context("When reconciling a resource", func() {
+		const resourceName = "test-cluster-resource"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{Name: resourceName}
+		clusterSimple := &demov2.ClusterSimple{}
+
+		BeforeEach(func() {
+			By("creating the selected and unselected namespaces")
+			for name, labels := range map[string]map[string]string{
+				"broadcast-selected":   {"broadcast": "true"},
+				"broadcast-unselected": nil,
+			} {
+				ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+				if err := k8sClient.Create(ctx, ns); !errors.IsAlreadyExists(err) {
+					Expect(err).NotTo(HaveOccurred())
+				}
+			}
+
+			By("creating the custom resource for the Kind ClusterSimple")
+			err := k8sClient.Get(ctx, typeNamespacedName, clusterSimple)
+			if err != nil && errors.IsNotFound(err) {
+				resource := &demov2.ClusterSimple{
+					ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+					Spec: demov2.ClusterSimpleSpec{
+						Messages: []demov2.MessageSpec{{Name: "greeting", Text: "Hello everyone"}},
+						NamespaceSelector: metav1.LabelSelector{
+							MatchLabels: map[string]string{"broadcast": "true"},
+						},
+					},
+				}
+				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			resource := &demov2.ClusterSimple{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+
+			By("Cleanup the specific resource instance ClusterSimple")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+
+		It("should propagate the ConfigMap to the selected namespaces only", func() {
+			controllerReconciler := &ClusterSimpleReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Checking the propagated ConfigMap")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, clusterSimple)).To(Succeed())
+			cm := &corev1.ConfigMap{}
+			cmName := types.NamespacedName{Name: resourceName + "-cluster-message", Namespace: "broadcast-selected"}
+			Expect(k8sClient.Get(ctx, cmName, cm)).To(Succeed())
+			Expect(cm.Data).To(HaveKeyWithValue("message", "Hello everyone"))
+			Expect(cm.Data).To(HaveKeyWithValue("greeting", "Hello everyone"))
+			Expect(metav1.IsControlledBy(cm, clusterSimple)).To(BeTrue())
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cmName.Name, Namespace: "broadcast-unselected"},
+				&corev1.ConfigMap{})).To(Satisfy(errors.IsNotFound))
+
+			By("Checking the propagation status")
+			Expect(meta.IsStatusConditionTrue(clusterSimple.Status.Conditions, demov2.ConditionReady)).To(BeTrue())
+			Expect(clusterSimple.Status.Propagated).To(Equal(int32(1)))
+			Expect(clusterSimple.Status.Namespaces).To(ConsistOf(SatisfyAll(
+				HaveField("Namespace", "broadcast-selected"),
+				HaveField("Propagated", true),
+			)))
+
+			By("Removing the ConfigMap once the namespace is no longer selected")
+			ns := &corev1.Namespace{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "broadcast-selected"}, ns)).To(Succeed())
+			delete(ns.Labels, "broadcast")
+			Expect(k8sClient.Update(ctx, ns)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "broadcast-selected"}, ns)).To(Succeed())
+				ns.Labels = map[string]string{"broadcast": "true"}
+				Expect(k8sClient.Update(ctx, ns)).To(Succeed())
+			})
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, cmName, &corev1.ConfigMap{})).To(Satisfy(errors.IsNotFound))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, clusterSimple)).To(Succeed())
+			Expect(clusterSimple.Status.Namespaces).To(BeEmpty())
+		})
+	})
+})
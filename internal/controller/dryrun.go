@@ -0,0 +1,249 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// dryRunLogSize is the number of entries kept by a DryRunLog
+const dryRunLogSize = 1000
+
+// DryRunLog records what a dry-run operator would have done: the writes
+// validated by the API server without being persisted, and the skipped
+// deliveries. It serves the latest entries as JSON.
+type DryRunLog struct {
+	mu      sync.Mutex
+	entries []DryRunEntry
+}
+
+// DryRunEntry is a write or a delivery skipped in dry-run mode.
+type DryRunEntry struct {
+	Time time.Time `json:"time"`
+	// Action is create, update, patch, delete, deleteAllOf, deliver, notify or event
+	Action    string `json:"action"`
+	Kind      string `json:"kind,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	// SubResource is the written subresource, e.g. status
+	SubResource string `json:"subResource,omitempty"`
+	// Diff is the body of the patch, or the object created or updated
+	Diff json.RawMessage `json:"diff,omitempty"`
+	// Detail describes the skipped deliveries
+	Detail string `json:"detail,omitempty"`
+}
+
+// NewDryRunLog returns an empty DryRunLog.
+func NewDryRunLog() *DryRunLog {
+	return &DryRunLog{}
+}
+
+// record adds entry to the log, dropping the oldest entry once full, and logs it.
+func (l *DryRunLog) record(ctx context.Context, entry DryRunEntry) {
+	entry.Time = time.Now()
+	log.FromContext(ctx).Info("Dry run, skipped "+entry.Action, "kind", entry.Kind,
+		"namespace", entry.Namespace, "name", entry.Name, "subResource", entry.SubResource,
+		"diff", string(entry.Diff), "detail", entry.Detail)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.entries) == dryRunLogSize {
+		l.entries = l.entries[1:]
+	}
+	l.entries = append(l.entries, entry)
+}
+
+// Entries returns the recorded entries, oldest first.
+func (l *DryRunLog) Entries() []DryRunEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]DryRunEntry(nil), l.entries...)
+}
+
+// ServeHTTP serves the recorded entries as a JSON array, oldest first.
+func (l *DryRunLog) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(l.Entries())
+}
+
+// dryRunSimpleEntry returns the entry of a skipped action of simple.
+func dryRunSimpleEntry(simple *demov2.Simple, action, detail string) DryRunEntry {
+	return DryRunEntry{Action: action, Kind: "Simple", Namespace: simple.Namespace, Name: simple.Name, Detail: detail}
+}
+
+// NewDryRunClient returns a client sending the writes of c to the API server
+// as dry runs, which validate them without persisting anything, and
+// recording them in l.
+func NewDryRunClient(c client.Client, l *DryRunLog) client.Client {
+	return &dryRunClient{Client: client.NewDryRunClient(c), log: l}
+}
+
+type dryRunClient struct {
+	client.Client
+	log *DryRunLog
+}
+
+// entry returns the entry of action on obj, with the JSON of diff.
+func (c *dryRunClient) entry(action string, obj client.Object, diff any) DryRunEntry {
+	entry := DryRunEntry{Action: action, Namespace: obj.GetNamespace(), Name: obj.GetName()}
+	if gvk, err := apiutil.GVKForObject(obj, c.Scheme()); err == nil {
+		entry.Kind = gvk.Kind
+	}
+	switch diff := diff.(type) {
+	case nil:
+	case client.Patch:
+		entry.Diff, _ = diff.Data(obj)
+	default:
+		entry.Diff, _ = json.Marshal(diff)
+	}
+	if entry.Kind == "Secret" {
+		entry.Diff = redactSecretData(entry.Diff)
+	}
+	return entry
+}
+
+// NewDryRunRecorder returns an EventRecorder emitting no Event, only
+// recording them in l. scheme tells the kinds of the objects.
+func NewDryRunRecorder(l *DryRunLog, scheme *runtime.Scheme) record.EventRecorder {
+	return &dryRunRecorder{log: l, scheme: scheme}
+}
+
+type dryRunRecorder struct {
+	log    *DryRunLog
+	scheme *runtime.Scheme
+}
+
+func (r *dryRunRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	entry := DryRunEntry{Action: "event", Detail: eventtype + " " + reason + ": " + message}
+	if obj, ok := object.(client.Object); ok {
+		entry.Namespace, entry.Name = obj.GetNamespace(), obj.GetName()
+	}
+	if gvk, err := apiutil.GVKForObject(object, r.scheme); err == nil {
+		entry.Kind = gvk.Kind
+	}
+	r.log.record(context.Background(), entry)
+}
+
+func (r *dryRunRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...any) {
+	r.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *dryRunRecorder) AnnotatedEventf(object runtime.Object, _ map[string]string, eventtype, reason,
+	messageFmt string, args ...any) {
+	r.Eventf(object, eventtype, reason, messageFmt, args...)
+}
+
+// redactSecretData replaces the values of data and stringData in diff, the
+// JSON of a Secret or of a patch of one, with redactedText, so the messages
+// of the Secrets don't end up in the logs and /debug/dryrun.
+func redactSecretData(diff json.RawMessage) json.RawMessage {
+	var fields map[string]any
+	if len(diff) == 0 || json.Unmarshal(diff, &fields) != nil {
+		return nil
+	}
+	for _, name := range []string{"data", "stringData"} {
+		values, ok := fields[name].(map[string]any)
+		if !ok {
+			continue
+		}
+		for key := range values {
+			values[key] = redactedText
+		}
+	}
+	redacted, err := json.Marshal(fields)
+	if err != nil {
+		return nil
+	}
+	return redacted
+}
+
+func (c *dryRunClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	c.log.record(ctx, c.entry("create", obj, obj))
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *dryRunClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	c.log.record(ctx, c.entry("update", obj, obj))
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *dryRunClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	c.log.record(ctx, c.entry("patch", obj, patch))
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *dryRunClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	c.log.record(ctx, c.entry("delete", obj, nil))
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func (c *dryRunClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	c.log.record(ctx, c.entry("deleteAllOf", obj, nil))
+	return c.Client.DeleteAllOf(ctx, obj, opts...)
+}
+
+func (c *dryRunClient) Status() client.SubResourceWriter {
+	return c.SubResource("status")
+}
+
+func (c *dryRunClient) SubResource(subResource string) client.SubResourceClient {
+	return &dryRunSubResourceClient{SubResourceClient: c.Client.SubResource(subResource), client: c,
+		subResource: subResource}
+}
+
+type dryRunSubResourceClient struct {
+	client.SubResourceClient
+	client      *dryRunClient
+	subResource string
+}
+
+func (c *dryRunSubResourceClient) record(ctx context.Context, action string, obj client.Object, diff any) {
+	entry := c.client.entry(action, obj, diff)
+	entry.SubResource = c.subResource
+	c.client.log.record(ctx, entry)
+}
+
+func (c *dryRunSubResourceClient) Create(ctx context.Context, obj, subResource client.Object,
+	opts ...client.SubResourceCreateOption) error {
+	c.record(ctx, "create", obj, subResource)
+	return c.SubResourceClient.Create(ctx, obj, subResource, opts...)
+}
+
+func (c *dryRunSubResourceClient) Update(ctx context.Context, obj client.Object,
+	opts ...client.SubResourceUpdateOption) error {
+	c.record(ctx, "update", obj, obj)
+	return c.SubResourceClient.Update(ctx, obj, opts...)
+}
+
+func (c *dryRunSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch,
+	opts ...client.SubResourcePatchOption) error {
+	c.record(ctx, "patch", obj, patch)
+	return c.SubResourceClient.Patch(ctx, obj, patch, opts...)
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Dry run", func() {
+	It("should record the writes without persisting them", func() {
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		existing := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "default"},
+			Data:       map[string]string{"message": "old"},
+		}
+		inner := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).Build()
+		dryRunLog := NewDryRunLog()
+		c := NewDryRunClient(inner, dryRunLog)
+
+		created := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "created", Namespace: "default"}}
+		Expect(c.Create(ctx, created)).To(Succeed())
+		err := inner.Get(ctx, client.ObjectKeyFromObject(created), &corev1.ConfigMap{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		patch := client.MergeFrom(existing.DeepCopy())
+		existing.Data["message"] = "new"
+		Expect(c.Patch(ctx, existing, patch)).To(Succeed())
+		var cm corev1.ConfigMap
+		Expect(inner.Get(ctx, client.ObjectKeyFromObject(existing), &cm)).To(Succeed())
+		Expect(cm.Data).To(HaveKeyWithValue("message", "old"))
+
+		entries := dryRunLog.Entries()
+		Expect(entries).To(HaveLen(2))
+		Expect(entries[0].Action).To(Equal("create"))
+		Expect(entries[0].Kind).To(Equal("ConfigMap"))
+		Expect(entries[1].Action).To(Equal("patch"))
+		Expect(string(entries[1].Diff)).To(MatchJSON(`{"data":{"message":"new"}}`))
+
+		recorder := httptest.NewRecorder()
+		dryRunLog.ServeHTTP(recorder, httptest.NewRequest("GET", "/debug/dryrun", nil))
+		Expect(recorder.Body.String()).To(ContainSubstring(`"name":"existing"`))
+	})
+	It("should redact the data of the Secrets", func() {
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		dryRunLog := NewDryRunLog()
+		c := NewDryRunClient(fake.NewClientBuilder().WithScheme(scheme).Build(), dryRunLog)
+
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-message", Namespace: "default"},
+			Data:       map[string][]byte{"message": []byte("s3cr3t")},
+			StringData: map[string]string{"token": "t0ken"},
+		}
+		Expect(c.Create(ctx, secret)).To(Succeed())
+
+		entries := dryRunLog.Entries()
+		Expect(entries).To(HaveLen(1))
+		Expect(string(entries[0].Diff)).NotTo(Or(ContainSubstring("t0ken"), ContainSubstring("czNjcjN0")))
+		Expect(string(entries[0].Diff)).To(ContainSubstring(`"data":{"message":"[REDACTED]"}`))
+		Expect(string(entries[0].Diff)).To(ContainSubstring(`"stringData":{"token":"[REDACTED]"}`))
+	})
+	It("should record the Events instead of emitting them", func() {
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		dryRunLog := NewDryRunLog()
+		recorder := NewDryRunRecorder(dryRunLog, scheme)
+
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+		recorder.Eventf(cm, corev1.EventTypeNormal, "Created", "Created %s", "it")
+
+		Expect(dryRunLog.Entries()).To(ConsistOf(SatisfyAll(
+			HaveField("Action", "event"),
+			HaveField("Kind", "ConfigMap"),
+			HaveField("Name", "test"),
+			HaveField("Detail", "Normal Created: Created it"),
+		)))
+	})
+})
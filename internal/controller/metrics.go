@@ -0,0 +1,148 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/leobip/demo-operator/internal/sinks"
+)
+
+var (
+	repliesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "simple_replies_total",
+			Help: "Number of messages delivered by the Simple controller",
+		},
+		[]string{"namespace"},
+	)
+	reconcileErrorsTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "simple_reconcile_errors_total",
+			Help: "Number of Simple reconciles that failed",
+		},
+	)
+	messageLengthBytes = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "simple_message_length_bytes",
+			Help:    "Length of the delivered messages in bytes",
+			Buckets: prometheus.ExponentialBuckets(16, 4, 6),
+		},
+	)
+	simplesByPhase = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "simple_resources",
+			Help: "Number of Simples in each phase",
+		},
+		[]string{"phase"},
+	)
+	timeToReplied = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "simple_time_to_replied_seconds",
+			Help:    "Time from the creation of a Simple to its first successful reply",
+			Buckets: sinks.LatencyBuckets,
+		},
+	)
+	deadLettersTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "simple_dead_letters_total",
+			Help: "Number of Simples given up on once their retries were exhausted, by failing sink",
+		},
+		[]string{"sink"},
+	)
+	deprecatedFieldUsageTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "simple_deprecated_field_usage_total",
+			Help: "Number of generations of Simples setting a deprecated field, by field",
+		},
+		[]string{"namespace", "field"},
+	)
+	orphansTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "simple_orphaned_artifacts_total",
+			Help: "Number of copies in other namespaces of the message ConfigMaps and Secrets of deleted Simples found by the sweeper",
+		},
+		[]string{"kind"},
+	)
+	deliveriesThrottledTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "simple_deliveries_throttled_total",
+			Help: "Number of reconciliations of edited Simples postponed by their re-delivery rate limit",
+		},
+		[]string{"namespace"},
+	)
+	queueDepth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "simple_workqueue_depth",
+			Help: "Number of Simples waiting to be reconciled, by priority",
+		},
+		[]string{"priority"},
+	)
+)
+
+func init() {
+	// Register custom metrics with the global prometheus registry
+	metrics.Registry.MustRegister(repliesTotal, reconcileErrorsTotal, messageLengthBytes, simplesByPhase, queueDepth,
+		timeToReplied, deadLettersTotal, deprecatedFieldUsageTotal, orphansTotal,
+		deliveriesThrottledTotal)
+}
+
+// phases tracks the last phase seen for every Simple to feed simplesByPhase
+var phases = &phaseTracker{phases: map[types.NamespacedName]string{}}
+
+type phaseTracker struct {
+	mu     sync.Mutex
+	phases map[types.NamespacedName]string
+}
+
+// observe records that the Simple key is in phase.
+func (t *phaseTracker) observe(key types.NamespacedName, phase string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if old, ok := t.phases[key]; ok {
+		if old == phase {
+			return
+		}
+		simplesByPhase.WithLabelValues(old).Dec()
+	}
+	t.phases[key] = phase
+	simplesByPhase.WithLabelValues(phase).Inc()
+}
+
+// forget drops a deleted Simple.
+func (t *phaseTracker) forget(key types.NamespacedName) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if old, ok := t.phases[key]; ok {
+		simplesByPhase.WithLabelValues(old).Dec()
+		delete(t.phases, key)
+	}
+}
+
+// get returns the last phase seen for the Simple key.
+func (t *phaseTracker) get(key types.NamespacedName) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.phases[key]
+}
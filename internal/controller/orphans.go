@@ -0,0 +1,123 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// OrphanSweeper deletes the copies of the message ConfigMaps and Secrets
+// replicated to other namespaces whose Simple no longer exists, e.g. because
+// its finalizer was removed by hand. Owner references can't span namespaces,
+// so the garbage collector doesn't delete them. It is a Runnable, to be added
+// to the manager of a single replica.
+type OrphanSweeper struct {
+	// Client lists and deletes the copies
+	Client client.Client
+
+	// Reader reads the Simples from the API server, so a Simple the cache
+	// doesn't know yet isn't taken for deleted
+	Reader client.Reader
+
+	// Interval is how often the copies are swept, never when 0
+	Interval time.Duration
+}
+
+// NeedLeaderElection makes a single replica sweep the copies.
+func (s *OrphanSweeper) NeedLeaderElection() bool {
+	return true
+}
+
+// Start sweeps the orphaned copies every Interval until ctx is done.
+func (s *OrphanSweeper) Start(ctx context.Context) error {
+	if s.Interval <= 0 {
+		return nil
+	}
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+	for {
+		if err := s.sweep(ctx); err != nil {
+			log.FromContext(ctx).Error(err, "failed to sweep the orphaned copies of the message ConfigMaps")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// sweep deletes the orphaned copies, counting them in orphansTotal.
+func (s *OrphanSweeper) sweep(ctx context.Context) error {
+	labeled := client.HasLabels{ownerNameLabel, ownerNamespaceLabel}
+	var configMaps corev1.ConfigMapList
+	if err := s.Client.List(ctx, &configMaps, labeled); err != nil {
+		return fmt.Errorf("listing ConfigMaps: %w", err)
+	}
+	var secrets corev1.SecretList
+	if err := s.Client.List(ctx, &secrets, labeled); err != nil {
+		return fmt.Errorf("listing Secrets: %w", err)
+	}
+	artifacts := make([]foreignArtifact, 0, len(configMaps.Items)+len(secrets.Items))
+	for i := range configMaps.Items {
+		artifacts = append(artifacts, foreignArtifact{&configMaps.Items[i], demov2.OutputKindConfigMap})
+	}
+	for i := range secrets.Items {
+		artifacts = append(artifacts, foreignArtifact{&secrets.Items[i], demov2.OutputKindSecret})
+	}
+
+	// The Simples are looked up once per sweep
+	exists := map[client.ObjectKey]bool{}
+	for _, artifact := range artifacts {
+		labels := artifact.GetLabels()
+		owner := client.ObjectKey{Namespace: labels[ownerNamespaceLabel], Name: labels[ownerNameLabel]}
+		// The artifacts of the Simple's own namespace are owned by it
+		if owner.Namespace == artifact.GetNamespace() {
+			continue
+		}
+		found, ok := exists[owner]
+		if !ok {
+			err := s.Reader.Get(ctx, owner, &demov2.Simple{})
+			if client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("getting Simple %s: %w", owner, err)
+			}
+			found = !apierrors.IsNotFound(err)
+			exists[owner] = found
+		}
+		if found {
+			continue
+		}
+
+		orphansTotal.WithLabelValues(string(artifact.kind)).Inc()
+		if err := s.Client.Delete(ctx, artifact.Object); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("namespace %s: deleting %s %s: %w", artifact.GetNamespace(), artifact.kind,
+				artifact.GetName(), err)
+		}
+		log.FromContext(ctx).Info("Deleted the orphaned copy of a deleted Simple", "namespace", artifact.GetNamespace(),
+			"kind", artifact.kind, "name", artifact.GetName(), "simple", owner.String())
+	}
+	return nil
+}
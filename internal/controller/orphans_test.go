@@ -0,0 +1,70 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+var _ = Describe("Orphan sweeper", func() {
+	It("should only delete the copies in other namespaces of deleted Simples", func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(demov2.AddToScheme(scheme)).To(Succeed())
+		labels := func(namespace, name string) map[string]string {
+			return map[string]string{ownerNamespaceLabel: namespace, ownerNameLabel: name}
+		}
+		live := &demov2.Simple{ObjectMeta: metav1.ObjectMeta{Name: "live", Namespace: "source"}}
+		liveCopy := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name: "live-message", Namespace: "target", Labels: labels("source", "live"),
+		}}
+		orphan := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name: "gone-message", Namespace: "target", Labels: labels("source", "gone"),
+		}}
+		orphanSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Name: "gone-message", Namespace: "other", Labels: labels("source", "gone"),
+		}}
+		// The garbage collector deletes the ones of the Simple's namespace
+		owned := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name: "gone-message", Namespace: "source", Labels: labels("source", "gone"),
+		}}
+		c := fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(live, liveCopy, orphan, orphanSecret, owned).Build()
+		sweeper := &OrphanSweeper{Client: c, Reader: c}
+		before := testutil.ToFloat64(orphansTotal.WithLabelValues(string(demov2.OutputKindSecret)))
+
+		Expect(sweeper.sweep(ctx)).To(Succeed())
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(orphan), &corev1.ConfigMap{})).To(
+			Satisfy(apierrors.IsNotFound))
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(orphanSecret), &corev1.Secret{})).To(
+			Satisfy(apierrors.IsNotFound))
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(liveCopy), &corev1.ConfigMap{})).To(Succeed())
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(owned), &corev1.ConfigMap{})).To(Succeed())
+		Expect(testutil.ToFloat64(orphansTotal.WithLabelValues(string(demov2.OutputKindSecret)))).To(Equal(before + 1))
+	})
+})
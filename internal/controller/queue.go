@@ -0,0 +1,373 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// queuePriorities are the priorities of the Simples, in the order they are served
+var queuePriorities = []demov2.Priority{demov2.PriorityHigh, demov2.PriorityNormal, demov2.PriorityLow}
+
+// priorityQueue is the workqueue of the Simple controller. It hands out the
+// High Simples before the Normal ones and those before the Low ones, and takes
+// turns between the namespaces of a priority, so that a namespace with many
+// Simples can't hold back the Simples of the others. Like the client-go
+// queue, a request is never queued twice nor handed out to two workers.
+// Unlike it, no request is handed out once the queue is shut down: the
+// manager is stopping, the next leader reconciles every Simple anyway.
+type priorityQueue struct {
+	rateLimiter workqueue.TypedRateLimiter[reconcile.Request]
+	// priorityOf returns the priority of the Simple of a request
+	priorityOf func(reconcile.Request) demov2.Priority
+	// debounce, when set, is how long the events of a Simple must stop before
+	// its request is queued, so a burst of events is reconciled once. The
+	// requeues of the reconciliations aren't delayed.
+	debounce time.Duration
+
+	mu   sync.Mutex
+	cond *sync.Cond
+	// levels hold the queued requests, by priority in queuePriorities order
+	levels []fairQueue
+	// dirty holds the priority of the requests waiting to be handed out,
+	// queued or added again while being processed
+	dirty      map[reconcile.Request]int
+	processing map[reconcile.Request]struct{}
+	// debounced holds the requests waiting for their events to stop
+	debounced map[reconcile.Request]*debouncedRequest
+	// waiting holds the requests added with a delay, by earliest deadline
+	waiting  map[reconcile.Request]*waitingRequest
+	shutDown bool
+	// queued is the number of requests in levels
+	queued int
+	// progress is when a request was last handed out or done, or when the
+	// queue stopped being empty
+	progress time.Time
+}
+
+var _ workqueue.TypedRateLimitingInterface[reconcile.Request] = &priorityQueue{}
+
+// newPriorityQueue returns a priority queue retrying the failed requests
+// after the delay of rateLimiter.
+func newPriorityQueue(rateLimiter workqueue.TypedRateLimiter[reconcile.Request],
+	priorityOf func(reconcile.Request) demov2.Priority) *priorityQueue {
+	q := &priorityQueue{
+		rateLimiter: rateLimiter,
+		priorityOf:  priorityOf,
+		levels:      make([]fairQueue, len(queuePriorities)),
+		dirty:       map[reconcile.Request]int{},
+		processing:  map[reconcile.Request]struct{}{},
+		debounced:   map[reconcile.Request]*debouncedRequest{},
+		waiting:     map[reconcile.Request]*waitingRequest{},
+	}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// fairQueue is a FIFO queue per namespace, served in turns.
+type fairQueue struct {
+	// namespaces are the namespaces with queued requests, next to be served first
+	namespaces []string
+	requests   map[string][]reconcile.Request
+}
+
+func (f *fairQueue) push(req reconcile.Request) {
+	if f.requests == nil {
+		f.requests = map[string][]reconcile.Request{}
+	}
+	if len(f.requests[req.Namespace]) == 0 {
+		f.namespaces = append(f.namespaces, req.Namespace)
+	}
+	f.requests[req.Namespace] = append(f.requests[req.Namespace], req)
+}
+
+func (f *fairQueue) pop() (reconcile.Request, bool) {
+	if len(f.namespaces) == 0 {
+		return reconcile.Request{}, false
+	}
+	namespace := f.namespaces[0]
+	f.namespaces = f.namespaces[1:]
+	requests := f.requests[namespace]
+	req := requests[0]
+	if len(requests) == 1 {
+		delete(f.requests, namespace)
+	} else {
+		f.requests[namespace] = requests[1:]
+		f.namespaces = append(f.namespaces, namespace)
+	}
+	return req, true
+}
+
+// push queues req at priority level, with q.mu held.
+func (q *priorityQueue) push(req reconcile.Request, level int) {
+	if q.queued == 0 {
+		q.progress = time.Now()
+	}
+	q.queued++
+	q.levels[level].push(req)
+	queueDepth.WithLabelValues(string(queuePriorities[level])).Inc()
+	q.cond.Signal()
+}
+
+// debounceMaxWait is how many debounce windows a request waits at most,
+// so a Simple whose events never stop is still reconciled
+const debounceMaxWait = 10
+
+// debouncedRequest is when the first and the last event of a debounced request were added.
+type debouncedRequest struct {
+	first, last time.Time
+}
+
+// Add queues req, once its events stopped for the debounce window if any.
+// The event handlers of the controller add their requests here.
+func (q *priorityQueue) Add(req reconcile.Request) {
+	if q.debounce <= 0 {
+		q.add(req)
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.shutDown {
+		return
+	}
+	now := time.Now()
+	if pending, ok := q.debounced[req]; ok {
+		pending.last = now
+		return
+	}
+	q.debounced[req] = &debouncedRequest{first: now, last: now}
+	time.AfterFunc(q.debounce, func() { q.flushDebounced(req) })
+}
+
+// flushDebounced queues the debounced req if its events stopped for the
+// debounce window, or it waited for debounceMaxWait windows already, and
+// checks again later otherwise.
+func (q *priorityQueue) flushDebounced(req reconcile.Request) {
+	q.mu.Lock()
+	pending := q.debounced[req]
+	now := time.Now()
+	wait := min(pending.last.Add(q.debounce).Sub(now), pending.first.Add(debounceMaxWait*q.debounce).Sub(now))
+	if wait > 0 {
+		q.mu.Unlock()
+		time.AfterFunc(wait, func() { q.flushDebounced(req) })
+		return
+	}
+	delete(q.debounced, req)
+	q.mu.Unlock()
+	q.add(req)
+}
+
+// add queues req at once.
+func (q *priorityQueue) add(req reconcile.Request) {
+	// Look the priority up before locking, it reads the Simple from the cache
+	level := slices.Index(queuePriorities, q.priorityOf(req))
+	if level < 0 {
+		level = slices.Index(queuePriorities, demov2.PriorityNormal)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.shutDown {
+		return
+	}
+	if _, ok := q.dirty[req]; ok {
+		return
+	}
+	q.dirty[req] = level
+	// Requests added while being processed are queued once they are done
+	if _, ok := q.processing[req]; ok {
+		return
+	}
+	q.push(req, level)
+}
+
+// waitingRequest is the deadline of a request added with a delay, and the
+// timer adding it then.
+type waitingRequest struct {
+	deadline time.Time
+	timer    *time.Timer
+}
+
+// AddAfter queues req once duration passed. A request waits once: adding it
+// again only moves its deadline earlier, the way the delaying queue of
+// client-go keeps the earliest one, so the requeues of a busy Simple don't
+// pile up timers.
+func (q *priorityQueue) AddAfter(req reconcile.Request, duration time.Duration) {
+	if duration <= 0 {
+		q.add(req)
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.shutDown {
+		return
+	}
+	deadline := time.Now().Add(duration)
+	if pending, ok := q.waiting[req]; ok {
+		if !deadline.Before(pending.deadline) {
+			return
+		}
+		pending.timer.Stop()
+	}
+	waiting := &waitingRequest{deadline: deadline}
+	waiting.timer = time.AfterFunc(duration, func() { q.addWaiting(req, waiting) })
+	q.waiting[req] = waiting
+}
+
+// addWaiting queues req when waiting is still its deadline.
+func (q *priorityQueue) addWaiting(req reconcile.Request, waiting *waitingRequest) {
+	q.mu.Lock()
+	if q.waiting[req] != waiting {
+		q.mu.Unlock()
+		return
+	}
+	delete(q.waiting, req)
+	q.mu.Unlock()
+	q.add(req)
+}
+
+func (q *priorityQueue) AddRateLimited(req reconcile.Request) {
+	q.AddAfter(req, q.rateLimiter.When(req))
+}
+
+func (q *priorityQueue) Forget(req reconcile.Request) {
+	q.rateLimiter.Forget(req)
+}
+
+func (q *priorityQueue) NumRequeues(req reconcile.Request) int {
+	return q.rateLimiter.NumRequeues(req)
+}
+
+func (q *priorityQueue) Get() (reconcile.Request, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for {
+		if q.shutDown {
+			return reconcile.Request{}, true
+		}
+		for level := range q.levels {
+			if req, ok := q.levels[level].pop(); ok {
+				q.queued--
+				q.progress = time.Now()
+				queueDepth.WithLabelValues(string(queuePriorities[level])).Dec()
+				delete(q.dirty, req)
+				q.processing[req] = struct{}{}
+				return req, false
+			}
+		}
+		q.cond.Wait()
+	}
+}
+
+func (q *priorityQueue) Done(req reconcile.Request) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.processing, req)
+	q.progress = time.Now()
+	if level, ok := q.dirty[req]; ok {
+		q.push(req, level)
+	}
+	// ShutDownWithDrain waits for the processing requests
+	q.cond.Broadcast()
+}
+
+func (q *priorityQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.queued
+}
+
+// stalledFor returns how long requests have been queued without any being
+// handed out or done, 0 if the queue is empty.
+func (q *priorityQueue) stalledFor() time.Duration {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.queued == 0 {
+		return 0
+	}
+	return time.Since(q.progress)
+}
+
+func (q *priorityQueue) ShutDown() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.shutDown = true
+	q.cond.Broadcast()
+}
+
+func (q *priorityQueue) ShutDownWithDrain() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.shutDown = true
+	q.cond.Broadcast()
+	for len(q.processing) > 0 {
+		q.cond.Wait()
+	}
+}
+
+func (q *priorityQueue) ShuttingDown() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.shutDown
+}
+
+// newQueue is the NewQueue of the Simple controller.
+func (r *SimpleReconciler) newQueue(_ string,
+	rateLimiter workqueue.TypedRateLimiter[reconcile.Request]) workqueue.TypedRateLimitingInterface[reconcile.Request] {
+	q := newPriorityQueue(rateLimiter, r.priorityOf)
+	q.debounce = r.DebounceWindow
+	r.queue.Store(q)
+	return q
+}
+
+// QueueHealthz returns a health check failing once Simples have been waiting
+// in the queue of the controller for stallTimeout without any of them being
+// reconciled, e.g. because all the workers hang. It passes until the
+// controller starts, on the replicas that aren't the leader.
+func (r *SimpleReconciler) QueueHealthz(stallTimeout time.Duration) healthz.Checker {
+	return func(_ *http.Request) error {
+		q := r.queue.Load()
+		if q == nil {
+			return nil
+		}
+		if stalled := q.stalledFor(); stalled > stallTimeout {
+			return fmt.Errorf("no Simple reconciled for %s, %d waiting", stalled.Round(time.Second), q.Len())
+		}
+		return nil
+	}
+}
+
+// priorityOf returns the priority of the Simple of req, Normal if it isn't
+// in the cache.
+func (r *SimpleReconciler) priorityOf(req reconcile.Request) demov2.Priority {
+	var simple demov2.Simple
+	if err := r.Get(context.Background(), req.NamespacedName, &simple); err != nil {
+		return demov2.PriorityNormal
+	}
+	return simple.Spec.PriorityOrDefault()
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+var _ = Describe("Priority queue", func() {
+	request := func(namespace, name string) reconcile.Request {
+		return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}}
+	}
+	priorities := map[string]demov2.Priority{"alert": demov2.PriorityHigh, "bulk": demov2.PriorityLow}
+	newQueue := func() *priorityQueue {
+		return newPriorityQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request](),
+			func(req reconcile.Request) demov2.Priority {
+				if priority, ok := priorities[req.Name]; ok {
+					return priority
+				}
+				return demov2.PriorityNormal
+			})
+	}
+	get := func(q *priorityQueue) reconcile.Request {
+		req, shutDown := q.Get()
+		Expect(shutDown).To(BeFalse())
+		q.Done(req)
+		return req
+	}
+
+	It("should hand out the Simples by priority and take turns between namespaces", func() {
+		q := newQueue()
+		q.Add(request("team-a", "bulk"))
+		q.Add(request("team-a", "one"))
+		q.Add(request("team-a", "two"))
+		q.Add(request("team-a", "three"))
+		q.Add(request("team-b", "one"))
+		q.Add(request("team-b", "alert"))
+		Expect(q.Len()).To(Equal(6))
+
+		Expect(get(q)).To(Equal(request("team-b", "alert")))
+		Expect(get(q)).To(Equal(request("team-a", "one")))
+		Expect(get(q)).To(Equal(request("team-b", "one")))
+		Expect(get(q)).To(Equal(request("team-a", "two")))
+		Expect(get(q)).To(Equal(request("team-a", "three")))
+		Expect(get(q)).To(Equal(request("team-a", "bulk")))
+		Expect(q.Len()).To(BeZero())
+	})
+
+	It("should queue a request added while it is processed once it is done", func() {
+		q := newQueue()
+		q.Add(request("default", "test"))
+		q.Add(request("default", "test"))
+		Expect(q.Len()).To(Equal(1))
+
+		req, _ := q.Get()
+		q.Add(req)
+		Expect(q.Len()).To(BeZero())
+		q.Done(req)
+		Expect(q.Len()).To(Equal(1))
+	})
+
+	It("should add the delayed requests after their delay", func() {
+		q := newQueue()
+		q.AddAfter(request("default", "test"), 50*time.Millisecond)
+		Expect(q.Len()).To(BeZero())
+		Eventually(q.Len).Should(Equal(1))
+	})
+
+	It("should only keep the earliest deadline of a delayed request", func() {
+		q := newQueue()
+		req := request("default", "test")
+		q.AddAfter(req, time.Hour)
+		q.AddAfter(req, 50*time.Millisecond)
+		for range 100 {
+			q.AddAfter(req, time.Minute)
+		}
+		Expect(q.waiting).To(HaveLen(1))
+		Eventually(q.Len).Should(Equal(1))
+		Expect(q.waiting).To(BeEmpty())
+
+		Expect(get(q)).To(Equal(req))
+		Consistently(q.Len, 100*time.Millisecond, 10*time.Millisecond).Should(BeZero())
+	})
+
+	It("should queue a burst of events once they stopped for the debounce window", func() {
+		q := newQueue()
+		q.debounce = 100 * time.Millisecond
+		req := request("default", "test")
+		var last time.Time
+		for range 5 {
+			q.Add(req)
+			last = time.Now()
+			Consistently(q.Len, 40*time.Millisecond, 10*time.Millisecond).Should(BeZero())
+		}
+		Eventually(q.Len).Should(Equal(1))
+		Expect(time.Since(last)).To(BeNumerically(">=", 100*time.Millisecond))
+		Expect(get(q)).To(Equal(req))
+
+		By("not delaying the requeues")
+		q.AddAfter(req, 0)
+		Expect(q.Len()).To(Equal(1))
+	})
+
+	It("should hand out nothing once shut down", func() {
+		q := newQueue()
+		q.ShutDown()
+		q.Add(request("default", "test"))
+		_, shutDown := q.Get()
+		Expect(shutDown).To(BeTrue())
+	})
+})
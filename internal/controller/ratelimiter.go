@@ -0,0 +1,48 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// RateLimiterOptions configures the workqueue rate limiter of the Simple controller.
+type RateLimiterOptions struct {
+	// BaseDelay is the delay before retrying a Simple after its first failure
+	BaseDelay time.Duration
+	// MaxDelay caps the per-Simple exponential backoff
+	MaxDelay time.Duration
+	// QPS and Burst limit the requeues of all Simples together
+	QPS   float64
+	Burst int
+}
+
+// NewRateLimiter returns a rate limiter combining a per-Simple exponential
+// backoff, reset once a Simple reconciles successfully, with an overall token
+// bucket, so that a failing Simple can't starve the others.
+func NewRateLimiter(opts RateLimiterOptions) workqueue.TypedRateLimiter[reconcile.Request] {
+	return workqueue.NewTypedMaxOfRateLimiter(
+		workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](opts.BaseDelay, opts.MaxDelay),
+		&workqueue.TypedBucketRateLimiter[reconcile.Request]{
+			Limiter: rate.NewLimiter(rate.Limit(opts.QPS), opts.Burst),
+		},
+	)
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Rate limiter", func() {
+	It("should back off per Simple and reset on success", func() {
+		limiter := NewRateLimiter(RateLimiterOptions{
+			BaseDelay: time.Second,
+			MaxDelay:  4 * time.Second,
+			QPS:       1000,
+			Burst:     1000,
+		})
+		failing := reconcile.Request{NamespacedName: types.NamespacedName{Name: "failing", Namespace: "default"}}
+		healthy := reconcile.Request{NamespacedName: types.NamespacedName{Name: "healthy", Namespace: "default"}}
+
+		Expect(limiter.When(failing)).To(Equal(time.Second))
+		Expect(limiter.When(failing)).To(Equal(2 * time.Second))
+		Expect(limiter.When(failing)).To(Equal(4 * time.Second))
+		Expect(limiter.When(failing)).To(Equal(4 * time.Second))
+		Expect(limiter.When(healthy)).To(Equal(time.Second))
+
+		limiter.Forget(failing)
+		Expect(limiter.When(failing)).To(Equal(time.Second))
+	})
+})
@@ -0,0 +1,61 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"hash/fnv"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// Shard selects the Simples reconciled by one of several operator replicas.
+// Each Simple belongs to exactly one shard, from the hash of its namespace and
+// name, so the replicas share the work without coordinating with each other.
+type Shard struct {
+	// Count is the number of shards. Sharding is disabled when it is 0 or 1.
+	Count int
+	// Index is the shard of this replica, from 0 to Count-1
+	Index int
+}
+
+// Enabled reports whether the Simples are split between several shards.
+func (s Shard) Enabled() bool {
+	return s.Count > 1
+}
+
+// Owns reports whether the Simple namespace/name belongs to the shard.
+func (s Shard) Owns(namespace, name string) bool {
+	if !s.Enabled() {
+		return true
+	}
+	return shardOf(namespace, name, s.Count) == s.Index
+}
+
+// predicate filters out the events of the Simples of other shards.
+func (s Shard) predicate() predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return s.Owns(obj.GetNamespace(), obj.GetName())
+	})
+}
+
+// shardOf returns the shard, out of count, of the Simple namespace/name.
+func shardOf(namespace, name string, count int) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(namespace + "/" + name))
+	return int(h.Sum32() % uint32(count))
+}
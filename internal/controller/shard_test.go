@@ -0,0 +1,50 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Simple shards", func() {
+	It("should give every Simple to exactly one shard", func() {
+		shards := []Shard{{Count: 3, Index: 0}, {Count: 3, Index: 1}, {Count: 3, Index: 2}}
+		owned := make([]int, len(shards))
+		for i := range 300 {
+			name := fmt.Sprintf("simple-%d", i)
+			owners := 0
+			for j, shard := range shards {
+				if shard.Owns("default", name) {
+					owners++
+					owned[j]++
+				}
+			}
+			Expect(owners).To(Equal(1), name)
+		}
+		for _, n := range owned {
+			Expect(n).To(BeNumerically(">", 50))
+		}
+	})
+
+	It("should own every Simple when sharding is disabled", func() {
+		Expect(Shard{}.Owns("default", "test")).To(BeTrue())
+		Expect(Shard{Count: 1}.Owns("default", "test")).To(BeTrue())
+	})
+})
@@ -0,0 +1,54 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+var _ = Describe("Simple direct API reads", func() {
+	It("should load the referenced Secrets with the APIReader", func() {
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(demov2.AddToScheme(scheme)).To(Succeed())
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "message", Namespace: "default"},
+			Data:       map[string][]byte{"text": []byte("Hello from the API server")},
+		}
+		simple := &demov2.Simple{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: demov2.SimpleSpec{MessageFrom: &demov2.MessageSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "message"},
+				Key:                  "text",
+			}}},
+		}
+		// The cache hasn't seen the Secret yet
+		r := &SimpleReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).Build(), Scheme: scheme}
+		_, err := r.resolveMessageFrom(ctx, simple)
+		Expect(err).To(MatchError(ContainSubstring("loading message from Secret message")))
+
+		r.APIReader = fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+		Expect(r.resolveMessageFrom(ctx, simple)).To(Equal("Hello from the API server"))
+	})
+})
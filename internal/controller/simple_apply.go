@@ -0,0 +1,267 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/csaupgrade"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// defaultFieldManager is the field manager child objects are applied with
+// when SimpleReconciler.FieldManager is not set.
+const defaultFieldManager = "simple-operator"
+
+// fieldManagerOrDefault returns fieldManager, or defaultFieldManager when it is empty.
+func fieldManagerOrDefault(fieldManager string) string {
+	if fieldManager != "" {
+		return fieldManager
+	}
+	return defaultFieldManager
+}
+
+// legacyFieldManagers are the field managers of the Update requests the
+// controller created and updated child objects with before it applied them.
+var legacyFieldManagers = sets.New("manager")
+
+// appliedHashAnnotation holds the hash of the desired state last applied to a
+// child object, telling changes of the desired state apart from drift.
+const appliedHashAnnotation = "simple.example.com/applied-hash"
+
+// apply server-side applies obj, which only carries the fields the controller
+// owns, as a child of simple. Manual edits of these fields and deletions of
+// obj are reverted and reported with a DriftCorrected event. obj is updated
+// with the state returned by the API server.
+func (r *SimpleReconciler) apply(ctx context.Context, simple *demov2.Simple, obj client.Object) error {
+	gvk, err := apiutil.GVKForObject(obj, r.Scheme)
+	if err != nil {
+		return err
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+
+	r.propagate(simple, obj)
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	for k, v := range ownerLabels(simple) {
+		labels[k] = v
+	}
+	obj.SetLabels(labels)
+	if err := controllerutil.SetControllerReference(simple, obj, r.Scheme); err != nil {
+		return err
+	}
+
+	hash, err := desiredHash(obj)
+	if err != nil {
+		return err
+	}
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[appliedHashAnnotation] = hash
+	obj.SetAnnotations(annotations)
+
+	current := currentObject(obj, gvk)
+	found := true
+	if err := r.liveReader().Get(ctx, client.ObjectKeyFromObject(obj), current); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("getting %s %s: %w", gvk.Kind, obj.GetName(), err)
+		}
+		found = false
+	}
+	// A child created by an earlier reconciliation the cache hasn't observed
+	// yet is applied again, but isn't reported as created a second time
+	created := !found && r.expectations.isPending(simple.UID, opCreation, gvk.Kind, obj)
+	var adopted bool
+	if found {
+		if adopted, err = checkAdoption(simple, current, gvk.Kind); err != nil {
+			return err
+		}
+	}
+
+	c, err := r.childClient(simple)
+	if err != nil {
+		return err
+	}
+
+	// Hand the fields set by earlier Update requests over to the apply
+	// manager, otherwise they are never removed from the object
+	if found {
+		patch, err := csaupgrade.UpgradeManagedFieldsPatch(current, legacyFieldManagers, r.fieldManager())
+		if err != nil {
+			return fmt.Errorf("upgrading managed fields of %s %s: %w", gvk.Kind, obj.GetName(), err)
+		}
+		if patch != nil {
+			if err := c.Patch(ctx, current, client.RawPatch(types.JSONPatchType, patch)); err != nil {
+				return fmt.Errorf("upgrading managed fields of %s %s: %w", gvk.Kind, obj.GetName(), err)
+			}
+		}
+	}
+
+	expected := !found && !created && r.expect(simple, opCreation, gvk.Kind, obj)
+	if err := c.Patch(ctx, obj, client.Apply, client.FieldOwner(r.fieldManager()), client.ForceOwnership); err != nil {
+		if expected {
+			r.expectations.observed(simple.UID, opCreation, gvk.Kind, obj)
+		}
+		return fmt.Errorf("applying %s %s: %w%s", gvk.Kind, obj.GetName(), err, forbiddenHint(err, gvk.Kind))
+	}
+
+	var result string
+	switch {
+	case created:
+	case adopted:
+		result = "adopted"
+		r.event(simple, corev1.EventTypeNormal, eventReasonAdopted, "Adopted existing %s %s", gvk.Kind, obj.GetName())
+	case !found && childExpected(simple):
+		result = "recreated"
+		r.event(simple, corev1.EventTypeWarning, eventReasonDriftCorrected,
+			"Recreated deleted %s %s", gvk.Kind, obj.GetName())
+	case !found:
+		result = "created"
+		r.event(simple, corev1.EventTypeNormal, eventReasonChildCreated, "Created %s %s", gvk.Kind, obj.GetName())
+	case r.appliedAt(current).Equal(r.appliedAt(obj)):
+	case current.GetAnnotations()[appliedHashAnnotation] == hash:
+		result = "corrected"
+		r.event(simple, corev1.EventTypeWarning, eventReasonDriftCorrected,
+			"Reverted manual changes to %s %s", gvk.Kind, obj.GetName())
+	default:
+		result = "updated"
+	}
+	if result != "" {
+		log.FromContext(ctx).Info("Applied child object", "kind", gvk.Kind, "name", obj.GetName(), "result", result)
+	}
+	return nil
+}
+
+// checkAdoption tells whether current, the existing object named like a
+// child of kind of simple, is taken over by this apply. Objects made for a
+// Simple of the same name, including an earlier one whose children await
+// garbage collection, are its children already. Others are only adopted
+// without an owner and with the AdoptAnnotation, so objects of the user aren't
+// overwritten by mistake.
+func checkAdoption(simple *demov2.Simple, current client.Object, kind string) (bool, error) {
+	if labeledFor(current, simple) {
+		return false, nil
+	}
+	return checkAdoptionBy(simple, "Simple", current, kind)
+}
+
+// checkAdoptionBy tells whether current, the existing object named like a
+// child of kind of owner, a resource of ownerKind, is taken over by this
+// apply: the objects controlled by owner are its children already, the ones
+// without an owner are only adopted with the AdoptAnnotation on owner.
+func checkAdoptionBy(owner client.Object, ownerKind string, current client.Object, kind string) (bool, error) {
+	if metav1.IsControlledBy(current, owner) {
+		return false, nil
+	}
+	if controller := metav1.GetControllerOf(current); controller != nil {
+		return false, fmt.Errorf("%w: %s %s already exists and is controlled by %s %s",
+			errInvalidSpec, kind, current.GetName(), controller.Kind, controller.Name)
+	}
+	if owner.GetAnnotations()[demov2.AdoptAnnotation] != "true" {
+		return false, fmt.Errorf("%w: %s %s already exists and isn't owned by the %s, "+
+			"annotate the %s with %s=true to adopt it", errInvalidSpec, kind, current.GetName(), ownerKind, ownerKind,
+			demov2.AdoptAnnotation)
+	}
+	return true, nil
+}
+
+// adoptionPatchOptions runs checkAdoption on the existing object named like
+// obj, read through reader, a child of kind of simple that isn't owned by it,
+// and returns the options obj is then applied with: the conflicts with other
+// managers are only forced on the objects made for simple, not on the
+// adopted ones.
+func (r *SimpleReconciler) adoptionPatchOptions(ctx context.Context, reader client.Reader, simple *demov2.Simple,
+	obj client.Object, kind string) ([]client.PatchOption, error) {
+	opts := []client.PatchOption{client.FieldOwner(r.fieldManager())}
+	gvk, err := apiutil.GVKForObject(obj, r.Scheme)
+	if err != nil {
+		return nil, err
+	}
+	current := currentObject(obj, gvk)
+	if err := reader.Get(ctx, client.ObjectKeyFromObject(obj), current); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("getting %s %s: %w", kind, obj.GetName(), err)
+		}
+		return append(opts, client.ForceOwnership), nil
+	}
+	adopted, err := checkAdoption(simple, current, kind)
+	if err != nil {
+		return nil, err
+	}
+	if !adopted {
+		opts = append(opts, client.ForceOwnership)
+	}
+	return opts, nil
+}
+
+// forbiddenHint explains a Forbidden error writing an object of kind: the
+// operator may only write Secrets in the namespaces that allow it.
+func forbiddenHint(err error, kind string) string {
+	if kind != "Secret" || !apierrors.IsForbidden(err) {
+		return ""
+	}
+	return ", bind the secret-writer ClusterRole to the operator in the namespace to allow it"
+}
+
+// childExpected reports whether the children of simple should already exist,
+// because its current generation was reconciled successfully.
+func childExpected(simple *demov2.Simple) bool {
+	return simple.Status.ObservedGeneration == simple.Generation &&
+		meta.IsStatusConditionTrue(simple.Status.Conditions, demov2.ConditionReady)
+}
+
+// appliedAt returns when the fields applied by the controller last changed in
+// obj, ignoring other writers such as status updates. It is nil if the
+// controller never applied obj.
+func (r *SimpleReconciler) appliedAt(obj client.Object) *metav1.Time {
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Manager == r.fieldManager() && entry.Operation == metav1.ManagedFieldsOperationApply &&
+			entry.Subresource == "" {
+			return entry.Time
+		}
+	}
+	return nil
+}
+
+// desiredHash returns the SHA-256 of the desired state in obj.
+func desiredHash(obj client.Object) (string, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
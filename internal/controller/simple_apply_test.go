@@ -0,0 +1,141 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// ownerReferenceTo returns the controller reference to simple.
+func ownerReferenceTo(simple *demov2.Simple) metav1.OwnerReference {
+	return metav1.OwnerReference{
+		APIVersion: demov2.GroupVersion.String(), Kind: "Simple", Name: simple.Name, UID: simple.UID, Controller: ptr.To(true),
+	}
+}
+
+var _ = Describe("Simple adoption", func() {
+	var simple *demov2.Simple
+
+	BeforeEach(func() {
+		simple = &demov2.Simple{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: "new"}}
+	})
+
+	It("should treat the objects made for a Simple of the same name as its children", func() {
+		current := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name:   "test-message",
+			Labels: ownerLabels(simple),
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: demov2.GroupVersion.String(), Kind: "Simple", Name: "test", UID: "old", Controller: ptr.To(true),
+			}},
+		}}
+		Expect(checkAdoption(simple, current, "ConfigMap")).To(BeFalse())
+	})
+
+	It("should only adopt the objects without an owner when asked to", func() {
+		current := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-message"}}
+		_, err := checkAdoption(simple, current, "ConfigMap")
+		Expect(err).To(MatchError(errInvalidSpec))
+		Expect(err).To(MatchError(ContainSubstring("ConfigMap test-message already exists and isn't owned by the Simple")))
+
+		simple.Annotations = map[string]string{demov2.AdoptAnnotation: "true"}
+		Expect(checkAdoption(simple, current, "ConfigMap")).To(BeTrue())
+
+		current.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: "apps/v1", Kind: "Deployment", Name: "other", UID: "other", Controller: ptr.To(true),
+		}}
+		_, err = checkAdoption(simple, current, "ConfigMap")
+		Expect(err).To(MatchError(ContainSubstring("is controlled by Deployment other")))
+	})
+
+	It("should not force the fields of the adopted objects", func() {
+		c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-message", Namespace: "other"}},
+		).Build()
+		r := &SimpleReconciler{Client: c, Scheme: clientgoscheme.Scheme}
+		copyIn := func(namespace string) client.Object {
+			return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-message", Namespace: namespace}}
+		}
+
+		opts, err := r.adoptionPatchOptions(ctx, c, simple, copyIn("new"), "ConfigMap")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(opts).To(ContainElement(client.ForceOwnership))
+
+		_, err = r.adoptionPatchOptions(ctx, c, simple, copyIn("other"), "ConfigMap")
+		Expect(err).To(MatchError(ContainSubstring("isn't owned by the Simple")))
+
+		simple.Annotations = map[string]string{demov2.AdoptAnnotation: "true"}
+		opts, err = r.adoptionPatchOptions(ctx, c, simple, copyIn("other"), "ConfigMap")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(opts).NotTo(ContainElement(client.ForceOwnership))
+	})
+
+	It("should only delete the echo Deployment it controls", func() {
+		deploy := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "test-echo", Namespace: "default"}}
+		c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(deploy).Build()
+		r := &SimpleReconciler{Client: c, Scheme: clientgoscheme.Scheme}
+		Expect(r.reconcileEcho(ctx, simple, nil)).To(Succeed())
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(deploy), deploy)).To(Succeed())
+
+		deploy.OwnerReferences = []metav1.OwnerReference{ownerReferenceTo(simple)}
+		Expect(c.Update(ctx, deploy)).To(Succeed())
+		Expect(r.reconcileEcho(ctx, simple, nil)).To(Succeed())
+		Expect(apierrors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(deploy), deploy))).To(BeTrue())
+
+		By("not deleting anything once it is gone")
+		Expect(r.reconcileEcho(ctx, simple, nil)).To(Succeed())
+	})
+
+	It("should only delete the exposing objects it controls", func() {
+		owned := ownerReferenceTo(simple)
+		c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}},
+			&networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{owned}}},
+		).Build()
+		r := &SimpleReconciler{Client: c, Scheme: clientgoscheme.Scheme}
+		key := client.ObjectKey{Name: "test", Namespace: "default"}
+		Expect(r.deleteChild(ctx, simple, &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}})).To(Succeed())
+		Expect(c.Get(ctx, key, &corev1.Service{})).To(Succeed())
+		Expect(r.deleteChild(ctx, simple, &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}})).To(Succeed())
+		Expect(apierrors.IsNotFound(c.Get(ctx, key, &networkingv1.Ingress{}))).To(BeTrue())
+	})
+
+	It("should only replicate to the namespaces accepting replicas", func() {
+		c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "open",
+				Labels: map[string]string{demov2.AcceptReplicasLabel: "true"}}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "closed",
+				Labels: map[string]string{demov2.AcceptReplicasLabel: "false"}}},
+		).Build()
+		r := &SimpleReconciler{Client: c, Scheme: clientgoscheme.Scheme}
+		Expect(r.acceptsReplicas(ctx, "open")).To(Succeed())
+		Expect(r.acceptsReplicas(ctx, "closed")).To(MatchError(errInvalidSpec))
+		Expect(r.acceptsReplicas(ctx, "missing")).To(MatchError(ContainSubstring("not found")))
+	})
+})
@@ -0,0 +1,274 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// auditPruneInterval is how often the expired SimpleAudits are deleted
+const auditPruneInterval = time.Hour
+
+// auditNamePrefixLength bounds the part of the Simple's name in the names of its SimpleAudits
+const auditNamePrefixLength = 200
+
+// Auditor records the audit trail of the Simples as SimpleAudits, and
+// deletes them once their retention period is over. It is a Runnable doing
+// the pruning, to be added to the manager of a single replica.
+type Auditor struct {
+	// Client writes the SimpleAudits
+	Client client.Client
+
+	// Reader reads the managed fields of the Simples, which the cache drops,
+	// and lists the SimpleAudits to prune without caching them
+	Reader client.Reader
+
+	// Retention is how long the SimpleAudits are kept, forever when 0
+	Retention time.Duration
+}
+
+// NeedLeaderElection makes a single replica prune the SimpleAudits.
+func (a *Auditor) NeedLeaderElection() bool {
+	return true
+}
+
+// Start prunes the expired SimpleAudits every auditPruneInterval until ctx is done.
+func (a *Auditor) Start(ctx context.Context) error {
+	if a.Retention <= 0 {
+		return nil
+	}
+	ticker := time.NewTicker(auditPruneInterval)
+	defer ticker.Stop()
+	for {
+		if err := a.prune(ctx, time.Now().Add(-a.Retention)); err != nil {
+			log.FromContext(ctx).Error(err, "failed to prune the SimpleAudits")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// prune deletes the SimpleAudits recorded before cutoff, a page at a time.
+func (a *Auditor) prune(ctx context.Context, cutoff time.Time) error {
+	var deleted int
+	opts := []client.ListOption{client.Limit(500)}
+	for {
+		var audits demov2.SimpleAuditList
+		if err := a.Reader.List(ctx, &audits, opts...); err != nil {
+			return fmt.Errorf("listing SimpleAudits: %w", err)
+		}
+		for i := range audits.Items {
+			audit := &audits.Items[i]
+			if !audit.Spec.Time.Time.Before(cutoff) {
+				continue
+			}
+			if err := a.Client.Delete(ctx, audit); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("deleting SimpleAudit %s/%s: %w", audit.Namespace, audit.Name, err)
+			}
+			deleted++
+		}
+		if audits.Continue == "" {
+			break
+		}
+		opts = []client.ListOption{client.Limit(500), client.Continue(audits.Continue)}
+	}
+	if deleted > 0 {
+		log.FromContext(ctx).Info("Pruned the expired SimpleAudits", "deleted", deleted)
+	}
+	return nil
+}
+
+// record creates audit. An entry with a fixed name that already exists was
+// recorded by an earlier reconciliation and is left alone.
+func (a *Auditor) record(ctx context.Context, audit *demov2.SimpleAudit) error {
+	if err := a.Client.Create(ctx, audit); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("recording the %s audit entry: %w", audit.Spec.Action, err)
+	}
+	return nil
+}
+
+// specManager returns the field manager of the last change of the spec of
+// simple, read from the API server as the cache drops the managed fields.
+func (a *Auditor) specManager(ctx context.Context, simple *demov2.Simple) (string, error) {
+	current := &metav1.PartialObjectMetadata{}
+	current.SetGroupVersionKind(demov2.GroupVersion.WithKind("Simple"))
+	if err := a.Reader.Get(ctx, client.ObjectKeyFromObject(simple), current); err != nil {
+		return "", fmt.Errorf("reading the managed fields: %w", err)
+	}
+	var latest *metav1.ManagedFieldsEntry
+	for i := range current.ManagedFields {
+		entry := &current.ManagedFields[i]
+		if entry.Subresource != "" || entry.FieldsV1 == nil || !bytes.Contains(entry.FieldsV1.Raw, []byte(`"f:spec"`)) {
+			continue
+		}
+		if latest == nil || (entry.Time != nil && (latest.Time == nil || latest.Time.Before(entry.Time))) {
+			latest = entry
+		}
+	}
+	if latest == nil {
+		return "", nil
+	}
+	return latest.Manager, nil
+}
+
+// newAudit returns the audit entry of action on simple, named after the
+// Simple and suffix, or generated from its name when suffix is empty.
+func newAudit(simple *demov2.Simple, action demov2.AuditAction, suffix string) (*demov2.SimpleAudit, error) {
+	specHash, err := specHash(simple)
+	if err != nil {
+		return nil, err
+	}
+	prefix := simple.Name
+	if len(prefix) > auditNamePrefixLength {
+		prefix = strings.TrimRight(prefix[:auditNamePrefixLength], "-.")
+	}
+	prefix = fmt.Sprintf("%s-%.8s-", prefix, simple.UID)
+	audit := &demov2.SimpleAudit{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: simple.Namespace,
+			Labels:    map[string]string{demov2.AuditSimpleLabel: labelValue(simple.Name)},
+		},
+		Spec: demov2.SimpleAuditSpec{
+			SimpleName: simple.Name,
+			SimpleUID:  simple.UID,
+			Action:     action,
+			Time:       metav1.Now(),
+			Generation: simple.Generation,
+			SpecHash:   specHash,
+		},
+	}
+	if suffix == "" {
+		audit.GenerateName = prefix
+	} else {
+		audit.Name = prefix + suffix
+	}
+	return audit, nil
+}
+
+// labelValue returns s as a label value: unchanged if it fits, otherwise
+// truncated and suffixed with its hash, so long names still label their
+// objects apart.
+func labelValue(s string) string {
+	if len(s) <= validation.LabelValueMaxLength {
+		return s
+	}
+	sum := sha256.Sum256([]byte(s))
+	suffix := hex.EncodeToString(sum[:])[:10]
+	prefix := strings.TrimRight(s[:validation.LabelValueMaxLength-len(suffix)-1], "-.")
+	return prefix + "-" + suffix
+}
+
+// auditSpecChange records the generation of simple. It is named after the
+// generation, so a reconciliation retried before the status records it
+// doesn't record it twice.
+func (r *SimpleReconciler) auditSpecChange(ctx context.Context, simple *demov2.Simple) error {
+	audit, err := newAudit(simple, demov2.AuditActionSpecChanged, fmt.Sprintf("g%d", simple.Generation))
+	if err != nil {
+		return err
+	}
+	if audit.Spec.Actor, err = r.Audit.specManager(ctx, simple); err != nil {
+		return err
+	}
+	return r.Audit.record(ctx, audit)
+}
+
+// auditDelivery records the delivery attempt of a reconciliation, which
+// failed with reconcileErr when it isn't nil. original is the status before
+// the attempt, the sinks it called since are reported.
+func (r *SimpleReconciler) auditDelivery(ctx context.Context, simple *demov2.Simple, original *demov2.SimpleStatus,
+	reconcileErr error) error {
+	audit, err := newAudit(simple, demov2.AuditActionDelivery, "")
+	if err != nil {
+		return err
+	}
+	audit.Spec.Actor = r.fieldManager()
+	audit.Spec.MessageHash = messagesHash(simple)
+	audit.Spec.Outcome = demov2.HistoryOutcomeDelivered
+	if reconcileErr != nil {
+		audit.Spec.Outcome = demov2.HistoryOutcomeFailed
+		audit.Spec.Message = reconcileErr.Error()
+	}
+	audit.Spec.Sinks = attemptedSinks(simple, original)
+	return r.Audit.record(ctx, audit)
+}
+
+// attemptedSinks returns the outcome of the sinks of simple called since its
+// status was original.
+func attemptedSinks(simple *demov2.Simple, original *demov2.SimpleStatus) []demov2.AuditSinkOutcome {
+	var outcomes []demov2.AuditSinkOutcome
+	for _, sink := range simple.Status.Sinks {
+		if sink.LastAttemptTime == nil {
+			continue
+		}
+		previous := findSinkStatus(original.Sinks, sink.Name)
+		if previous != nil && equalTime(previous.LastAttemptTime, sink.LastAttemptTime) {
+			continue
+		}
+		outcomes = append(outcomes, demov2.AuditSinkOutcome{
+			Name:         sink.Name,
+			Delivered:    sink.Delivered,
+			Attempts:     sink.Attempts,
+			ResponseCode: sink.ResponseCode,
+		})
+	}
+	return outcomes
+}
+
+// auditDeletion records the deletion of simple, once. Who deleted it isn't
+// known to the controller.
+func (r *SimpleReconciler) auditDeletion(ctx context.Context, simple *demov2.Simple) error {
+	audit, err := newAudit(simple, demov2.AuditActionDeleted, "deleted")
+	if err != nil {
+		return err
+	}
+	return r.Audit.record(ctx, audit)
+}
+
+// findSinkStatus returns the status of the sink called name, nil if there's none.
+func findSinkStatus(statuses []demov2.SinkStatus, name string) *demov2.SinkStatus {
+	for i := range statuses {
+		if statuses[i].Name == name {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
+
+// equalTime tells whether a and b are both unset or the same time.
+func equalTime(a, b *metav1.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(b)
+}
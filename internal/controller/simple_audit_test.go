@@ -0,0 +1,138 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+var _ = Describe("Simple audit", func() {
+	var (
+		c      client.Client
+		r      *SimpleReconciler
+		simple *demov2.Simple
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(demov2.AddToScheme(scheme)).To(Succeed())
+		changed := metav1.NewTime(time.Now())
+		simple = &demov2.Simple{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "test",
+				Namespace:  "default",
+				UID:        "3f2a9c1e-5b7d-4c1a-9e8f-0a1b2c3d4e5f",
+				Generation: 2,
+				ManagedFields: []metav1.ManagedFieldsEntry{
+					{Manager: "simple-operator", Operation: metav1.ManagedFieldsOperationUpdate, Subresource: "status",
+						Time: &changed, FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:status":{}}`)}},
+					{Manager: "kubectl-edit", Operation: metav1.ManagedFieldsOperationUpdate,
+						Time: &changed, FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:message":{}}}`)}},
+				},
+			},
+			Spec: demov2.SimpleSpec{Message: "Hello"},
+		}
+		c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(simple).Build()
+		r = &SimpleReconciler{Client: c, Scheme: scheme, Audit: &Auditor{Client: c, Reader: c}}
+	})
+
+	It("should record each generation once, with the manager that changed the spec", func() {
+		Expect(r.auditSpecChange(ctx, simple)).To(Succeed())
+		Expect(r.auditSpecChange(ctx, simple)).To(Succeed())
+
+		var audits demov2.SimpleAuditList
+		Expect(c.List(ctx, &audits)).To(Succeed())
+		Expect(audits.Items).To(HaveLen(1))
+		audit := audits.Items[0]
+		Expect(audit.Name).To(Equal("test-3f2a9c1e-g2"))
+		Expect(audit.Labels).To(HaveKeyWithValue(demov2.AuditSimpleLabel, "test"))
+		Expect(audit.Spec.Action).To(Equal(demov2.AuditActionSpecChanged))
+		Expect(audit.Spec.Actor).To(Equal("kubectl-edit"))
+		Expect(audit.Spec.Generation).To(Equal(int64(2)))
+		Expect(audit.Spec.SpecHash).NotTo(BeEmpty())
+	})
+
+	It("should label the entries of Simples with long names apart", func() {
+		Expect(labelValue("test")).To(Equal("test"))
+		long := strings.Repeat("a", 51) + "-long-name-of-a-simple"
+		value := labelValue(long)
+		Expect(value).To(HaveLen(62))
+		Expect(value).To(MatchRegexp(`^a{51}-[0-9a-f]{10}$`))
+		Expect(labelValue(long + "-other")).NotTo(Equal(value))
+		Expect(validation.IsValidLabelValue(labelValue(strings.Repeat("b", 253)))).To(BeEmpty())
+
+		simple.Name = long
+		audit, err := newAudit(simple, demov2.AuditActionSpecChanged, "g1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(audit.Labels).To(HaveKeyWithValue(demov2.AuditSimpleLabel, value))
+		Expect(audit.Spec.SimpleName).To(Equal(long))
+	})
+
+	It("should record every delivery attempt with the sinks it called", func() {
+		original := simple.Status.DeepCopy()
+		attempted := metav1.Now()
+		simple.Status.Sinks = []demov2.SinkStatus{
+			{Name: "http", Attempts: 3, ResponseCode: 502, LastAttemptTime: &attempted},
+		}
+		Expect(r.auditDelivery(ctx, simple, original, errors.New("502 Bad Gateway"))).To(Succeed())
+		Expect(r.auditDelivery(ctx, simple, simple.Status.DeepCopy(), nil)).To(Succeed())
+
+		var audits demov2.SimpleAuditList
+		Expect(c.List(ctx, &audits)).To(Succeed())
+		Expect(audits.Items).To(HaveLen(2))
+		Expect(audits.Items).To(ContainElement(HaveField("Spec", And(
+			HaveField("Action", demov2.AuditActionDelivery),
+			HaveField("Outcome", demov2.HistoryOutcomeFailed),
+			HaveField("Message", "502 Bad Gateway"),
+			HaveField("Sinks", ConsistOf(demov2.AuditSinkOutcome{Name: "http", Attempts: 3, ResponseCode: 502})),
+		))))
+		Expect(audits.Items).To(ContainElement(HaveField("Spec", And(
+			HaveField("Outcome", demov2.HistoryOutcomeDelivered),
+			HaveField("Sinks", BeEmpty()),
+		))))
+	})
+
+	It("should prune the entries older than the retention", func() {
+		old := &demov2.SimpleAudit{
+			ObjectMeta: metav1.ObjectMeta{Name: "old", Namespace: "default"},
+			Spec:       demov2.SimpleAuditSpec{Time: metav1.NewTime(time.Now().Add(-48 * time.Hour))},
+		}
+		recent := &demov2.SimpleAudit{
+			ObjectMeta: metav1.ObjectMeta{Name: "recent", Namespace: "default"},
+			Spec:       demov2.SimpleAuditSpec{Time: metav1.Now()},
+		}
+		Expect(c.Create(ctx, old)).To(Succeed())
+		Expect(c.Create(ctx, recent)).To(Succeed())
+
+		Expect(r.Audit.prune(ctx, time.Now().Add(-24*time.Hour))).To(Succeed())
+		var audits demov2.SimpleAuditList
+		Expect(c.List(ctx, &audits)).To(Succeed())
+		Expect(audits.Items).To(ConsistOf(HaveField("Name", "recent")))
+	})
+})
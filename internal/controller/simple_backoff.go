@@ -0,0 +1,144 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+const (
+	// defaultBackoffDelay is used when Spec.Backoff.Delay is unset
+	defaultBackoffDelay = time.Second
+	// maxBackoffDelay caps the delay between two retries
+	maxBackoffDelay = time.Hour
+)
+
+// deadLetterConfigMapName returns the name of the dead-letter ConfigMap of simple.
+func deadLetterConfigMapName(simple *demov2.Simple) string {
+	return simple.Name + "-dead-letter"
+}
+
+// retriesExhausted reports whether the retries of Spec.Backoff were
+// exhausted for the current generation of simple.
+func retriesExhausted(simple *demov2.Simple) bool {
+	cond := meta.FindStatusCondition(simple.Status.Conditions, demov2.ConditionDegraded)
+	return cond != nil && cond.Status == metav1.ConditionTrue &&
+		cond.Reason == demov2.ReasonRetriesExhausted && cond.ObservedGeneration == simple.Generation
+}
+
+// recordFailure counts a failed reconciliation of simple and records err in
+// Status.LastError. The count restarts when the spec changed since the last
+// reconciliation.
+func recordFailure(simple *demov2.Simple, err error, now time.Time) {
+	if simple.Status.ObservedGeneration != simple.Generation || simple.Status.FirstFailureTime == nil {
+		simple.Status.FailedAttempts = 0
+		simple.Status.FirstFailureTime = &metav1.Time{Time: now}
+	}
+	simple.Status.FailedAttempts++
+	simple.Status.LastError = &demov2.LastError{
+		Message:    err.Error(),
+		Reason:     failureReason(err),
+		Time:       metav1.Time{Time: now},
+		RetryCount: simple.Status.FailedAttempts - 1,
+	}
+}
+
+// nextRetry returns the delay before retrying simple after a failure, or
+// false once Spec.Backoff is exhausted.
+func nextRetry(simple *demov2.Simple, now time.Time) (time.Duration, bool) {
+	backoff := simple.Spec.Backoff
+	if simple.Status.FailedAttempts > backoff.MaxRetries {
+		return 0, false
+	}
+	if backoff.MaxDuration != nil && now.Sub(simple.Status.FirstFailureTime.Time) >= backoff.MaxDuration.Duration {
+		return 0, false
+	}
+
+	delay := defaultBackoffDelay
+	if backoff.Delay != nil {
+		delay = backoff.Delay.Duration
+	}
+	factor := time.Duration(max(backoff.Factor, 1))
+	for i := int32(1); i < simple.Status.FailedAttempts && delay < maxBackoffDelay; i++ {
+		delay *= factor
+	}
+	return min(delay, maxBackoffDelay), true
+}
+
+// resetFailures clears the failure count and last error of simple after a success.
+func resetFailures(simple *demov2.Simple) {
+	simple.Status.FailedAttempts = 0
+	simple.Status.FirstFailureTime = nil
+	simple.Status.LastError = nil
+}
+
+// exhaustRetries marks simple as Failed for good, and copies its messages
+// and the last error into the dead-letter ConfigMap if Spec.Backoff.DeadLetter is set.
+func (r *SimpleReconciler) exhaustRetries(ctx context.Context, simple *demov2.Simple, cause error) error {
+	message := "Giving up after " + strconv.Itoa(int(simple.Status.FailedAttempts)) + " failed attempts: " + cause.Error()
+	setCondition(simple, demov2.ConditionDegraded, metav1.ConditionTrue, demov2.ReasonRetriesExhausted, message)
+	setCondition(simple, demov2.ConditionProgressing, metav1.ConditionFalse,
+		demov2.ReasonRetriesExhausted, "Retries exhausted, waiting for a spec change")
+	r.event(simple, corev1.EventTypeWarning, eventReasonRetriesExhausted, message)
+	countDeadLetter(simple)
+	if !simple.Spec.Backoff.DeadLetter {
+		return nil
+	}
+
+	messages := simple.Spec.AllMessages()
+	texts := make([]string, 0, len(messages))
+	for _, m := range messages {
+		texts = append(texts, m.Text)
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deadLetterConfigMapName(simple),
+			Namespace: simple.Namespace,
+		},
+		Data: map[string]string{
+			messageConfigMapKey: loggedMessage(simple, strings.Join(texts, "\n")),
+			"error":             cause.Error(),
+			"attempts":          strconv.Itoa(int(simple.Status.FailedAttempts)),
+			"firstFailureTime":  simple.Status.FirstFailureTime.UTC().Format(time.RFC3339),
+		},
+	}
+	return r.apply(ctx, simple, cm)
+}
+
+// countDeadLetter counts simple in deadLettersTotal once for each sink it
+// failed to deliver to, or as sink "none" if it failed before reaching them.
+func countDeadLetter(simple *demov2.Simple) {
+	counted := false
+	for _, status := range simple.Status.Sinks {
+		if !status.Delivered {
+			deadLettersTotal.WithLabelValues(status.Name).Inc()
+			counted = true
+		}
+	}
+	if !counted {
+		deadLettersTotal.WithLabelValues("none").Inc()
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// messageCatalogIndex is the field index listing the Simples delivering an
+// entry of a message catalog ConfigMap
+const messageCatalogIndex = ".spec.catalog"
+
+// setupMessageCatalogIndex registers messageCatalogIndex.
+func setupMessageCatalogIndex(ctx context.Context, mgr ctrl.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(ctx, &demov2.Simple{}, messageCatalogIndex,
+		func(obj client.Object) []string {
+			spec := obj.(*demov2.Simple).Spec
+			if spec.MessageKey == "" || spec.Catalog == "" {
+				return nil
+			}
+			return []string{spec.Catalog}
+		})
+}
+
+// catalogLocales returns the locales the catalog is searched for, most
+// specific first: locale less its subtags one by one, then the same for
+// defaultLocale, without duplicates.
+func catalogLocales(locale, defaultLocale string) []string {
+	var locales []string
+	seen := map[string]bool{}
+	for _, l := range []string{locale, defaultLocale} {
+		for l != "" {
+			if !seen[l] {
+				seen[l] = true
+				locales = append(locales, l)
+			}
+			i := strings.LastIndex(l, "-")
+			if i < 0 {
+				break
+			}
+			l = l[:i]
+		}
+	}
+	return locales
+}
+
+// resolveMessageKey returns the entry of Spec.Catalog for Spec.MessageKey in
+// the first of its locales the catalog has, or without locale, and records
+// that locale in Status.Locale.
+func (r *SimpleReconciler) resolveMessageKey(ctx context.Context, simple *demov2.Simple) (string, error) {
+	key := simple.Spec.MessageKey
+	var cm corev1.ConfigMap
+	if err := r.Get(ctx, types.NamespacedName{Name: simple.Spec.Catalog, Namespace: simple.Namespace}, &cm); err != nil {
+		return "", fmt.Errorf("loading message catalog %s: %w", simple.Spec.Catalog, err)
+	}
+	locales := catalogLocales(cmp.Or(simple.Spec.Locale, r.DefaultLocale), r.DefaultLocale)
+	for _, locale := range locales {
+		if text, ok := cm.Data[key+"."+locale]; ok {
+			simple.Status.Locale = locale
+			return text, nil
+		}
+	}
+	if text, ok := cm.Data[key]; ok {
+		simple.Status.Locale = ""
+		return text, nil
+	}
+	return "", fmt.Errorf("message catalog %s has no key %q for the locales %s", simple.Spec.Catalog, key,
+		strings.Join(locales, ", "))
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+var _ = Describe("Simple message catalog", func() {
+	It("should search the locale down to its language, then the default locale", func() {
+		Expect(catalogLocales("de-CH-1996", "en-GB")).To(Equal([]string{"de-CH-1996", "de-CH", "de", "en-GB", "en"}))
+		Expect(catalogLocales("en-US", "en")).To(Equal([]string{"en-US", "en"}))
+		Expect(catalogLocales("", "")).To(BeEmpty())
+	})
+
+	It("should resolve the message key against the catalog", func() {
+		catalog := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "messages", Namespace: "default"},
+			Data:       map[string]string{"greeting.en": "Hello", "greeting.de-AT": "Servus", "farewell": "Bye"},
+		}
+		r := &SimpleReconciler{
+			Client:        fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(catalog).Build(),
+			Scheme:        scheme.Scheme,
+			DefaultLocale: "en",
+		}
+		simple := &demov2.Simple{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec:       demov2.SimpleSpec{MessageKey: "greeting", Locale: "de-AT", Catalog: "messages"},
+		}
+		Expect(r.resolveMessageKey(ctx, simple)).To(Equal("Servus"))
+		Expect(simple.Status.Locale).To(Equal("de-AT"))
+
+		simple.Spec.Locale = "fr-CA"
+		Expect(r.resolveMessageKey(ctx, simple)).To(Equal("Hello"))
+		Expect(simple.Status.Locale).To(Equal("en"))
+
+		simple.Spec.MessageKey = "farewell"
+		Expect(r.resolveMessageKey(ctx, simple)).To(Equal("Bye"))
+		Expect(simple.Status.Locale).To(BeEmpty())
+
+		simple.Spec.MessageKey = "missing"
+		_, err := r.resolveMessageKey(ctx, simple)
+		Expect(err).To(MatchError(ContainSubstring(`no key "missing" for the locales fr-CA, fr, en`)))
+	})
+})
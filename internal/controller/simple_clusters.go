@@ -0,0 +1,253 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// remoteTimeout bounds each request to a remote cluster
+const remoteTimeout = 30 * time.Second
+
+// remoteCleanupTimeout is how long after the deletion of a Simple its finalizer
+// keeps trying to delete the copies of a cluster it can't reach
+const remoteCleanupTimeout = 10 * time.Minute
+
+// kubeconfigSecretIndex is the field index listing the Simples replicating to
+// a cluster with the kubeconfig of a Secret
+const kubeconfigSecretIndex = ".spec.clusters.kubeconfigSecretRef.name"
+
+// setupClustersIndex registers kubeconfigSecretIndex.
+func setupClustersIndex(ctx context.Context, mgr ctrl.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(ctx, &demov2.Simple{}, kubeconfigSecretIndex,
+		func(obj client.Object) []string {
+			var names []string
+			for _, cluster := range obj.(*demov2.Simple).Spec.Clusters {
+				names = append(names, cluster.KubeconfigSecretRef.Name)
+			}
+			return names
+		})
+}
+
+// remoteClientKey identifies a kubeconfig in SimpleReconciler.remoteClients.
+type remoteClientKey struct {
+	secret types.UID
+	key    string
+}
+
+// remoteClientEntry is a client of SimpleReconciler.remoteClients. The
+// resource version of the Secret makes a changed kubeconfig replace it.
+type remoteClientEntry struct {
+	resourceVersion string
+	client          client.Client
+	httpClient      *http.Client
+}
+
+// remoteClient returns a client of cluster, built from its kubeconfig Secret.
+func (r *SimpleReconciler) remoteClient(ctx context.Context, simple *demov2.Simple,
+	cluster demov2.ClusterTarget) (client.Client, error) {
+	ref := cluster.KubeconfigSecretRef
+	var secret corev1.Secret
+	if err := r.liveReader().Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: simple.Namespace}, &secret); err != nil {
+		return nil, fmt.Errorf("loading kubeconfig from Secret %s: %w", ref.Name, err)
+	}
+	key := remoteClientKey{secret: secret.UID, key: ref.Key}
+	if entry, ok := r.remoteClients.Load(key); ok && entry.(remoteClientEntry).resourceVersion == secret.ResourceVersion {
+		return entry.(remoteClientEntry).client, nil
+	}
+
+	kubeconfig, ok := secret.Data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf("secret %s has no kubeconfig in key %q", ref.Name, ref.Key)
+	}
+	config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("parsing kubeconfig from Secret %s: %w", ref.Name, err)
+	}
+	config.Timeout = remoteTimeout
+	// A proxy keeps the transport out of the cache of client-go, so that it
+	// goes away with the client once the kubeconfig changes
+	if config.Proxy == nil {
+		config.Proxy = http.ProxyFromEnvironment
+	}
+	httpClient, err := rest.HTTPClientFor(config)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to cluster %s: %w", cluster.Name, err)
+	}
+	c, err := client.New(config, client.Options{Scheme: r.Scheme, HTTPClient: httpClient})
+	if err != nil {
+		return nil, fmt.Errorf("connecting to cluster %s: %w", cluster.Name, err)
+	}
+	if r.DryRun != nil {
+		c = NewDryRunClient(c, r.DryRun)
+	}
+	entry := remoteClientEntry{resourceVersion: secret.ResourceVersion, client: c, httpClient: httpClient}
+	if stale, ok := r.remoteClients.Swap(key, entry); ok {
+		stale.(remoteClientEntry).httpClient.CloseIdleConnections()
+	}
+	return c, nil
+}
+
+// remoteNamespace returns the namespace of cluster the messages of simple are written to.
+func remoteNamespace(simple *demov2.Simple, cluster demov2.ClusterTarget) string {
+	if cluster.Namespace != "" {
+		return cluster.Namespace
+	}
+	return simple.Namespace
+}
+
+// reconcileClusters replicates the message ConfigMap of simple, with data, to
+// the clusters of Spec.Clusters and reports the outcome in Status.Clusters
+// and the ClustersSynced condition. The copies carry the ownerLabels of
+// simple and are deleted by its finalizer.
+func (r *SimpleReconciler) reconcileClusters(ctx context.Context, simple *demov2.Simple, data map[string]string) error {
+	if len(simple.Spec.Clusters) == 0 {
+		simple.Status.Clusters = nil
+		meta.RemoveStatusCondition(&simple.Status.Conditions, demov2.ConditionClustersSynced)
+		return nil
+	}
+
+	previous := map[string]demov2.ClusterSyncStatus{}
+	for _, status := range simple.Status.Clusters {
+		previous[status.Name] = status
+	}
+	statuses := make([]demov2.ClusterSyncStatus, 0, len(simple.Spec.Clusters))
+	var errs []error
+	for _, cluster := range simple.Spec.Clusters {
+		status := previous[cluster.Name]
+		status.Name = cluster.Name
+		if err := r.syncCluster(ctx, simple, cluster, data); err != nil {
+			err = fmt.Errorf("cluster %s: %w", cluster.Name, err)
+			status.Synced = false
+			status.LastError = err.Error()
+			errs = append(errs, err)
+		} else {
+			now := metav1.Now()
+			status.Synced = true
+			status.LastSyncTime = &now
+			status.LastError = ""
+		}
+		statuses = append(statuses, status)
+	}
+	simple.Status.Clusters = statuses
+
+	if err := errors.Join(errs...); err != nil {
+		setCondition(simple, demov2.ConditionClustersSynced, metav1.ConditionFalse, demov2.ReasonSyncFailed, err.Error())
+		return err
+	}
+	setCondition(simple, demov2.ConditionClustersSynced, metav1.ConditionTrue, demov2.ReasonSynced,
+		fmt.Sprintf("Synced to %d clusters", len(statuses)))
+	return nil
+}
+
+// syncCluster applies the copy of the message ConfigMap of simple to cluster.
+func (r *SimpleReconciler) syncCluster(ctx context.Context, simple *demov2.Simple, cluster demov2.ClusterTarget,
+	data map[string]string) error {
+	c, err := r.remoteClient(ctx, simple, cluster)
+	if err != nil {
+		return err
+	}
+	kind := simple.Spec.OutputKind()
+	obj := messageObject(simple, kind, remoteNamespace(simple, cluster), data)
+	obj.SetLabels(ownerLabels(simple))
+	r.propagate(simple, obj)
+	opts, err := r.adoptionPatchOptions(ctx, c, simple, obj, string(kind))
+	if err != nil {
+		return err
+	}
+	if err := c.Patch(ctx, obj, client.Apply, opts...); err != nil {
+		return fmt.Errorf("applying %s %s/%s: %w", kind, obj.GetNamespace(), obj.GetName(), err)
+	}
+
+	// Spec.Output may have changed the kind of the copy
+	return deleteRemoteCopy(ctx, c, simple, otherOutputKind(simple), obj.GetNamespace())
+}
+
+// deleteRemoteCopy deletes the copy of kind of the messages of simple from
+// namespace through c. Objects not labeled for simple belong to someone else
+// and are left alone.
+func deleteRemoteCopy(ctx context.Context, c client.Client, simple *demov2.Simple, kind demov2.OutputKind,
+	namespace string) error {
+	obj := messageObject(simple, kind, namespace, nil)
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !labeledFor(obj, simple) {
+		return nil
+	}
+	if err := c.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("deleting %s %s/%s: %w", kind, namespace, obj.GetName(), err)
+	}
+	return nil
+}
+
+// deleteRemoteCopies deletes the copies of the messages of simple in the
+// clusters of Spec.Clusters. Clusters whose kubeconfig Secret is gone are
+// skipped, and so are the clusters still failing remoteCleanupTimeout after
+// the deletion of simple, leaving their copies behind rather than blocking
+// the deletion forever.
+func (r *SimpleReconciler) deleteRemoteCopies(ctx context.Context, simple *demov2.Simple) error {
+	log := log.FromContext(ctx)
+	for _, cluster := range simple.Spec.Clusters {
+		err := r.deleteClusterCopies(ctx, simple, cluster)
+		switch {
+		case apierrors.IsNotFound(err):
+			log.Info("Skipping the cleanup of a cluster without kubeconfig", "cluster", cluster.Name)
+		case err != nil && simple.DeletionTimestamp != nil && time.Since(simple.DeletionTimestamp.Time) > remoteCleanupTimeout:
+			log.Error(err, "Giving up on the cleanup of a remote cluster", "cluster", cluster.Name)
+			r.event(simple, corev1.EventTypeWarning, eventReasonCleanupFailed,
+				"Left the copies in cluster %s behind: %v", cluster.Name, err)
+		case err != nil:
+			return fmt.Errorf("cluster %s: %w", cluster.Name, err)
+		default:
+			log.Info("Deleted the copies in a remote cluster", "cluster", cluster.Name)
+		}
+	}
+	return nil
+}
+
+// deleteClusterCopies deletes the copies of the messages of simple in cluster.
+func (r *SimpleReconciler) deleteClusterCopies(ctx context.Context, simple *demov2.Simple,
+	cluster demov2.ClusterTarget) error {
+	c, err := r.remoteClient(ctx, simple, cluster)
+	if err != nil {
+		return err
+	}
+	for _, kind := range []demov2.OutputKind{demov2.OutputKindConfigMap, demov2.OutputKindSecret} {
+		if err := deleteRemoteCopy(ctx, c, simple, kind, remoteNamespace(simple, cluster)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
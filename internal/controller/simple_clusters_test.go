@@ -0,0 +1,99 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// unreachableKubeconfig points to a port nothing listens on
+const unreachableKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: remote
+  cluster:
+    server: https://127.0.0.1:1
+contexts:
+- name: remote
+  context:
+    cluster: remote
+current-context: remote
+`
+
+var _ = Describe("Simple remote clusters", func() {
+	var (
+		c      client.Client
+		r      *SimpleReconciler
+		simple *demov2.Simple
+		secret *corev1.Secret
+	)
+
+	BeforeEach(func() {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "remote", Namespace: "default", UID: "secret-uid"},
+			Data:       map[string][]byte{"kubeconfig": []byte(unreachableKubeconfig)},
+		}
+		c = fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(secret).Build()
+		r = &SimpleReconciler{Client: c, Scheme: clientgoscheme.Scheme}
+		simple = &demov2.Simple{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: demov2.SimpleSpec{Clusters: []demov2.ClusterTarget{{
+				Name: "remote",
+				KubeconfigSecretRef: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "remote"}, Key: "kubeconfig",
+				},
+			}}},
+		}
+	})
+
+	It("should replace the client of a changed kubeconfig", func() {
+		first, err := r.remoteClient(ctx, simple, simple.Spec.Clusters[0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.remoteClient(ctx, simple, simple.Spec.Clusters[0])).To(BeIdenticalTo(first))
+
+		secret.Data["kubeconfig"] = []byte(unreachableKubeconfig + "\n")
+		Expect(c.Update(ctx, secret)).To(Succeed())
+		second, err := r.remoteClient(ctx, simple, simple.Spec.Clusters[0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(second).NotTo(BeIdenticalTo(first))
+
+		entries := 0
+		r.remoteClients.Range(func(_, _ any) bool {
+			entries++
+			return true
+		})
+		Expect(entries).To(Equal(1))
+	})
+
+	It("should give up on the cleanup of an unreachable cluster", func() {
+		simple.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		Expect(r.deleteRemoteCopies(ctx, simple)).To(MatchError(ContainSubstring("cluster remote")))
+
+		simple.DeletionTimestamp = &metav1.Time{Time: time.Now().Add(-remoteCleanupTimeout - time.Minute)}
+		Expect(r.deleteRemoteCopies(ctx, simple)).To(Succeed())
+	})
+})
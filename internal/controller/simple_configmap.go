@@ -0,0 +1,120 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+	"github.com/leobip/demo-operator/internal/format"
+)
+
+// messageConfigMapKey is the data key holding all messages in the child ConfigMap,
+// rendered in Spec.Format. Named messages are also stored under their own name.
+const messageConfigMapKey = "message"
+
+// messageConfigMapName returns the name of the ConfigMap, or Secret with
+// Spec.Output, holding the messages of simple.
+func messageConfigMapName(simple *demov2.Simple) string {
+	return simple.Name + "-message"
+}
+
+// messageConfigMapData returns the data of the message ConfigMap of simple
+// holding messages, all of them rendered in Spec.Format under
+// messageConfigMapKey. The top-level keys of Spec.Payload that are valid
+// ConfigMap keys and don't collide with the message names are added too,
+// strings as is and other values as JSON.
+func messageConfigMapData(simple *demov2.Simple, messages []demov2.MessageSpec) map[string]string {
+	data := map[string]string{}
+	var payload string
+	for _, message := range messages {
+		if message.Name != "" {
+			data[message.Name] = message.Text
+		}
+		if message.Name == demov2.PayloadMessageName && simple.Spec.Payload != nil {
+			payload = message.Text
+		}
+	}
+	data[messageConfigMapKey] = format.Text(simple, messages)
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(payload), &fields); err == nil {
+		for key, value := range fields {
+			if _, ok := data[key]; ok || len(validation.IsConfigMapKey(key)) > 0 {
+				continue
+			}
+			var text string
+			if json.Unmarshal(value, &text) != nil {
+				text = string(value)
+			}
+			data[key] = text
+		}
+	}
+	return data
+}
+
+// messageObject returns the object of kind holding data in namespace for simple.
+func messageObject(simple *demov2.Simple, kind demov2.OutputKind, namespace string,
+	data map[string]string) client.Object {
+	objectMeta := metav1.ObjectMeta{Name: messageConfigMapName(simple), Namespace: namespace}
+	if kind == demov2.OutputKindSecret {
+		secret := &corev1.Secret{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+			ObjectMeta: objectMeta,
+			Type:       corev1.SecretTypeOpaque,
+			Data:       map[string][]byte{},
+		}
+		for k, v := range data {
+			secret.Data[k] = []byte(v)
+		}
+		return secret
+	}
+	return &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: objectMeta,
+		Data:       data,
+	}
+}
+
+// otherOutputKind returns the output kind simple doesn't write its messages to.
+func otherOutputKind(simple *demov2.Simple) demov2.OutputKind {
+	if simple.Spec.OutputKind() == demov2.OutputKindSecret {
+		return demov2.OutputKindConfigMap
+	}
+	return demov2.OutputKindSecret
+}
+
+// reconcileConfigMap makes sure the message ConfigMap, or Secret with
+// Spec.Output, exists, is owned by the Simple and carries the current
+// messages. Manual edits are reverted, and the object of the other kind is
+// deleted when Spec.Output changes.
+func (r *SimpleReconciler) reconcileConfigMap(ctx context.Context, simple *demov2.Simple,
+	messages []demov2.MessageSpec) error {
+	obj := messageObject(simple, simple.Spec.OutputKind(), simple.Namespace, messageConfigMapData(simple, messages))
+	if err := r.apply(ctx, simple, obj); err != nil {
+		return err
+	}
+
+	stale := messageObject(simple, otherOutputKind(simple), simple.Namespace, nil)
+	return r.deleteChild(ctx, simple, stale)
+}
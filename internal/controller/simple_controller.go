@@ -18,24 +18,249 @@ package controller
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
-	demov1 "github.com/leobip/demo-operator/api/v1"
+	demov2 "github.com/leobip/demo-operator/api/v2"
+	"github.com/leobip/demo-operator/internal/auditstream"
+	"github.com/leobip/demo-operator/internal/policy"
+	"github.com/leobip/demo-operator/internal/sinks"
 )
 
 // SimpleReconciler reconciles a Simple object
 type SimpleReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// Recorder emits Kubernetes Events about the Simples, optional
+	Recorder record.EventRecorder
+
+	// HTTPClient is used for outbound notifications, defaults to a client with a 10s timeout
+	HTTPClient *http.Client
+
+	// Sinks are the destinations messages are delivered to, defaults to all built-in sinks
+	Sinks *sinks.Registry
+
+	// ClusterInfo is exposed to message templates as .Cluster
+	ClusterInfo ClusterInfo
+
+	// Clientset reads the logs of the Jobs created for Spec.Run. Job output
+	// isn't captured when it is nil.
+	Clientset kubernetes.Interface
+
+	// MaxConcurrentReconciles is the number of Simples reconciled in parallel, defaults to 1
+	MaxConcurrentReconciles int
+
+	// RateLimiter limits how often Simples are requeued, defaults to the controller-runtime limiter
+	RateLimiter workqueue.TypedRateLimiter[reconcile.Request]
+
+	// ReconcileOnStatusChange reconciles Simples on every update, including status-only
+	// updates and resyncs. By default only spec, label and annotation changes trigger a reconcile.
+	ReconcileOnStatusChange bool
+
+	// DetectDuplicates flags the Simples delivering the same messages as an
+	// older Simple of their namespace with Status.DuplicateOf
+	DetectDuplicates bool
+
+	// ReconcileTimeout bounds the duration of a reconciliation unless
+	// Spec.TimeoutSeconds is set. No deadline applies when it is 0.
+	ReconcileTimeout time.Duration
+
+	// DryRun, when set, records the deliveries to the sinks and the deletion
+	// notifications instead of making them. Client must then be a
+	// NewDryRunClient recording to the same log.
+	DryRun *DryRunLog
+
+	// DrainTimeout is how long the reconciliations in flight when the manager
+	// stops may keep delivering, before they are aborted. They are aborted at
+	// once when it is 0.
+	DrainTimeout time.Duration
+
+	// DefaultChildLabels are the keys of the labels of every Simple copied
+	// onto the objects created for it, in addition to Spec.Propagation.Labels
+	DefaultChildLabels []string
+
+	// ProbeEndpoints makes the Simples with Spec.Expose wait for Status.URL to
+	// serve their messages before they are Ready
+	ProbeEndpoints bool
+
+	// FieldManager is the field manager child objects are applied with, defaults to defaultFieldManager
+	FieldManager string
+	// DefaultLocale is the locale of the Simples with Spec.MessageKey but no
+	// Spec.Locale, and the fallback of the others
+	DefaultLocale string
+
+	// MaxDeliveriesPerMinute limits how many times per minute the edits of a
+	// Simple are re-delivered, or fewer if its MaxDeliveriesPerMinuteAnnotation
+	// says so. 0 doesn't limit them.
+	MaxDeliveriesPerMinute int
+
+	// DebounceWindow, when set, is how long the events of a Simple must stop
+	// before it is reconciled, so a burst of updates of the Simple or its
+	// children is reconciled once
+	DebounceWindow time.Duration
+
+	// Shard is the subset of the Simples this replica reconciles, all of them by default
+	Shard Shard
+
+	// APIReader, when set, reads the children before applying them and the
+	// referenced Secrets from the API server instead of the cache, so a child
+	// created by a reconciliation the cache hasn't caught up with yet isn't
+	// reported as created, or recreated, twice. Optional.
+	APIReader client.Reader
+
+	// RESTConfig is the configuration the clients impersonating the
+	// Spec.ServiceAccountName of the Simples are built from. Simples with a
+	// ServiceAccountName are rejected when it is nil.
+	RESTConfig *rest.Config
+
+	// RequireServiceAccount rejects the Simples without a ServiceAccountName,
+	// so no child is ever written with the operator's own permissions
+	RequireServiceAccount bool
+
+	// Audit, when set, records the spec changes, delivery attempts and
+	// deletions of the Simples as SimpleAudits
+	Audit *Auditor
+
+	// Stream, when set, receives the changes of the Simples and the outcomes
+	// of their deliveries
+	Stream *auditstream.Stream
+
+	sinksOnce sync.Once
+	// inFlight holds the start time of the running reconciliations, by Simple
+	inFlight sync.Map
+
+	// remoteClients caches the clients of the clusters of Spec.Clusters, a
+	// remoteClientEntry by remoteClientKey
+	remoteClients sync.Map
+
+	// impersonatingClients caches the clients impersonating the ServiceAccounts
+	// of Spec.ServiceAccountName, by user name
+	impersonatingClients sync.Map
+
+	// deliveryLimiters limits the re-deliveries of the edited Simples
+	deliveryLimiters deliveryLimiters
+
+	// expectations holds the children written that the cache hasn't observed yet
+	expectations expectations
+
+	// queue is the workqueue of the controller, once it started
+	queue atomic.Pointer[priorityQueue]
+}
+
+// Reasons of the Events emitted for Simples
+const (
+	eventReasonMessageReplied   = "MessageReplied"
+	eventReasonChildCreated     = "ChildCreated"
+	eventReasonValidationFailed = "ValidationFailed"
+	eventReasonDeliveryFailed   = "DeliveryFailed"
+	eventReasonExpired          = "Expired"
+	eventReasonRenderFailed     = "RenderFailed"
+	eventReasonMissedSchedule   = "MissedSchedule"
+	eventReasonDriftCorrected   = "DriftCorrected"
+	eventReasonRetriesExhausted = "RetriesExhausted"
+	eventReasonReconcileTimeout = "ReconcileTimeout"
+	eventReasonDuplicate        = "DuplicateMessages"
+	eventReasonAdopted          = "Adopted"
+	eventReasonCleanupFailed    = "CleanupFailed"
+)
+
+// errInvalidSpec wraps reconcile errors caused by the Simple's spec rather than the cluster
+var errInvalidSpec = errors.New("invalid spec")
+
+// defaultHTTPClient is used when SimpleReconciler.HTTPClient is not set
+var defaultHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+func (r *SimpleReconciler) httpClient() *http.Client {
+	if r.HTTPClient != nil {
+		return r.HTTPClient
+	}
+	return defaultHTTPClient
+}
+
+func (r *SimpleReconciler) fieldManager() string {
+	return fieldManagerOrDefault(r.FieldManager)
+}
+
+// liveReader returns the reader of the children and the Secrets: APIReader
+// if set, the cached client otherwise.
+func (r *SimpleReconciler) liveReader() client.Reader {
+	if r.APIReader != nil {
+		return r.APIReader
+	}
+	return r.Client
+}
+
+func (r *SimpleReconciler) sinkRegistry() *sinks.Registry {
+	r.sinksOnce.Do(func() {
+		if r.Sinks == nil {
+			r.Sinks = sinks.NewDefaultRegistry(r.Client, r.httpClient())
+		}
+	})
+	return r.Sinks
+}
+
+// event records an Event on simple if a Recorder is configured.
+func (r *SimpleReconciler) event(simple *demov2.Simple, eventType, reason, messageFmt string, args ...any) {
+	if r.Recorder != nil {
+		r.Recorder.Eventf(simple, eventType, reason, messageFmt, args...)
+	}
 }
 
 // +kubebuilder:rbac:groups=demo.demo.local,resources=simples,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=demo.demo.local,resources=simples/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=demo.demo.local,resources=simples/finalizers,verbs=update
+// +kubebuilder:rbac:groups=demo.demo.local,resources=simplepolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=demo.demo.local,resources=simpleaudits,verbs=list;create;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// The Secrets are only written in the namespaces binding the secret-writer
+// ClusterRole of config/rbac to the operator
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=impersonate
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=core,resources=pods/log,verbs=get
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -47,32 +272,670 @@ type SimpleReconciler struct {
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.21.0/pkg/reconcile
 func (r *SimpleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	// The watches of the children and the referenced objects enqueue the
+	// Simples of every shard
+	if !r.Shard.Owns(req.Namespace, req.Name) {
+		return ctrl.Result{}, nil
+	}
+	ctx, span := tracer.Start(ctx, "Reconcile", trace.WithAttributes(
+		attribute.String("simple.namespace", req.Namespace),
+		attribute.String("simple.name", req.Name),
+	))
+	defer span.End()
+	r.inFlight.Store(req.NamespacedName, time.Now())
+	defer r.inFlight.Delete(req.NamespacedName)
+	ctx, cancel := r.drainContext(ctx)
+	defer cancel()
+	result, err := r.reconcile(ctx, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+// reconcile implements Reconcile within its span.
+func (r *SimpleReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
 
 	// 1. Fetch the Simple instance
-	var simple demov1.Simple
+	var simple demov2.Simple
 	if err := r.Get(ctx, req.NamespacedName, &simple); err != nil {
+		if apierrors.IsNotFound(err) {
+			phases.forget(req.NamespacedName)
+			r.deliveryLimiters.forget(req.NamespacedName)
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	log = simpleLogger(log, &simple)
+	ctx = ctrl.LoggerInto(ctx, log)
+
+	// 2. Run the cleanup of deleted resources, or make sure it will run
+	if !simple.DeletionTimestamp.IsZero() {
+		r.expectations.forget(simple.UID)
+		return ctrl.Result{}, r.finalize(ctx, &simple)
+	}
+	if !controllerutil.ContainsFinalizer(&simple, simpleFinalizer) {
+		patch := client.MergeFromWithOptions(simple.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		controllerutil.AddFinalizer(&simple, simpleFinalizer)
+		if err := r.Patch(ctx, &simple, patch); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
 
-	// 2. Log the message
-	log.Info("Hallo Welt!", "name", simple.Name, "message", simple.Spec.Message)
+	// 3. Announce that we started working on a resource we haven't seen yet. All
+	// the status changes of the reconcile are written at once, at its end.
+	base := simple.DeepCopy()
+	original := &base.Status
+	if len(simple.Status.Conditions) == 0 {
+		setProgressingConditions(&simple)
+		simple.Status.Phase = simplePhase(&simple)
+	}
+	checkDeprecatedFields(&simple)
+	if r.Audit != nil && original.ObservedGeneration != simple.Generation {
+		if err := r.auditSpecChange(ctx, &simple); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
 
-	// 3. Update status if not already done
-	if !simple.Status.Replied {
+	// 4. Leave the resource alone while it is paused, suspended, out of retries
+	// or waiting for its dependencies
+	if simple.Annotations[demov2.PausedAnnotation] == "true" {
+		log.V(1).Info("Skipping the paused Simple")
+		setCondition(&simple, demov2.ConditionReconciliationPaused, metav1.ConditionTrue,
+			demov2.ReasonPaused, "Reconciliation is paused by the "+demov2.PausedAnnotation+" annotation")
+		if !equality.Semantic.DeepEqual(original, &simple.Status) {
+			return ctrl.Result{}, patchStatus(ctx, r.Client, &simple, base)
+		}
+		return ctrl.Result{}, nil
+	}
+	if meta.IsStatusConditionTrue(simple.Status.Conditions, demov2.ConditionReconciliationPaused) {
+		setCondition(&simple, demov2.ConditionReconciliationPaused, metav1.ConditionFalse,
+			demov2.ReasonResumed, "Reconciliation resumed")
+	}
+	if simple.Spec.Suspend {
+		log.V(1).Info("Skipping the suspended Simple")
+		setCondition(&simple, demov2.ConditionSuspended, metav1.ConditionTrue,
+			demov2.ReasonSuspended, "Reconciliation is suspended")
+		setCondition(&simple, demov2.ConditionProgressing, metav1.ConditionFalse,
+			demov2.ReasonSuspended, "Reconciliation is suspended")
+		simple.Status.ObservedGeneration = simple.Generation
+		simple.Status.Phase = simplePhase(&simple)
+		phases.observe(req.NamespacedName, string(simple.Status.Phase))
+		if !equality.Semantic.DeepEqual(original, &simple.Status) {
+			return ctrl.Result{}, patchStatus(ctx, r.Client, &simple, base)
+		}
+		return ctrl.Result{}, nil
+	}
+	if meta.IsStatusConditionTrue(simple.Status.Conditions, demov2.ConditionSuspended) {
+		setCondition(&simple, demov2.ConditionSuspended, metav1.ConditionFalse,
+			demov2.ReasonResumed, "Reconciliation resumed")
+	}
+	if retriesExhausted(&simple) {
+		// A requested re-delivery gets a new round of retries
+		if _, ok := simple.ResendRequested(); !ok {
+			log.V(1).Info("Skipping the Simple until its spec changes, its retries are exhausted")
+			return ctrl.Result{}, nil
+		}
+		resetFailures(&simple)
+	}
+	pending, err := r.pendingDependencies(ctx, &simple)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(pending) > 0 {
+		log.V(1).Info("Waiting for the dependencies of the Simple", "pending", pending)
+		msg := "Waiting for " + strings.Join(pending, ", ") + " to be Ready"
+		setCondition(&simple, demov2.ConditionWaitingForDependencies, metav1.ConditionTrue,
+			demov2.ReasonDependenciesNotReady, msg)
+		setCondition(&simple, demov2.ConditionProgressing, metav1.ConditionFalse,
+			demov2.ReasonDependenciesNotReady, msg)
+		simple.Status.ObservedGeneration = simple.Generation
+		simple.Status.Phase = simplePhase(&simple)
+		phases.observe(req.NamespacedName, string(simple.Status.Phase))
+		if !equality.Semantic.DeepEqual(original, &simple.Status) {
+			return ctrl.Result{}, patchStatus(ctx, r.Client, &simple, base)
+		}
+		return ctrl.Result{}, nil
+	}
+	if meta.IsStatusConditionTrue(simple.Status.Conditions, demov2.ConditionWaitingForDependencies) {
+		setCondition(&simple, demov2.ConditionWaitingForDependencies, metav1.ConditionFalse,
+			demov2.ReasonDependenciesReady, "Every dependency is Ready")
+	}
+
+	throttled, err := r.throttleDelivery(ctx, &simple)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("hashing the spec: %w", err)
+	}
+	if throttled > 0 {
+		log.V(1).Info("Throttling the re-delivery of the edited Simple", "retryAfter", throttled)
+		setCondition(&simple, demov2.ConditionProgressing, metav1.ConditionFalse, demov2.ReasonThrottled,
+			fmt.Sprintf("Delivering the latest changes in %s, at most %d re-deliveries per minute",
+				throttled.Round(time.Second), r.deliveriesPerMinute(ctx, &simple)))
+		simple.Status.Phase = simplePhase(&simple)
+		phases.observe(req.NamespacedName, string(simple.Status.Phase))
+		deliveriesThrottledTotal.WithLabelValues(simple.Namespace).Inc()
+		if !equality.Semantic.DeepEqual(original, &simple.Status) {
+			if err := patchStatus(ctx, r.Client, &simple, base); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		return ctrl.Result{RequeueAfter: throttled}, nil
+	}
+
+	// 5. Reply to the message
+	reconcileErr := r.replyWithTimeout(ctx, &simple)
+	if reconcileErr != nil && ctx.Err() != nil {
+		reconcileErr = fmt.Errorf("interrupted by the shutdown of the operator: %w", reconcileErr)
+	}
+
+	// 6. Record the outcome in the status conditions
+	var retryAfter time.Duration
+	attempted := reconcileErr != nil
+	if reconcileErr != nil {
+		reconcileErrorsTotal.Inc()
+		log.Error(reconcileErr, "failed to reply")
+		switch {
+		case errors.Is(reconcileErr, errReconcileTimeout):
+			r.event(&simple, corev1.EventTypeWarning, eventReasonReconcileTimeout, reconcileErr.Error())
+		case errors.Is(reconcileErr, errRenderFailed):
+			r.event(&simple, corev1.EventTypeWarning, eventReasonRenderFailed, reconcileErr.Error())
+		case errors.Is(reconcileErr, errInvalidSpec):
+			r.event(&simple, corev1.EventTypeWarning, eventReasonValidationFailed, reconcileErr.Error())
+		default:
+			r.event(&simple, corev1.EventTypeWarning, eventReasonDeliveryFailed, reconcileErr.Error())
+		}
+		setFailedConditions(&simple, reconcileErr)
+		recordHistory(&simple, demov2.HistoryOutcomeFailed, reconcileErr.Error())
+		recordFailure(&simple, reconcileErr, time.Now())
+		if simple.Spec.Backoff != nil {
+			var retry bool
+			if retryAfter, retry = nextRetry(&simple, time.Now()); !retry {
+				if err := r.exhaustRetries(ctx, &simple, reconcileErr); err != nil {
+					log.Error(err, "failed to write the dead-letter ConfigMap")
+				}
+			}
+		}
+	} else {
+		if !original.Replied {
+			timeToReplied.Observe(time.Since(simple.CreationTimestamp.Time).Seconds())
+		}
 		simple.Status.Replied = true
-		if err := r.Status().Update(ctx, &simple); err != nil {
+		resetFailures(&simple)
+		if waitingForEndpoint(&simple) {
+			setWaitingForEndpointConditions(&simple)
+		} else {
+			setReadyConditions(&simple)
+		}
+		if !equality.Semantic.DeepEqual(original.LastRepliedTime, simple.Status.LastRepliedTime) {
+			recordHistory(&simple, demov2.HistoryOutcomeDelivered, "")
+			attempted = true
+		}
+		if r.DetectDuplicates {
+			if err := r.detectDuplicate(ctx, &simple); err != nil {
+				log.Error(err, "failed to detect duplicates")
+			}
+		}
+	}
+	simple.Status.ObservedGeneration = simple.Generation
+	simple.Status.Phase = simplePhase(&simple)
+	phases.observe(req.NamespacedName, string(simple.Status.Phase))
+
+	// 7. Update status in a single write if it changed. It also records the
+	// deliveries and the retries of reconciliations interrupted by a shutdown.
+	if !equality.Semantic.DeepEqual(original, &simple.Status) {
+		statusCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), statusWriteTimeout)
+		err := patchStatus(statusCtx, r.Client, &simple, base)
+		cancel()
+		if err != nil {
 			return ctrl.Result{}, err
 		}
 	}
+	if r.Audit != nil && attempted {
+		if err := r.auditDelivery(context.WithoutCancel(ctx), &simple, original, reconcileErr); err != nil {
+			log.Error(err, "failed to audit the delivery")
+		}
+	}
+	if r.Stream != nil && attempted {
+		r.streamDelivery(context.WithoutCancel(ctx), &simple, original, reconcileErr)
+	}
+	if err := r.acknowledgeResend(ctx, &simple); err != nil {
+		return ctrl.Result{}, err
+	}
 
-	return ctrl.Result{}, nil
+	if reconcileErr != nil {
+		if simple.Spec.Backoff == nil {
+			return ctrl.Result{}, reconcileErr
+		}
+		// Spec.Backoff replaces the rate limiter, and stops the retries once exhausted
+		return ctrl.Result{RequeueAfter: retryAfter}, nil
+	}
+
+	// 8. Delete the resource once its TTL after being replied has elapsed, unless
+	// it is protected
+	var result ctrl.Result
+	if expiresIn, ok := ttlRemaining(&simple); ok && !simple.DeletionProtected() {
+		if expiresIn <= 0 {
+			log.Info("Deleting Simple after its TTL expired")
+			r.event(&simple, corev1.EventTypeNormal, eventReasonExpired, "TTL after replied expired")
+			return ctrl.Result{}, client.IgnoreNotFound(r.Delete(ctx, &simple))
+		}
+		result.RequeueAfter = expiresIn
+	}
+
+	// 9. Come back when the next delivery, fetch of the message sources or
+	// probe of the endpoint is due
+	if waitingForEndpoint(&simple) && (result.RequeueAfter == 0 || endpointProbeInterval < result.RequeueAfter) {
+		result.RequeueAfter = endpointProbeInterval
+	}
+	if next := simple.Status.NextReplyTime; next != nil {
+		if until := time.Until(next.Time); result.RequeueAfter == 0 || until < result.RequeueAfter {
+			result.RequeueAfter = until
+		}
+	}
+	for _, remaining := range []func(*demov2.Simple, time.Time) (time.Duration, bool){
+		messageURLPollRemaining, gitSourceSyncRemaining,
+	} {
+		if until, ok := remaining(&simple, time.Now()); ok && (result.RequeueAfter == 0 || until < result.RequeueAfter) {
+			result.RequeueAfter = max(until, time.Second)
+		}
+	}
+
+	return result, nil
+}
+
+// messagePreviewLength is the maximum number of characters of Status.MessagePreview
+const messagePreviewLength = 40
+
+// truncate shortens s to at most n characters, marking the cut with an ellipsis.
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+// ttlRemaining returns how long until the TTL of simple expires, measured from
+// the time it became Ready. ok is false when no TTL applies.
+func ttlRemaining(simple *demov2.Simple) (remaining time.Duration, ok bool) {
+	ttl := simple.Spec.TTLSecondsAfterReplied
+	ready := meta.FindStatusCondition(simple.Status.Conditions, demov2.ConditionReady)
+	if ttl == nil || ready == nil || ready.Status != metav1.ConditionTrue {
+		return 0, false
+	}
+	expiresAt := ready.LastTransitionTime.Add(time.Duration(*ttl) * time.Second)
+	return time.Until(expiresAt), true
+}
+
+// reply materializes the messages of the Simple into its ConfigMap and
+// delivers each new or changed one, tracking the per-message state in the
+// status. When Spec.Interval is set, all messages are re-delivered once
+// Status.NextReplyTime has passed.
+func (r *SimpleReconciler) reply(ctx context.Context, simple *demov2.Simple) error {
+	messages := simple.Spec.AllMessages()
+	if err := traced(ctx, "FetchMessage", func(ctx context.Context) error {
+		if simple.Spec.MessageFrom != nil {
+			text, err := r.resolveMessageFrom(ctx, simple)
+			if err != nil {
+				return err
+			}
+			if text != "" {
+				messages = append([]demov2.MessageSpec{{Text: text}}, messages...)
+			}
+		}
+		if simple.Spec.MessageKey == "" {
+			simple.Status.Locale = ""
+		} else {
+			text, err := r.resolveMessageKey(ctx, simple)
+			if err != nil {
+				return err
+			}
+			messages = append([]demov2.MessageSpec{{Text: text}}, messages...)
+		}
+		text, err := r.fetchMessage(ctx, simple)
+		if text != "" {
+			messages = append([]demov2.MessageSpec{{Text: text}}, messages...)
+		}
+		return err
+	}); err != nil {
+		return err
+	}
+	if len(messages) == 0 && simple.Spec.Payload == nil {
+		return fmt.Errorf("%w: spec must set message, messageFrom, messageURL, gitSource, encryptedMessage, messageKey, messages or payload",
+			errInvalidSpec)
+	}
+	if err := traced(ctx, "RenderMessages", func(ctx context.Context) (err error) {
+		messages, err = r.renderMessages(ctx, simple, messages)
+		return err
+	}); err != nil {
+		return err
+	}
+	// The payload isn't a template, it is delivered as is
+	if payload, err := simple.Spec.PayloadJSON(); err != nil {
+		return fmt.Errorf("%w: spec.payload: %v", errInvalidSpec, err)
+	} else if payload != nil {
+		messages = append(messages, demov2.MessageSpec{Name: demov2.PayloadMessageName, Text: string(payload)})
+	}
+	log.FromContext(ctx).V(1).Info("Rendered the messages", "count", len(messages))
+	simple.Status.MessagePreview = truncate(loggedMessage(simple, messages[0].Text), messagePreviewLength)
+	if err := traced(ctx, "CheckPolicies", func(ctx context.Context) error {
+		return r.checkPolicies(ctx, simple, messages)
+	}); err != nil {
+		return err
+	}
+
+	schedule, err := replySchedule(simple)
+	if err != nil {
+		return err
+	}
+
+	if err := traced(ctx, "ReconcileConfigMap", func(ctx context.Context) error {
+		return r.reconcileConfigMap(ctx, simple, messages)
+	}); err != nil {
+		return err
+	}
+	data := messageConfigMapData(simple, messages)
+	if err := traced(ctx, "ReconcileTargets", func(ctx context.Context) error {
+		return r.reconcileTargets(ctx, simple, data)
+	}); err != nil {
+		return err
+	}
+	if err := traced(ctx, "ReconcileClusters", func(ctx context.Context) error {
+		return r.reconcileClusters(ctx, simple, data)
+	}); err != nil {
+		return err
+	}
+	if err := traced(ctx, "ReconcilePods", func(ctx context.Context) error {
+		return r.reconcilePods(ctx, simple, data[messageConfigMapKey])
+	}); err != nil {
+		return err
+	}
+	if err := traced(ctx, "ReconcileEcho", func(ctx context.Context) error {
+		return r.reconcileEcho(ctx, simple, messages)
+	}); err != nil {
+		return err
+	}
+	if err := traced(ctx, "ReconcileExpose", func(ctx context.Context) error {
+		return r.reconcileExpose(ctx, simple)
+	}); err != nil {
+		return err
+	}
+	_ = traced(ctx, "ProbeEndpoint", func(ctx context.Context) error {
+		r.probeEndpoint(ctx, simple, messages)
+		return nil
+	})
+
+	log := log.FromContext(ctx)
+	now := metav1.Now()
+	delivered := 0
+	due := simple.Status.NextReplyTime != nil && !now.Before(simple.Status.NextReplyTime)
+	if due && schedule != nil {
+		if missedDeadline(simple, now.Time) {
+			log.Info("Skipping scheduled delivery past its starting deadline",
+				"scheduledTime", simple.Status.NextReplyTime.Time)
+			r.event(simple, corev1.EventTypeWarning, eventReasonMissedSchedule,
+				"Missed scheduled delivery at %s", simple.Status.NextReplyTime.Format(time.RFC3339))
+			due = false
+		} else {
+			simple.Status.LastScheduleTime = simple.Status.NextReplyTime.DeepCopy()
+		}
+	}
+	// An edited spec re-delivers every message, including the unchanged ones
+	hash, err := specHash(simple)
+	if err != nil {
+		return fmt.Errorf("hashing the spec: %w", err)
+	}
+	if simple.Status.SpecHash != "" && simple.Status.SpecHash != hash {
+		log.Info("The spec of the messages changed, re-delivering them")
+		due = true
+	}
+	// So does the ResendAnnotation, whether the messages were replied or not
+	resend, resendDue := simple.ResendRequested()
+	if resendDue {
+		log.Info("Re-delivering the messages on request", "resend", resend)
+		due = true
+	}
+	statuses := make([]demov2.MessageStatus, 0, len(messages))
+	for i, message := range messages {
+		// Keep the state of messages that didn't change and aren't due again
+		if !due && i < len(simple.Status.Messages) && simple.Status.Messages[i].Message == statusMessage(simple, message.Text) &&
+			simple.Status.Messages[i].Name == message.Name && simple.Status.Messages[i].Delivered {
+			log.V(1).Info("Keeping the state of an unchanged message", "index", i, "messageName", message.Name)
+			statuses = append(statuses, simple.Status.Messages[i])
+			continue
+		}
+
+		log.Info("Hallo Welt!", "index", i, "messageName", message.Name, "message", loggedMessage(simple, message.Text))
+		statuses = append(statuses, demov2.MessageStatus{
+			Message:       statusMessage(simple, message.Text),
+			Name:          message.Name,
+			Delivered:     true,
+			DeliveredTime: &now,
+		})
+		simple.Status.LastRepliedTime = &now
+		delivered++
+		repliesTotal.WithLabelValues(simple.Namespace).Inc()
+		messageLengthBytes.Observe(float64(len(message.Text)))
+	}
+	simple.Status.Messages = statuses
+	simple.Status.MessageHash = messagesHash(simple)
+	simple.Status.SpecHash = hash
+	if resendDue {
+		simple.Status.LastResend = resend
+	}
+	if delivered > 0 {
+		simple.Status.ReplyCount++
+		r.event(simple, corev1.EventTypeNormal, eventReasonMessageReplied, "Delivered %d message(s)", delivered)
+	}
+
+	// Schedule the next delivery
+	simple.Status.NextReplyTime = nil
+	if schedule != nil {
+		next := metav1.NewTime(schedule.Next(now.Time))
+		simple.Status.NextReplyTime = &next
+	} else if interval := replyInterval(simple); interval > 0 && simple.Status.LastRepliedTime != nil {
+		next := metav1.NewTime(simple.Status.LastRepliedTime.Add(interval))
+		simple.Status.NextReplyTime = &next
+	}
+
+	if err := traced(ctx, "ReconcileRun", func(ctx context.Context) error {
+		return r.reconcileRun(ctx, simple, messages, delivered > 0)
+	}); err != nil {
+		return err
+	}
+	setWorkloadReadyCondition(simple)
+
+	return traced(ctx, "DeliverSinks", func(ctx context.Context) error {
+		if r.DryRun != nil {
+			if delivered > 0 {
+				r.DryRun.record(ctx, dryRunSimpleEntry(simple, "deliver",
+					fmt.Sprintf("%d message(s) to the sinks", len(messages))))
+			}
+			return nil
+		}
+		return r.sinkRegistry().Deliver(sinks.WithMessages(ctx, messages), simple, delivered > 0)
+	})
+}
+
+// replyInterval returns the re-delivery cadence of simple, or 0 if it is delivered once.
+func replyInterval(simple *demov2.Simple) time.Duration {
+	if simple.Spec.Interval == nil {
+		return 0
+	}
+	return simple.Spec.Interval.Duration
+}
+
+// checkPolicies rejects simple if it violates a SimplePolicy selecting it.
+// Unlike the webhook it checks the resolved and rendered messages.
+func (r *SimpleReconciler) checkPolicies(ctx context.Context, simple *demov2.Simple, messages []demov2.MessageSpec) error {
+	texts := make([]string, 0, len(messages))
+	for _, message := range messages {
+		texts = append(texts, message.Text)
+	}
+	violations, err := policy.Check(ctx, r.Client, simple, texts)
+	if err != nil || len(violations) == 0 {
+		return err
+	}
+	errs := make([]error, 0, len(violations))
+	for _, violation := range violations {
+		errs = append(errs, errors.New(violation.String()))
+	}
+	return fmt.Errorf("%w: %w", errInvalidSpec, errors.Join(errs...))
+}
+
+// simplePhase derives the lifecycle phase of simple from its conditions.
+func simplePhase(simple *demov2.Simple) demov2.SimplePhase {
+	switch {
+	case meta.IsStatusConditionTrue(simple.Status.Conditions, demov2.ConditionDegraded):
+		return demov2.PhaseFailed
+	case meta.IsStatusConditionTrue(simple.Status.Conditions, demov2.ConditionReady):
+		return demov2.PhaseReplied
+	case meta.IsStatusConditionTrue(simple.Status.Conditions, demov2.ConditionProgressing):
+		return demov2.PhaseDelivering
+	default:
+		return demov2.PhasePending
+	}
+}
+
+// setProgressingConditions marks the Simple as being worked on.
+func setProgressingConditions(simple *demov2.Simple) {
+	setCondition(simple, demov2.ConditionReady, metav1.ConditionUnknown,
+		demov2.ReasonReconciling, "Starting reconciliation")
+	setCondition(simple, demov2.ConditionProgressing, metav1.ConditionTrue,
+		demov2.ReasonReconciling, "Replying to the message")
+	setCondition(simple, demov2.ConditionDegraded, metav1.ConditionFalse,
+		demov2.ReasonReconciling, "Starting reconciliation")
+}
+
+// setReadyConditions marks the Simple as successfully replied.
+func setReadyConditions(simple *demov2.Simple) {
+	setCondition(simple, demov2.ConditionReady, metav1.ConditionTrue,
+		demov2.ReasonReplied, "Message has been replied")
+	setCondition(simple, demov2.ConditionProgressing, metav1.ConditionFalse,
+		demov2.ReasonReplied, "Reconciliation finished")
+	setCondition(simple, demov2.ConditionDegraded, metav1.ConditionFalse,
+		demov2.ReasonReplied, "Reconciliation finished")
+}
+
+// failureReason returns the condition reason reporting err.
+func failureReason(err error) string {
+	if errors.Is(err, errRenderFailed) {
+		return demov2.ReasonRenderFailed
+	}
+	if errors.Is(err, errReconcileTimeout) {
+		return demov2.ReasonTimedOut
+	}
+	return demov2.ReasonReconcileFailed
+}
+
+// setFailedConditions marks the Simple as degraded because of err.
+func setFailedConditions(simple *demov2.Simple, err error) {
+	reason := failureReason(err)
+	setCondition(simple, demov2.ConditionReady, metav1.ConditionFalse,
+		reason, err.Error())
+	setCondition(simple, demov2.ConditionProgressing, metav1.ConditionFalse,
+		reason, "Reconciliation failed, will retry")
+	setCondition(simple, demov2.ConditionDegraded, metav1.ConditionTrue,
+		reason, err.Error())
+}
+
+func setCondition(simple *demov2.Simple, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&simple.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: simple.Generation,
+	})
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *SimpleReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&demov1.Simple{}).
+	if err := setupMessageFromIndexes(context.Background(), mgr); err != nil {
+		return err
+	}
+	if err := setupClustersIndex(context.Background(), mgr); err != nil {
+		return err
+	}
+	if err := setupEncryptedMessageIndex(context.Background(), mgr); err != nil {
+		return err
+	}
+	if err := setupMessageCatalogIndex(context.Background(), mgr); err != nil {
+		return err
+	}
+	if err := setupDependsOnIndex(context.Background(), mgr); err != nil {
+		return err
+	}
+	if r.DetectDuplicates {
+		if err := setupMessageHashIndex(context.Background(), mgr); err != nil {
+			return err
+		}
+	}
+	if r.Stream != nil {
+		if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+			return r.streamChanges(ctx, mgr.GetCache())
+		})); err != nil {
+			return err
+		}
+	}
+
+	// Our own status writes don't change the generation, skip them unless asked
+	var forPredicates []predicate.Predicate
+	if !r.ReconcileOnStatusChange {
+		forPredicates = append(forPredicates, predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.LabelChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+		))
+	}
+	if r.Shard.Enabled() {
+		forPredicates = append(forPredicates, r.Shard.predicate())
+	}
+
+	bldr := ctrl.NewControllerManagedBy(mgr).
+		For(&demov2.Simple{}, builder.WithPredicates(forPredicates...)).
+		Owns(&corev1.ConfigMap{}, builder.WithPredicates(r.expectations.observer("ConfigMap"))).
+		Owns(&corev1.Secret{}, builder.WithPredicates(r.expectations.observer("Secret"))).
+		Owns(&appsv1.Deployment{}, builder.OnlyMetadata, builder.WithPredicates(r.expectations.observer("Deployment"))).
+		Owns(&batchv1.Job{}, builder.WithPredicates(r.expectations.observer("Job"))).
+		Owns(&corev1.Service{}, builder.OnlyMetadata, builder.WithPredicates(r.expectations.observer("Service"))).
+		Owns(&networkingv1.Ingress{}, builder.OnlyMetadata, builder.WithPredicates(r.expectations.observer("Ingress"))).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.simplesForConfigMap)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.simplesForSecret)).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.simplesForNamespace),
+			builder.WithPredicates(predicate.LabelChangedPredicate{})).
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(r.simplesForPod), builder.OnlyMetadata,
+			builder.WithPredicates(predicate.Or(predicate.LabelChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
+		Watches(&demov2.Simple{}, handler.EnqueueRequestsFromMapFunc(r.simplesForDependency),
+			builder.WithPredicates(readinessChanged))
+	if r.DetectDuplicates {
+		bldr = bldr.Watches(&demov2.Simple{}, handler.EnqueueRequestsFromMapFunc(r.simplesWithSameMessages),
+			builder.WithPredicates(messagesChanged))
+	}
+	// The children of optional kinds are watched once their CRD is installed
+	optional := &optionalWatches{
+		Reader:     mgr.GetClient(),
+		Scheme:     mgr.GetScheme(),
+		RESTMapper: mgr.GetRESTMapper(),
+		Cache:      mgr.GetCache(),
+		Shard:      r.Shard,
+		Observer:   &r.expectations,
+		kinds:      slices.Clone(optionalKinds),
+		events:     make(chan event.GenericEvent),
+	}
+	c, err := bldr.
+		WatchesRawSource(source.Channel(optional.events, &handler.EnqueueRequestForObject{})).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: r.MaxConcurrentReconciles,
+			RateLimiter:             r.RateLimiter,
+			NewQueue:                r.newQueue,
+		}).
 		Named("simple").
-		Complete(r)
+		Build(r)
+	if err != nil {
+		return err
+	}
+	optional.Controller = c
+	return mgr.Add(optional)
 }
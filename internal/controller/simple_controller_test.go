@@ -18,16 +18,34 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
-	demov1 "github.com/leobip/demo-operator/api/v1"
+	demov2 "github.com/leobip/demo-operator/api/v2"
 )
 
 var _ = Describe("Simple Controller", func() {
@@ -40,45 +58,1429 @@ var _ = Describe("Simple Controller", func() {
 			Name:      resourceName,
 			Namespace: "default", // TODO(user):Modify as needed
 		}
-		simple := &demov1.Simple{}
+		simple := &demov2.Simple{}
 
 		BeforeEach(func() {
 			By("creating the custom resource for the Kind Simple")
 			err := k8sClient.Get(ctx, typeNamespacedName, simple)
 			if err != nil && errors.IsNotFound(err) {
-				resource := &demov1.Simple{
+				resource := &demov2.Simple{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      resourceName,
 						Namespace: "default",
 					},
-					// TODO(user): Specify other spec details if needed.
+					Spec: demov2.SimpleSpec{
+						Message:  "Hello from the test",
+						Messages: []demov2.MessageSpec{{Name: "second", Text: "Second message"}},
+					},
 				}
 				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
 			}
 		})
 
 		AfterEach(func() {
-			// TODO(user): Cleanup logic after each test, like removing the resource instance.
-			resource := &demov1.Simple{}
+			resource := &demov2.Simple{}
 			err := k8sClient.Get(ctx, typeNamespacedName, resource)
+			if errors.IsNotFound(err) {
+				return
+			}
 			Expect(err).NotTo(HaveOccurred())
 
 			By("Cleanup the specific resource instance Simple")
 			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			By("Running the finalizer")
+			controllerReconciler := &SimpleReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(func() bool {
+				return errors.IsNotFound(k8sClient.Get(ctx, typeNamespacedName, &demov2.Simple{}))
+			}).Should(BeTrue())
 		})
+
 		It("should successfully reconcile the resource", func() {
 			By("Reconciling the created resource")
+			recorder := record.NewFakeRecorder(10)
+			controllerReconciler := &SimpleReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Checking the status conditions")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(simple.Status.Replied).To(BeTrue())
+			Expect(simple.Status.ObservedGeneration).To(Equal(simple.Generation))
+			Expect(simple.Status.Messages).To(HaveLen(2))
+			Expect(simple.Status.Messages[0].Message).To(Equal("Hello from the test"))
+			Expect(simple.Status.Messages[1].Delivered).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(simple.Status.Conditions, demov2.ConditionReady)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(simple.Status.Conditions, demov2.ConditionProgressing)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(simple.Status.Conditions, demov2.ConditionDegraded)).To(BeTrue())
+			Expect(simple.Status.Phase).To(Equal(demov2.PhaseReplied))
+			Expect(simple.Status.History).To(HaveLen(1))
+			Expect(simple.Status.History[0].Outcome).To(Equal(demov2.HistoryOutcomeDelivered))
+			Expect(simple.Status.History[0].MessageHash).To(HaveLen(64))
+			Expect(simple.Status.MessageHash).To(Equal(simple.Status.History[0].MessageHash))
+			Expect(simple.Status.ReplyCount).To(Equal(int64(1)))
+			Expect(simple.Status.MessagePreview).To(Equal("Hello from the test"))
+
+			By("Checking the emitted events")
+			Expect(recorder.Events).To(Receive(ContainSubstring("ChildCreated")))
+			Expect(recorder.Events).To(Receive(ContainSubstring("MessageReplied")))
+
+			By("Checking the message ConfigMap")
+			cm := &corev1.ConfigMap{}
+			cmName := types.NamespacedName{Name: resourceName + "-message", Namespace: "default"}
+			Expect(k8sClient.Get(ctx, cmName, cm)).To(Succeed())
+			Expect(cm.Data).To(HaveKeyWithValue("message", "Hello from the test\nSecond message"))
+			Expect(cm.Data).To(HaveKeyWithValue("second", "Second message"))
+			Expect(metav1.IsControlledBy(cm, simple)).To(BeTrue())
+			Expect(cm.ManagedFields).To(ContainElement(SatisfyAll(
+				HaveField("Manager", defaultFieldManager),
+				HaveField("Operation", metav1.ManagedFieldsOperationApply),
+			)))
+
+			By("Repairing the ConfigMap after a manual edit")
+			cm.Data["message"] = "tampered"
+			Expect(k8sClient.Update(ctx, cm)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, cmName, cm)).To(Succeed())
+			Expect(cm.Data).To(HaveKeyWithValue("message", "Hello from the test\nSecond message"))
+			Expect(recorder.Events).To(Receive(ContainSubstring("DriftCorrected")))
+
+			By("Recreating the ConfigMap after a manual deletion")
+			Expect(k8sClient.Delete(ctx, cm)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, cmName, cm)).To(Succeed())
+			Expect(cm.Data).To(HaveKeyWithValue("message", "Hello from the test\nSecond message"))
+			Expect(recorder.Events).To(Receive(ContainSubstring("DriftCorrected")))
+
+			By("Counting a delivery per change of the messages")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(simple.Status.ReplyCount).To(Equal(int64(1)))
+			hash := simple.Status.MessageHash
+			simple.Spec.Messages[0].Text = "Edited second message"
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+			for range 2 {
+				_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: typeNamespacedName,
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(simple.Status.ReplyCount).To(Equal(int64(2)))
+			Expect(simple.Status.MessageHash).NotTo(Equal(hash))
+			Expect(simple.Status.SpecHash).To(HaveLen(64))
+
+			By("Re-delivering the remaining messages once one is removed")
+			simple.Spec.Messages = nil
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+			for range 2 {
+				_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: typeNamespacedName,
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(simple.Status.ReplyCount).To(Equal(int64(3)))
+			Expect(simple.Status.Messages).To(HaveLen(1))
+
+			By("Re-delivering the messages on request")
+			simple.Annotations = map[string]string{demov2.ResendAnnotation: "1"}
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(simple.Status.ReplyCount).To(Equal(int64(4)))
+			Expect(simple.Status.LastResend).To(Equal("1"))
+			Expect(simple.Annotations).NotTo(HaveKey(demov2.ResendAnnotation))
+		})
+
+		It("should write the top-level keys of the payload to the ConfigMap", func() {
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			simple.Spec.Payload = &runtime.RawExtension{Raw: []byte(`{"greeting":"hello","limits":{"cpu":1}}`)}
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+
+			controllerReconciler := &SimpleReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			cm := &corev1.ConfigMap{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: resourceName + "-message", Namespace: "default"},
+				cm)).To(Succeed())
+			Expect(cm.Data).To(HaveKeyWithValue("payload", `{"greeting":"hello","limits":{"cpu":1}}`))
+			Expect(cm.Data).To(HaveKeyWithValue("greeting", "hello"))
+			Expect(cm.Data).To(HaveKeyWithValue("limits", `{"cpu":1}`))
+		})
+
+		It("should write redacted messages to a Secret", func() {
+			controllerReconciler := &SimpleReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Switching the output to a Secret")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			simple.Spec.Output = &demov2.OutputSpec{Kind: demov2.OutputKindSecret}
+			simple.Spec.Redact = true
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			outputName := types.NamespacedName{Name: resourceName + "-message", Namespace: "default"}
+			secret := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, outputName, secret)).To(Succeed())
+			Expect(secret.Data).To(HaveKeyWithValue("message", []byte("Hello from the test\nSecond message")))
+			Expect(metav1.IsControlledBy(secret, simple)).To(BeTrue())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, outputName, &corev1.ConfigMap{}))).To(BeTrue())
+
+			By("Keeping the messages out of the status")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(simple.Status.MessagePreview).To(Equal(redactedText))
+			Expect(simple.Status.Messages).To(HaveEach(HaveField("Message", HavePrefix("sha256:"))))
+		})
+
+		It("should schedule the next reply when an interval is set", func() {
+			By("Setting an interval on the resource")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			simple.Spec.Interval = &metav1.Duration{Duration: time.Hour}
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+
+			controllerReconciler := &SimpleReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(simple.Status.LastRepliedTime).NotTo(BeNil())
+			Expect(simple.Status.NextReplyTime).NotTo(BeNil())
+			Expect(simple.Status.NextReplyTime.Sub(simple.Status.LastRepliedTime.Time)).To(Equal(time.Hour))
+		})
+
+		It("should write the children as the ServiceAccount of the Simple", func() {
+			By("Setting a ServiceAccount without permissions")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			simple.Spec.ServiceAccountName = "tenant"
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+			cmName := types.NamespacedName{Name: resourceName + "-message", Namespace: "default"}
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: cmName.Name, Namespace: cmName.Namespace},
+			}))).To(Succeed())
+
+			controllerReconciler := &SimpleReconciler{
+				Client:     k8sClient,
+				Scheme:     k8sClient.Scheme(),
+				RESTConfig: cfg,
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(errors.IsForbidden(err)).To(BeTrue(), "unexpected error: %v", err)
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, cmName, &corev1.ConfigMap{}))).To(BeTrue())
+
+			By("Allowing the ServiceAccount to write ConfigMaps")
+			role := &rbacv1.Role{
+				ObjectMeta: metav1.ObjectMeta{Name: "tenant", Namespace: "default"},
+				Rules: []rbacv1.PolicyRule{{
+					APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get", "create", "patch"},
+				}},
+			}
+			binding := &rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "tenant", Namespace: "default"},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "tenant"},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "tenant", Namespace: "default"}},
+			}
+			Expect(k8sClient.Create(ctx, role)).To(Succeed())
+			Expect(k8sClient.Create(ctx, binding)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, binding)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, role)).To(Succeed())
+			})
+			Eventually(func() error {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: typeNamespacedName,
+				})
+				return err
+			}).Should(Succeed())
+			Expect(k8sClient.Get(ctx, cmName, &corev1.ConfigMap{})).To(Succeed())
+		})
+
+		It("should only adopt an existing ConfigMap when asked to", func() {
+			By("Creating a ConfigMap without an owner named like the message ConfigMap")
+			cmName := types.NamespacedName{Name: resourceName + "-message", Namespace: "default"}
+			existing := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: cmName.Name, Namespace: cmName.Namespace}}
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, existing))).To(Succeed())
+			existing = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: cmName.Name, Namespace: cmName.Namespace},
+				Data:       map[string]string{"message": "mine"},
+			}
+			Expect(k8sClient.Create(ctx, existing)).To(Succeed())
+
+			controllerReconciler := &SimpleReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).To(MatchError(ContainSubstring("isn't owned by the Simple")))
+			Expect(k8sClient.Get(ctx, cmName, existing)).To(Succeed())
+			Expect(existing.Data).To(HaveKeyWithValue("message", "mine"))
+
+			By("Annotating the Simple to adopt it")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			simple.Annotations = map[string]string{demov2.AdoptAnnotation: "true"}
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, cmName, existing)).To(Succeed())
+			Expect(metav1.IsControlledBy(existing, simple)).To(BeTrue())
+			Expect(existing.Data).To(HaveKeyWithValue("message", "Hello from the test\nSecond message"))
+		})
+
+		It("should run the echo Deployment when echo is enabled", func() {
+			By("Enabling the echo server with two replicas")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			simple.Spec.Echo = &demov2.EchoSpec{WorkloadSpec: demov2.WorkloadSpec{
+				Resources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10m")},
+				},
+				NodeSelector: map[string]string{"kubernetes.io/os": "linux"},
+			}}
+			simple.Spec.Replicas = ptr.To[int32](2)
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+
+			controllerReconciler := &SimpleReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			deploy := &appsv1.Deployment{}
+			deployName := types.NamespacedName{Name: resourceName + "-echo", Namespace: "default"}
+			Expect(k8sClient.Get(ctx, deployName, deploy)).To(Succeed())
+			Expect(deploy.Spec.Replicas).To(HaveValue(Equal(int32(2))))
+			Expect(deploy.Spec.Template.Spec.Containers[0].Image).To(Equal("hashicorp/http-echo:1.0"))
+			Expect(deploy.Spec.Template.Spec.Containers[0].Args).To(
+				ContainElement("-text=Hello from the test\nSecond message"))
+			Expect(deploy.Spec.Template.Spec.Containers[0].Resources.Requests.Cpu().String()).To(Equal("10m"))
+			Expect(deploy.Spec.Template.Spec.NodeSelector).To(HaveKeyWithValue("kubernetes.io/os", "linux"))
+			Expect(metav1.IsControlledBy(deploy, simple)).To(BeTrue())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(simple.Status.Selector).To(Equal(
+				"app.kubernetes.io/instance=test-resource,app.kubernetes.io/name=simple-echo"))
+			Expect(meta.FindStatusCondition(simple.Status.Conditions, demov2.ConditionEchoAvailable)).To(
+				HaveField("Reason", demov2.ReasonRolloutPending))
+			Expect(meta.FindStatusCondition(simple.Status.Conditions, demov2.ConditionWorkloadReady)).To(
+				HaveField("Message", "0/2 echo replicas ready"))
+
+			By("Mirroring the rollout of the Deployment")
+			deploy.Status.ObservedGeneration = deploy.Generation
+			deploy.Status.Replicas = 2
+			deploy.Status.ReadyReplicas = 2
+			deploy.Status.Conditions = []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionTrue, Reason: "NewReplicaSetAvailable",
+					LastUpdateTime: metav1.Now(), LastTransitionTime: metav1.Now()},
+				{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue, Reason: "MinimumReplicasAvailable",
+					LastUpdateTime: metav1.Now(), LastTransitionTime: metav1.Now()},
+			}
+			Expect(k8sClient.Status().Update(ctx, deploy)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(simple.Status.Conditions, demov2.ConditionEchoProgressing)).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(simple.Status.Conditions, demov2.ConditionEchoAvailable)).To(BeTrue())
+			Expect(simple.Status.ReadyReplicas).To(Equal(int32(2)))
+			Expect(meta.IsStatusConditionTrue(simple.Status.Conditions, demov2.ConditionWorkloadReady)).To(BeTrue())
+
+			By("Restarting the echo pods when the messages change")
+			hash := deploy.Spec.Template.Annotations["simple.example.com/message-hash"]
+			Expect(hash).NotTo(BeEmpty())
+			simple.Spec.Message = "Changed message"
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, deployName, deploy)).To(Succeed())
+			Expect(deploy.Spec.Template.Annotations["simple.example.com/message-hash"]).NotTo(Equal(hash))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(meta.FindStatusCondition(simple.Status.Conditions, demov2.ConditionEchoProgressing)).To(
+				HaveField("Reason", demov2.ReasonRolloutPending))
+
+			By("Removing the Deployment when echo is disabled")
+			simple.Spec.Echo = nil
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, deployName, deploy))).To(BeTrue())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(meta.FindStatusCondition(simple.Status.Conditions, demov2.ConditionEchoAvailable)).To(BeNil())
+			Expect(meta.FindStatusCondition(simple.Status.Conditions, demov2.ConditionWorkloadReady)).To(BeNil())
+		})
+
+		It("should expose the echo server with a Service and an Ingress", func() {
+			By("Exposing the echo server on a host")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			simple.Spec.Echo = &demov2.EchoSpec{}
+			simple.Spec.Expose = &demov2.ExposeSpec{
+				Ingress: &demov2.IngressSpec{Host: "simple.example.com", TLSSecretName: "simple-tls"},
+			}
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+
+			controllerReconciler := &SimpleReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			exposeName := types.NamespacedName{Name: resourceName + "-echo", Namespace: "default"}
+			svc := &corev1.Service{}
+			Expect(k8sClient.Get(ctx, exposeName, svc)).To(Succeed())
+			Expect(svc.Spec.Selector).To(Equal(echoLabels(simple)))
+			Expect(svc.Spec.Ports).To(ConsistOf(HaveField("Port", int32(80))))
+			ingress := &networkingv1.Ingress{}
+			Expect(k8sClient.Get(ctx, exposeName, ingress)).To(Succeed())
+			Expect(ingress.Spec.Rules).To(ConsistOf(HaveField("Host", "simple.example.com")))
+			Expect(ingress.Spec.TLS).To(ConsistOf(HaveField("SecretName", "simple-tls")))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(simple.Status.URL).To(Equal("https://simple.example.com/"))
+
+			By("Falling back to the Service URL without the Ingress")
+			simple.Spec.Expose.Ingress = nil
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, exposeName, ingress))).To(BeTrue())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(simple.Status.URL).To(Equal("http://test-resource-echo.default.svc:80"))
+
+			By("Removing the Service when expose is unset")
+			simple.Spec.Expose = nil
+			simple.Spec.Echo = nil
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, exposeName, svc))).To(BeTrue())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(simple.Status.URL).To(BeEmpty())
+		})
+
+		It("should deliver on a cron schedule and skip missed runs", func() {
+			By("Setting a schedule on the resource")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			simple.Spec.Schedule = "0 9 * * MON"
+			simple.Spec.StartingDeadlineSeconds = ptr.To[int64](60)
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+
+			controllerReconciler := &SimpleReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+			Expect(result.RequeueAfter).To(BeNumerically("<=", 7*24*time.Hour))
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(simple.Status.NextReplyTime).NotTo(BeNil())
+			Expect(simple.Status.NextReplyTime.Weekday()).To(Equal(time.Monday))
+
+			By("Skipping a run that is past its starting deadline")
+			missed := metav1.NewTime(time.Now().Add(-time.Hour))
+			simple.Status.NextReplyTime = &missed
+			Expect(k8sClient.Status().Update(ctx, simple)).To(Succeed())
+			lastReplied := simple.Status.LastRepliedTime
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(simple.Status.LastRepliedTime.Equal(lastReplied)).To(BeTrue())
+			Expect(simple.Status.LastScheduleTime).To(BeNil())
+			Expect(simple.Status.NextReplyTime.After(time.Now())).To(BeTrue())
+		})
+
+		It("should run the messages in a Job when run is set", func() {
+			By("Enabling the Job runner")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			simple.Spec.Run = &demov2.RunSpec{Command: []string{"echo", "{{ .Name }}"}}
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+
+			controllerReconciler := &SimpleReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(simple.Status.Run).NotTo(BeNil())
+			Expect(simple.Status.Run.Result).To(Equal(demov2.RunResultRunning))
+
+			job := &batchv1.Job{}
+			jobName := types.NamespacedName{Name: simple.Status.Run.JobName, Namespace: "default"}
+			Expect(k8sClient.Get(ctx, jobName, job)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, job))).To(Succeed())
+			})
+			Expect(job.Spec.Template.Spec.Containers[0].Image).To(Equal("busybox:1.36"))
+			Expect(job.Spec.Template.Spec.Containers[0].Command).To(Equal([]string{"echo", "test-resource"}))
+			Expect(metav1.IsControlledBy(job, simple)).To(BeTrue())
+		})
+
+		It("should pause while suspended and resume when cleared", func() {
+			By("Suspending the resource")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			simple.Spec.Suspend = true
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+
+			controllerReconciler := &SimpleReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(simple.Status.Conditions, demov2.ConditionSuspended)).To(BeTrue())
+			Expect(simple.Status.Phase).To(Equal(demov2.PhasePending))
+			Expect(simple.Status.Replied).To(BeFalse())
+			Expect(simple.Status.Messages).To(BeEmpty())
+
+			By("Resuming the resource")
+			simple.Spec.Suspend = false
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			suspended := meta.FindStatusCondition(simple.Status.Conditions, demov2.ConditionSuspended)
+			Expect(suspended).NotTo(BeNil())
+			Expect(suspended.Status).To(Equal(metav1.ConditionFalse))
+			Expect(suspended.Reason).To(Equal(demov2.ReasonResumed))
+			Expect(simple.Status.Replied).To(BeTrue())
+		})
+
+		It("should skip a paused resource until the annotation is removed", func() {
+			By("Pausing the resource")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			simple.Annotations = map[string]string{demov2.PausedAnnotation: "true"}
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+
+			controllerReconciler := &SimpleReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			paused := meta.FindStatusCondition(simple.Status.Conditions, demov2.ConditionReconciliationPaused)
+			Expect(paused).NotTo(BeNil())
+			Expect(paused.Status).To(Equal(metav1.ConditionTrue))
+			Expect(paused.Reason).To(Equal(demov2.ReasonPaused))
+			Expect(simple.Status.Replied).To(BeFalse())
+			Expect(simple.Status.Messages).To(BeEmpty())
+
+			By("Removing the annotation")
+			delete(simple.Annotations, demov2.PausedAnnotation)
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			paused = meta.FindStatusCondition(simple.Status.Conditions, demov2.ConditionReconciliationPaused)
+			Expect(paused).NotTo(BeNil())
+			Expect(paused.Status).To(Equal(metav1.ConditionFalse))
+			Expect(paused.Reason).To(Equal(demov2.ReasonResumed))
+			Expect(simple.Status.Replied).To(BeTrue())
+		})
+
+		It("should wait for its dependencies to be Ready", func() {
+			By("Depending on a Simple that doesn't exist yet")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			simple.Spec.DependsOn = []demov2.ObjectReference{{Name: "test-dependency"}}
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+
+			controllerReconciler := &SimpleReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			waiting := meta.FindStatusCondition(simple.Status.Conditions, demov2.ConditionWaitingForDependencies)
+			Expect(waiting).NotTo(BeNil())
+			Expect(waiting.Status).To(Equal(metav1.ConditionTrue))
+			Expect(waiting.Message).To(ContainSubstring("default/test-dependency"))
+			Expect(simple.Status.Replied).To(BeFalse())
+
+			By("Delivering the dependency")
+			dependency := &demov2.Simple{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-dependency", Namespace: "default"},
+				Spec:       demov2.SimpleSpec{Message: "First"},
+			}
+			Expect(k8sClient.Create(ctx, dependency)).To(Succeed())
+			dependencyName := client.ObjectKeyFromObject(dependency)
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, dependency))).To(Succeed())
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: dependencyName})
+				Expect(err).NotTo(HaveOccurred())
+			})
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: dependencyName})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			waiting = meta.FindStatusCondition(simple.Status.Conditions, demov2.ConditionWaitingForDependencies)
+			Expect(waiting).NotTo(BeNil())
+			Expect(waiting.Status).To(Equal(metav1.ConditionFalse))
+			Expect(waiting.Reason).To(Equal(demov2.ReasonDependenciesReady))
+			Expect(simple.Status.Replied).To(BeTrue())
+		})
+
+		It("should reject invalid specs without the webhook", func() {
+			invalid := &demov2.Simple{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-invalid", Namespace: "default"},
+				Spec: demov2.SimpleSpec{
+					Message: "Hello",
+					MessageFrom: &demov2.MessageSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "greeting"},
+							Key:                  "text",
+						},
+					},
+				},
+			}
+			err := k8sClient.Create(ctx, invalid)
+			Expect(errors.IsInvalid(err)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring("message and messageFrom are mutually exclusive")))
+
+			invalid.Spec = demov2.SimpleSpec{
+				Message:  "Hello",
+				Schedule: "@hourly",
+				Interval: &metav1.Duration{Duration: time.Hour},
+			}
+			err = k8sClient.Create(ctx, invalid)
+			Expect(errors.IsInvalid(err)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring("interval and schedule are mutually exclusive")))
+		})
+
+		It("should load the message from a referenced ConfigMap", func() {
+			By("Creating the source ConfigMap")
+			source := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-message-source", Namespace: "default"},
+				Data:       map[string]string{"greeting": "Hello from a ConfigMap"},
+			}
+			Expect(k8sClient.Create(ctx, source)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, source))).To(Succeed())
+			})
+
+			By("Referencing the source from the resource")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			simple.Spec.Message = ""
+			simple.Spec.MessageFrom = &demov2.MessageSource{
+				ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: source.Name},
+					Key:                  "greeting",
+				},
+			}
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+
+			controllerReconciler := &SimpleReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			cm := &corev1.ConfigMap{}
+			cmName := types.NamespacedName{Name: resourceName + "-message", Namespace: "default"}
+			Expect(k8sClient.Get(ctx, cmName, cm)).To(Succeed())
+			Expect(cm.Data).To(HaveKeyWithValue("message", "Hello from a ConfigMap\nSecond message"))
+
+			By("Failing while the referenced key is missing")
+			source.Data = map[string]string{}
+			Expect(k8sClient.Update(ctx, source)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should deliver the localized entry of a message catalog", func() {
+			By("Creating the catalog ConfigMap")
+			catalog := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-message-catalog", Namespace: "default"},
+				Data: map[string]string{
+					"greeting":    "Hello",
+					"greeting.de": "Hallo",
+				},
+			}
+			Expect(k8sClient.Create(ctx, catalog)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, catalog))).To(Succeed())
+			})
+
+			By("Delivering its key in the locale of the resource")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			simple.Spec.Message = ""
+			simple.Spec.MessageKey = "greeting"
+			simple.Spec.Locale = "de-AT"
+			simple.Spec.Catalog = catalog.Name
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+
+			controllerReconciler := &SimpleReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				DefaultLocale: "en",
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			cm := &corev1.ConfigMap{}
+			cmName := types.NamespacedName{Name: resourceName + "-message", Namespace: "default"}
+			Expect(k8sClient.Get(ctx, cmName, cm)).To(Succeed())
+			Expect(cm.Data).To(HaveKeyWithValue("message", "Hallo\nSecond message"))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(simple.Status.Locale).To(Equal("de"))
+
+			By("Re-rendering when the catalog changes")
+			catalog.Data["greeting.de-AT"] = "Servus"
+			Expect(k8sClient.Update(ctx, catalog)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, cmName, cm)).To(Succeed())
+			Expect(cm.Data).To(HaveKeyWithValue("message", "Servus\nSecond message"))
+
+			By("Falling back to the entry without locale")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			simple.Spec.Locale = "fr"
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, cmName, cm)).To(Succeed())
+			Expect(cm.Data).To(HaveKeyWithValue("message", "Hello\nSecond message"))
+		})
+
+		It("should fetch the message from a URL and poll it with its ETag", func() {
+			By("Serving a message with an ETag")
+			var mu sync.Mutex
+			content, etag := "Hello from a URL", `"v1"`
+			var conditionalRequests int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				if req.Header.Get("Authorization") != "Bearer url-token" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				if req.Header.Get("If-None-Match") == etag {
+					conditionalRequests++
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				w.Header().Set("ETag", etag)
+				_, _ = w.Write([]byte(content))
+			}))
+			defer server.Close()
+
+			auth := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-url-auth", Namespace: "default"},
+				StringData: map[string]string{"authorization": "Bearer url-token"},
+			}
+			Expect(k8sClient.Create(ctx, auth)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, auth))).To(Succeed())
+			})
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			simple.Spec.Message = ""
+			simple.Spec.MessageURL = &demov2.MessageURLSource{
+				URL:          server.URL,
+				PollInterval: &metav1.Duration{Duration: time.Millisecond},
+				AuthorizationSecretRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: auth.Name},
+					Key:                  "authorization",
+				},
+			}
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+
+			controllerReconciler := &SimpleReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			reconcileAndGetMessage := func() string {
+				GinkgoHelper()
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: typeNamespacedName,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				cm := &corev1.ConfigMap{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: resourceName + "-message", Namespace: "default"},
+					cm)).To(Succeed())
+				return cm.Data["message"]
+			}
+
+			Expect(reconcileAndGetMessage()).To(Equal("Hello from a URL\nSecond message"))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(simple.Status.MessageURL.ETag).To(Equal(`"v1"`))
+			fetched := meta.FindStatusCondition(simple.Status.Conditions, demov2.ConditionMessageFetched)
+			Expect(fetched).NotTo(BeNil())
+			Expect(fetched.Reason).To(Equal(demov2.ReasonFetched))
+
+			By("Keeping the content the server reports as not modified")
+			time.Sleep(5 * time.Millisecond)
+			Expect(reconcileAndGetMessage()).To(Equal("Hello from a URL\nSecond message"))
+			mu.Lock()
+			Expect(conditionalRequests).To(Equal(1))
+			mu.Unlock()
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			fetched = meta.FindStatusCondition(simple.Status.Conditions, demov2.ConditionMessageFetched)
+			Expect(fetched.Reason).To(Equal(demov2.ReasonNotModified))
+
+			By("Updating the children when the content changes")
+			mu.Lock()
+			content, etag = "Updated from a URL", `"v2"`
+			mu.Unlock()
+			time.Sleep(5 * time.Millisecond)
+			Expect(reconcileAndGetMessage()).To(Equal("Updated from a URL\nSecond message"))
+
+			By("Keeping the previous content while the URL fails")
+			server.Close()
+			time.Sleep(5 * time.Millisecond)
+			Expect(reconcileAndGetMessage()).To(Equal("Updated from a URL\nSecond message"))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			fetched = meta.FindStatusCondition(simple.Status.Conditions, demov2.ConditionMessageFetched)
+			Expect(fetched.Status).To(Equal(metav1.ConditionFalse))
+			Expect(fetched.Reason).To(Equal(demov2.ReasonFetchFailed))
+		})
+
+		It("should delete the resource once its TTL after replied expires", func() {
+			By("Setting a zero TTL")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			simple.Spec.TTLSecondsAfterReplied = ptr.To[int32](0)
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+
+			controllerReconciler := &SimpleReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			By("Replying and then expiring the resource")
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(simple.DeletionTimestamp.IsZero()).To(BeFalse())
+		})
+
+		It("should deliver the messages to the Slack sink", func() {
+			By("Starting a Slack webhook receiver")
+			received := make(chan map[string]string, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				defer GinkgoRecover()
+				var payload map[string]string
+				Expect(json.NewDecoder(req.Body).Decode(&payload)).To(Succeed())
+				received <- payload
+			}))
+			defer server.Close()
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-slack", Namespace: "default"},
+				StringData: map[string]string{"url": server.URL},
+			}
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, secret))).To(Succeed())
+			})
+
+			By("Configuring the Slack sink")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			simple.Spec.Sinks = &demov2.SinksSpec{Slack: &demov2.SlackSink{
+				WebhookURLSecretRef: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name},
+					Key:                  "url",
+				},
+			}}
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+
+			controllerReconciler := &SimpleReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(received).To(Receive(HaveKeyWithValue("text", "Hello from the test\nSecond message")))
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(simple.Status.Sinks).To(HaveLen(1))
+			Expect(simple.Status.Sinks[0].Name).To(Equal("slack"))
+			Expect(simple.Status.Sinks[0].Delivered).To(BeTrue())
+			Expect(simple.Status.Sinks[0].LastDeliveryTime).NotTo(BeNil())
+		})
+
+		It("should retry deliveries to the HTTP sink", func() {
+			By("Starting an HTTP receiver that fails the first request")
+			var requests int
+			received := make(chan *http.Request, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				requests++
+				if requests == 1 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.WriteHeader(http.StatusAccepted)
+				received <- req
+			}))
+			defer server.Close()
+
+			headers := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-http-headers", Namespace: "default"},
+				StringData: map[string]string{"Authorization": "Bearer token"},
+			}
+			Expect(k8sClient.Create(ctx, headers)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, headers))).To(Succeed())
+			})
+
+			By("Configuring the HTTP sink")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			simple.Spec.Sinks = &demov2.SinksSpec{HTTP: &demov2.HTTPSink{
+				URL:              server.URL,
+				Method:           http.MethodPut,
+				HeadersSecretRef: &corev1.LocalObjectReference{Name: headers.Name},
+				Retry: &demov2.RetryPolicy{
+					MaxAttempts: 3,
+					Backoff:     &metav1.Duration{Duration: 10 * time.Millisecond},
+				},
+			}}
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+
+			controllerReconciler := &SimpleReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			var req *http.Request
+			Expect(received).To(Receive(&req))
+			Expect(req.Method).To(Equal(http.MethodPut))
+			Expect(req.Header.Get("Authorization")).To(Equal("Bearer token"))
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(simple.Status.Sinks).To(HaveLen(1))
+			Expect(simple.Status.Sinks[0].Attempts).To(Equal(int32(2)))
+			Expect(simple.Status.Sinks[0].ResponseCode).To(Equal(int32(http.StatusAccepted)))
+			Expect(meta.IsStatusConditionTrue(simple.Status.Conditions, demov2.ConditionHTTPSinkDelivered)).To(BeTrue())
+		})
+
+		It("should abort a hung sink delivery at the reconcile timeout", func() {
+			By("Starting an HTTP receiver that never answers")
+			release := make(chan struct{})
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				select {
+				case <-release:
+				case <-req.Context().Done():
+				}
+			}))
+			defer server.Close()
+			defer close(release)
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			simple.Spec.Sinks = &demov2.SinksSpec{HTTP: &demov2.HTTPSink{URL: server.URL}}
+			simple.Spec.TimeoutSeconds = ptr.To[int32](1)
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+
+			controllerReconciler := &SimpleReconciler{
+				Client:           k8sClient,
+				Scheme:           k8sClient.Scheme(),
+				ReconcileTimeout: time.Hour,
+			}
+
+			start := time.Now()
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).To(MatchError(errReconcileTimeout))
+			Expect(time.Since(start)).To(BeNumerically("<", 10*time.Second))
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			timedOut := meta.FindStatusCondition(simple.Status.Conditions, demov2.ConditionReconcileTimeout)
+			Expect(timedOut).NotTo(BeNil())
+			Expect(timedOut.Status).To(Equal(metav1.ConditionTrue))
+			Expect(timedOut.Reason).To(Equal(demov2.ReasonTimedOut))
+			Expect(meta.FindStatusCondition(simple.Status.Conditions, demov2.ConditionReady).Reason).
+				To(Equal(demov2.ReasonTimedOut))
+		})
+
+		It("should render message templates", func() {
+			By("Creating a ConfigMap referenced by the template")
+			values := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-template-values", Namespace: "default"},
+				Data:       map[string]string{"team": "platform"},
+			}
+			Expect(k8sClient.Create(ctx, values)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, values))).To(Succeed())
+			})
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			simple.Labels = map[string]string{"env": "test"}
+			simple.Spec.Message = `Hi {{ .Name }} in {{ .Cluster.Name }}/{{ .Labels.env }} from {{ configMap "test-template-values" "team" }}`
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+
+			controllerReconciler := &SimpleReconciler{
+				Client:      k8sClient,
+				Scheme:      k8sClient.Scheme(),
+				ClusterInfo: ClusterInfo{Name: "envtest"},
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(simple.Status.Messages[0].Message).To(Equal("Hi test-resource in envtest/test from platform"))
+
+			By("Reporting templates that fail to render")
+			simple.Spec.Message = `{{ configMap "missing" "team" }}`
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).To(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			degraded := meta.FindStatusCondition(simple.Status.Conditions, demov2.ConditionDegraded)
+			Expect(degraded).NotTo(BeNil())
+			Expect(degraded.Reason).To(Equal(demov2.ReasonRenderFailed))
+			Expect(simple.Status.LastError).NotTo(BeNil())
+			Expect(simple.Status.LastError.Reason).To(Equal(demov2.ReasonRenderFailed))
+			Expect(simple.Status.LastError.Message).To(Equal(degraded.Message))
+			Expect(simple.Status.LastError.RetryCount).To(BeZero())
+		})
+
+		It("should give up and dead-letter once the retries are exhausted", func() {
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			simple.Spec.Message = `{{ configMap "missing" "team" }}`
+			simple.Spec.Backoff = &demov2.BackoffSpec{
+				MaxRetries: 1,
+				Delay:      &metav1.Duration{Duration: 2 * time.Second},
+				DeadLetter: true,
+			}
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+
 			controllerReconciler := &SimpleReconciler{
 				Client: k8sClient,
 				Scheme: k8sClient.Scheme(),
 			}
 
+			By("Retrying after the backoff delay")
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(2 * time.Second))
+
+			By("Giving up once the retries are exhausted")
+			result, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(simple.Status.Phase).To(Equal(demov2.PhaseFailed))
+			Expect(simple.Status.FailedAttempts).To(Equal(int32(2)))
+			Expect(simple.Status.LastError.RetryCount).To(Equal(int32(1)))
+			degraded := meta.FindStatusCondition(simple.Status.Conditions, demov2.ConditionDegraded)
+			Expect(degraded).NotTo(BeNil())
+			Expect(degraded.Reason).To(Equal(demov2.ReasonRetriesExhausted))
+
+			deadLetter := &corev1.ConfigMap{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: resourceName + "-dead-letter", Namespace: "default"},
+				deadLetter)).To(Succeed())
+			Expect(deadLetter.Data).To(HaveKeyWithValue("message", simple.Spec.Message+"\nSecond message"))
+			Expect(deadLetter.Data).To(HaveKeyWithValue("attempts", "2"))
+
+			By("Staying failed until the spec changes")
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(simple.Status.FailedAttempts).To(Equal(int32(2)))
+
+			simple.Spec.Message = "Hello again"
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(simple.Status.Phase).To(Equal(demov2.PhaseReplied))
+			Expect(simple.Status.FailedAttempts).To(BeZero())
+			Expect(simple.Status.LastError).To(BeNil())
+		})
+
+		It("should replicate the message ConfigMap to the target namespaces", func() {
+			By("Creating a listed and a selected namespace")
+			listed := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   "simple-targets-listed",
+				Labels: map[string]string{demov2.AcceptReplicasLabel: "true"},
+			}}
+			selected := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   "simple-targets-selected",
+				Labels: map[string]string{"simple-targets": "true", demov2.AcceptReplicasLabel: "true"},
+			}}
+			for _, ns := range []*corev1.Namespace{listed, selected} {
+				Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, ns))).To(Succeed())
+			}
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			simple.Spec.TargetNamespaces = []string{listed.Name}
+			simple.Spec.NamespaceSelector = &metav1.LabelSelector{
+				MatchLabels: map[string]string{"simple-targets": "true"},
+			}
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+
+			controllerReconciler := &SimpleReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
 			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
 				NamespacedName: typeNamespacedName,
 			})
 			Expect(err).NotTo(HaveOccurred())
-			// TODO(user): Add more specific assertions depending on your controller's reconciliation logic.
-			// Example: If you expect a certain status condition after reconciliation, verify it here.
+
+			By("Checking the copies and the target status")
+			for _, ns := range []*corev1.Namespace{listed, selected} {
+				cm := &corev1.ConfigMap{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: resourceName + "-message", Namespace: ns.Name},
+					cm)).To(Succeed())
+				Expect(cm.Data).To(HaveKeyWithValue("message", "Hello from the test\nSecond message"))
+				Expect(cm.Labels).To(Equal(ownerLabels(simple)))
+				Expect(cm.OwnerReferences).To(BeEmpty())
+			}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(simple.Status.Targets).To(ConsistOf(
+				SatisfyAll(HaveField("Namespace", listed.Name), HaveField("Propagated", BeTrue())),
+				SatisfyAll(HaveField("Namespace", selected.Name), HaveField("Propagated", BeTrue())),
+			))
+
+			By("Deleting the copy of a namespace no longer selected")
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(selected), selected)).To(Succeed())
+			selected.Labels = nil
+			Expect(k8sClient.Update(ctx, selected)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			err = k8sClient.Get(ctx, types.NamespacedName{Name: resourceName + "-message", Namespace: selected.Name},
+				&corev1.ConfigMap{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(simple.Status.Targets).To(ConsistOf(HaveField("Namespace", listed.Name)))
+		})
+
+		It("should only replicate to the namespaces accepting replicas", func() {
+			closed := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "simple-targets-closed"}}
+			Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, closed))).To(Succeed())
+			By("Creating a ConfigMap of the user named like the copy")
+			existing := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName + "-message", Namespace: closed.Name},
+				Data:       map[string]string{"message": "Not a copy"},
+			}
+			Expect(k8sClient.Create(ctx, existing)).To(Succeed())
+			defer func() {
+				Expect(k8sClient.Delete(ctx, existing)).To(Succeed())
+			}()
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			simple.Spec.TargetNamespaces = []string{closed.Name}
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+			controllerReconciler := &SimpleReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			_, _ = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(simple.Status.Targets).To(ConsistOf(SatisfyAll(
+				HaveField("Namespace", closed.Name),
+				HaveField("Propagated", BeFalse()),
+				HaveField("LastError", ContainSubstring("doesn't accept replicas")),
+			)))
+
+			By("Refusing to overwrite the ConfigMap once the namespace accepts replicas")
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(closed), closed)).To(Succeed())
+			closed.Labels = map[string]string{demov2.AcceptReplicasLabel: "true"}
+			Expect(k8sClient.Update(ctx, closed)).To(Succeed())
+			_, _ = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(existing), existing)).To(Succeed())
+			Expect(existing.Data).To(HaveKeyWithValue("message", "Not a copy"))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(simple.Status.Targets).To(ConsistOf(
+				HaveField("LastError", ContainSubstring("isn't owned by the Simple"))))
+		})
+
+		It("should write the messages to the annotation of the selected Pods", func() {
+			By("Creating a selected Pod")
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "simple-pod-target",
+					Namespace: "default",
+					Labels:    map[string]string{"app": "simple-pod-target"},
+				},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "busybox:1.36"}}},
+			}
+			Expect(k8sClient.Create(ctx, pod)).To(Succeed())
+			defer func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, pod))).To(Succeed())
+			}()
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			simple.Spec.PodTarget = &demov2.PodTargetSpec{
+				Selector:      metav1.LabelSelector{MatchLabels: map[string]string{"app": "simple-pod-target"}},
+				AnnotationKey: "example.com/message",
+			}
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+
+			controllerReconciler := &SimpleReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(pod), pod)).To(Succeed())
+			Expect(pod.Annotations).To(HaveKeyWithValue("example.com/message", "Hello from the test\nSecond message"))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(simple.Status.AnnotatedPods).To(Equal(int32(1)))
+		})
+
+		It("should replicate the messages to the remote clusters", func() {
+			By("Storing a kubeconfig of the test cluster")
+			kubeconfig := clientcmdapi.NewConfig()
+			kubeconfig.Clusters["envtest"] = &clientcmdapi.Cluster{
+				Server:                   cfg.Host,
+				CertificateAuthorityData: cfg.CAData,
+			}
+			kubeconfig.AuthInfos["envtest"] = &clientcmdapi.AuthInfo{
+				ClientCertificateData: cfg.CertData,
+				ClientKeyData:         cfg.KeyData,
+				Token:                 cfg.BearerToken,
+			}
+			kubeconfig.Contexts["envtest"] = &clientcmdapi.Context{Cluster: "envtest", AuthInfo: "envtest"}
+			kubeconfig.CurrentContext = "envtest"
+			data, err := clientcmd.Write(*kubeconfig)
+			Expect(err).NotTo(HaveOccurred())
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "simple-remote-kubeconfig", Namespace: "default"},
+				Data:       map[string][]byte{"kubeconfig": data},
+			}
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+			defer func() {
+				Expect(k8sClient.Delete(ctx, secret)).To(Succeed())
+			}()
+			remote := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   "simple-remote",
+				Labels: map[string]string{demov2.AcceptReplicasLabel: "true"},
+			}}
+			Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, remote))).To(Succeed())
+
+			// The remote cluster is the test cluster: target its namespace too
+			// so that the copy isn't pruned as the one of an untargeted namespace
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			simple.Spec.TargetNamespaces = []string{remote.Name}
+			simple.Spec.Clusters = []demov2.ClusterTarget{{
+				Name: "self",
+				KubeconfigSecretRef: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name},
+					Key:                  "kubeconfig",
+				},
+				Namespace: remote.Name,
+			}}
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+
+			controllerReconciler := &SimpleReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			copyName := types.NamespacedName{Name: resourceName + "-message", Namespace: remote.Name}
+			Expect(k8sClient.Get(ctx, copyName, &corev1.ConfigMap{})).To(Succeed())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(simple.Status.Clusters).To(ConsistOf(SatisfyAll(
+				HaveField("Name", "self"), HaveField("Synced", BeTrue()),
+			)))
+			Expect(meta.IsStatusConditionTrue(simple.Status.Conditions, demov2.ConditionClustersSynced)).To(BeTrue())
+
+			By("Deleting the remote copy with the resource")
+			Expect(k8sClient.Delete(ctx, simple)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, copyName, &corev1.ConfigMap{}))).To(BeTrue())
+		})
+
+		It("should clean up and notify when the resource is deleted", func() {
+			By("Starting a notification receiver")
+			received := make(chan map[string]string, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				defer GinkgoRecover()
+				payload := map[string]string{}
+				Expect(json.NewDecoder(req.Body).Decode(&payload)).To(Succeed())
+				received <- payload
+			}))
+			defer server.Close()
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			simple.Spec.DeletionNotificationURL = server.URL
+			Expect(k8sClient.Update(ctx, simple)).To(Succeed())
+
+			controllerReconciler := &SimpleReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, simple)).To(Succeed())
+			Expect(simple.Finalizers).To(ContainElement("simple.example.com/finalizer"))
+
+			By("Creating an artifact in another namespace")
+			other := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "simple-finalizer-test"}}
+			Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, other))).To(Succeed())
+			artifact := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name:      resourceName + "-message",
+				Namespace: other.Name,
+				Labels:    ownerLabels(simple),
+			}}
+			Expect(k8sClient.Create(ctx, artifact)).To(Succeed())
+
+			By("Deleting the resource")
+			Expect(k8sClient.Delete(ctx, simple)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(artifact), artifact))).To(BeTrue())
+			Eventually(received).Should(Receive(And(
+				HaveKeyWithValue("event", "deleted"),
+				HaveKeyWithValue("name", resourceName),
+			)))
+			Eventually(func() bool {
+				return errors.IsNotFound(k8sClient.Get(ctx, typeNamespacedName, &demov2.Simple{}))
+			}).Should(BeTrue())
 		})
 	})
 })
@@ -0,0 +1,90 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// SimpleDebugInfo is the view of the controller of a Simple, served by DebugHandler.
+type SimpleDebugInfo struct {
+	Namespace          string             `json:"namespace"`
+	Name               string             `json:"name"`
+	Generation         int64              `json:"generation"`
+	ObservedGeneration int64              `json:"observedGeneration"`
+	Phase              demov2.SimplePhase `json:"phase,omitempty"`
+	// TrackedPhase is the phase last recorded in the simple_resources metric
+	TrackedPhase   string            `json:"trackedPhase,omitempty"`
+	LastError      *demov2.LastError `json:"lastError,omitempty"`
+	FailedAttempts int32             `json:"failedAttempts,omitempty"`
+	NextReplyTime  *metav1.Time      `json:"nextReplyTime,omitempty"`
+	// Requeues is the number of failures the rate limiter is backing off for
+	Requeues *int `json:"requeues,omitempty"`
+	// ReconcilingSince is set while a worker reconciles the Simple
+	ReconcilingSince *metav1.Time `json:"reconcilingSince,omitempty"`
+}
+
+// DebugHandler serves the view of the controller of every Simple in its cache as JSON.
+func (r *SimpleReconciler) DebugHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var list demov2.SimpleList
+		if err := r.List(req.Context(), &list); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		infos := make([]SimpleDebugInfo, 0, len(list.Items))
+		for i := range list.Items {
+			infos = append(infos, r.debugInfo(&list.Items[i]))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		_ = encoder.Encode(infos)
+	})
+}
+
+// debugInfo returns the view of the controller of simple.
+func (r *SimpleReconciler) debugInfo(simple *demov2.Simple) SimpleDebugInfo {
+	key := types.NamespacedName{Namespace: simple.Namespace, Name: simple.Name}
+	info := SimpleDebugInfo{
+		Namespace:          simple.Namespace,
+		Name:               simple.Name,
+		Generation:         simple.Generation,
+		ObservedGeneration: simple.Status.ObservedGeneration,
+		Phase:              simple.Status.Phase,
+		TrackedPhase:       phases.get(key),
+		LastError:          simple.Status.LastError,
+		FailedAttempts:     simple.Status.FailedAttempts,
+		NextReplyTime:      simple.Status.NextReplyTime,
+	}
+	if r.RateLimiter != nil {
+		requeues := r.RateLimiter.NumRequeues(reconcile.Request{NamespacedName: key})
+		info.Requeues = &requeues
+	}
+	if since, ok := r.inFlight.Load(key); ok {
+		info.ReconcilingSince = &metav1.Time{Time: since.(time.Time)}
+	}
+	return info
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+var _ = Describe("Simple debug handler", func() {
+	It("should dump the view of the controller of every Simple", func() {
+		scheme := runtime.NewScheme()
+		Expect(demov2.AddToScheme(scheme)).To(Succeed())
+		failing := &demov2.Simple{
+			ObjectMeta: metav1.ObjectMeta{Name: "failing", Namespace: "default", Generation: 2},
+			Status: demov2.SimpleStatus{
+				Phase:          demov2.PhaseFailed,
+				FailedAttempts: 3,
+				LastError:      &demov2.LastError{Message: "sink http: connection refused"},
+			},
+		}
+		key := types.NamespacedName{Namespace: "default", Name: "failing"}
+		reconciler := &SimpleReconciler{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(failing).Build(),
+			RateLimiter: NewRateLimiter(RateLimiterOptions{
+				BaseDelay: time.Second, MaxDelay: time.Minute, QPS: 10, Burst: 100,
+			}),
+		}
+		reconciler.RateLimiter.When(reconcile.Request{NamespacedName: key})
+		reconciler.inFlight.Store(key, time.Now())
+
+		recorder := httptest.NewRecorder()
+		reconciler.DebugHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/simples", nil))
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+
+		var infos []SimpleDebugInfo
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &infos)).To(Succeed())
+		Expect(infos).To(HaveLen(1))
+		Expect(infos[0].Name).To(Equal("failing"))
+		Expect(infos[0].Phase).To(Equal(demov2.PhaseFailed))
+		Expect(infos[0].FailedAttempts).To(Equal(int32(3)))
+		Expect(infos[0].LastError.Message).To(Equal("sink http: connection refused"))
+		Expect(infos[0].Requeues).To(HaveValue(Equal(1)))
+		Expect(infos[0].ReconcilingSince).NotTo(BeNil())
+	})
+})
@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// dependsOnIndex is the field index listing the Simples depending on a
+// Simple, by its namespace/name
+const dependsOnIndex = ".spec.dependsOn"
+
+// dependency returns the key of the Simple ref of simple refers to.
+func dependency(simple *demov2.Simple, ref demov2.ObjectReference) types.NamespacedName {
+	return types.NamespacedName{Namespace: cmp.Or(ref.Namespace, simple.Namespace), Name: ref.Name}
+}
+
+// setupDependsOnIndex registers dependsOnIndex.
+func setupDependsOnIndex(ctx context.Context, mgr ctrl.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(ctx, &demov2.Simple{}, dependsOnIndex,
+		func(obj client.Object) []string {
+			simple := obj.(*demov2.Simple)
+			var keys []string
+			for _, ref := range simple.Spec.DependsOn {
+				keys = append(keys, dependency(simple, ref).String())
+			}
+			return keys
+		})
+}
+
+// isReady tells whether simple is Ready for its current generation.
+func isReady(simple *demov2.Simple) bool {
+	return meta.IsStatusConditionTrue(simple.Status.Conditions, demov2.ConditionReady) &&
+		simple.Status.ObservedGeneration == simple.Generation
+}
+
+// pendingDependencies returns the Simples of Spec.DependsOn that are missing
+// or not Ready.
+func (r *SimpleReconciler) pendingDependencies(ctx context.Context, simple *demov2.Simple) ([]string, error) {
+	var pending []string
+	for _, ref := range simple.Spec.DependsOn {
+		key := dependency(simple, ref)
+		var dep demov2.Simple
+		if err := r.Get(ctx, key, &dep); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("getting dependency %s: %w", key, err)
+			}
+			pending = append(pending, key.String())
+			continue
+		}
+		if !isReady(&dep) {
+			pending = append(pending, key.String())
+		}
+	}
+	return pending, nil
+}
+
+// simplesForDependency enqueues the Simples depending on a Simple.
+func (r *SimpleReconciler) simplesForDependency(ctx context.Context, obj client.Object) []reconcile.Request {
+	var simples demov2.SimpleList
+	if err := r.List(ctx, &simples, client.MatchingFields{dependsOnIndex: client.ObjectKeyFromObject(obj).String()}); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list the Simples depending on Simple", "name", obj.GetName())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(simples.Items))
+	for _, simple := range simples.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&simple)})
+	}
+	return requests
+}
+
+// readinessChanged passes the creations and deletions of Simples and the
+// updates making them Ready or not Ready.
+var readinessChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldSimple, ok := e.ObjectOld.(*demov2.Simple)
+		if !ok {
+			return false
+		}
+		newSimple, ok := e.ObjectNew.(*demov2.Simple)
+		if !ok {
+			return false
+		}
+		return isReady(oldSimple) != isReady(newSimple)
+	},
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// checkDeprecatedFields sets ConditionDeprecatedFieldsInUse while the spec of
+// simple sets deprecated fields, counting each generation setting them once
+// in deprecatedFieldUsageTotal.
+func checkDeprecatedFields(simple *demov2.Simple) {
+	deprecated := simple.Spec.DeprecatedFields()
+	condition := meta.FindStatusCondition(simple.Status.Conditions, demov2.ConditionDeprecatedFieldsInUse)
+	if len(deprecated) == 0 {
+		if condition != nil && condition.Status == metav1.ConditionTrue {
+			setCondition(simple, demov2.ConditionDeprecatedFieldsInUse, metav1.ConditionFalse,
+				demov2.ReasonNoDeprecatedFields, "No deprecated field is in use")
+		}
+		return
+	}
+
+	if condition == nil || condition.Status != metav1.ConditionTrue || condition.ObservedGeneration != simple.Generation {
+		for _, field := range deprecated {
+			deprecatedFieldUsageTotal.WithLabelValues(simple.Namespace, field.Name).Inc()
+		}
+	}
+	uses := make([]string, 0, len(deprecated))
+	for _, field := range deprecated {
+		uses = append(uses, "spec."+field.Name+" is deprecated, use spec."+field.Replacement)
+	}
+	setCondition(simple, demov2.ConditionDeprecatedFieldsInUse, metav1.ConditionTrue,
+		demov2.ReasonDeprecatedFields, strings.Join(uses, "; "))
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+var _ = Describe("Simple deprecated fields", func() {
+	It("should report the deprecated fields in use once per generation", func() {
+		simple := &demov2.Simple{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "deprecations", Generation: 1},
+			Spec:       demov2.SimpleSpec{Message: "Hello"},
+		}
+		usage := deprecatedFieldUsageTotal.WithLabelValues("deprecations", "message")
+		before := testutil.ToFloat64(usage)
+
+		checkDeprecatedFields(simple)
+		checkDeprecatedFields(simple)
+		condition := meta.FindStatusCondition(simple.Status.Conditions, demov2.ConditionDeprecatedFieldsInUse)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Message).To(Equal("spec.message is deprecated, use spec.messages"))
+		Expect(testutil.ToFloat64(usage)).To(Equal(before + 1))
+
+		simple.Generation = 2
+		checkDeprecatedFields(simple)
+		Expect(testutil.ToFloat64(usage)).To(Equal(before + 2))
+
+		By("clearing the condition once migrated")
+		simple.Generation = 3
+		simple.Spec.Message = ""
+		simple.Spec.Messages = []demov2.MessageSpec{{Text: "Hello"}}
+		checkDeprecatedFields(simple)
+		Expect(meta.IsStatusConditionFalse(simple.Status.Conditions, demov2.ConditionDeprecatedFieldsInUse)).To(BeTrue())
+		Expect(testutil.ToFloat64(usage)).To(Equal(before + 2))
+	})
+})
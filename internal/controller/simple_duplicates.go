@@ -0,0 +1,143 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// messageHashIndex is the field index listing the Simples by the hash of
+// their delivered messages
+const messageHashIndex = ".status.messageHash"
+
+// setupMessageHashIndex registers messageHashIndex.
+func setupMessageHashIndex(ctx context.Context, mgr ctrl.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(ctx, &demov2.Simple{}, messageHashIndex, indexMessageHash)
+}
+
+// indexMessageHash returns the messageHashIndex values of a Simple, none
+// until its messages are delivered.
+func indexMessageHash(obj client.Object) []string {
+	simple := obj.(*demov2.Simple)
+	if len(simple.Status.Messages) == 0 || simple.Status.MessageHash == "" {
+		return nil
+	}
+	return []string{simple.Status.MessageHash}
+}
+
+// olderThan tells whether a was created before b, the name breaking ties.
+func olderThan(a, b *demov2.Simple) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return a.Name < b.Name
+}
+
+// detectDuplicate sets Status.DuplicateOf to the oldest Simple of the
+// namespace that delivered the same messages as simple and is older than it.
+// The older Simple is left alone, only the newer ones are flagged.
+func (r *SimpleReconciler) detectDuplicate(ctx context.Context, simple *demov2.Simple) error {
+	var original *demov2.Simple
+	if len(simple.Status.Messages) > 0 && simple.Status.MessageHash != "" {
+		var simples demov2.SimpleList
+		if err := r.List(ctx, &simples, client.InNamespace(simple.Namespace),
+			client.MatchingFields{messageHashIndex: simple.Status.MessageHash}); err != nil {
+			return fmt.Errorf("listing the Simples with the same messages: %w", err)
+		}
+		for i := range simples.Items {
+			other := &simples.Items[i]
+			if other.UID == simple.UID || !other.DeletionTimestamp.IsZero() || !olderThan(other, simple) {
+				continue
+			}
+			if original == nil || olderThan(other, original) {
+				original = other
+			}
+		}
+	}
+
+	if original == nil {
+		simple.Status.DuplicateOf = ""
+		if meta.IsStatusConditionTrue(simple.Status.Conditions, demov2.ConditionDuplicate) {
+			setCondition(simple, demov2.ConditionDuplicate, metav1.ConditionFalse,
+				demov2.ReasonUniqueMessages, "No older Simple delivered the same messages")
+		}
+		return nil
+	}
+	if simple.Status.DuplicateOf != original.Name {
+		log.FromContext(ctx).Info("Detected a duplicate Simple", "original", original.Name)
+		r.event(simple, corev1.EventTypeWarning, eventReasonDuplicate,
+			"Delivered the same messages as Simple %s", original.Name)
+	}
+	simple.Status.DuplicateOf = original.Name
+	setCondition(simple, demov2.ConditionDuplicate, metav1.ConditionTrue,
+		demov2.ReasonDuplicateMessages, "Delivered the same messages as Simple "+original.Name)
+	return nil
+}
+
+// simplesWithSameMessages enqueues the Simples that delivered the same
+// messages as a Simple, as they may stop or start being its duplicates.
+func (r *SimpleReconciler) simplesWithSameMessages(ctx context.Context, obj client.Object) []reconcile.Request {
+	simple, ok := obj.(*demov2.Simple)
+	if !ok || len(simple.Status.Messages) == 0 || simple.Status.MessageHash == "" {
+		return nil
+	}
+	var simples demov2.SimpleList
+	if err := r.List(ctx, &simples, client.InNamespace(simple.Namespace),
+		client.MatchingFields{messageHashIndex: simple.Status.MessageHash}); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list the Simples with the same messages", "name", simple.Name)
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(simples.Items))
+	for _, other := range simples.Items {
+		if other.UID != simple.UID {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&other)})
+		}
+	}
+	return requests
+}
+
+// messagesChanged passes the deletions of Simples and the updates changing
+// their delivered messages.
+var messagesChanged = predicate.Funcs{
+	CreateFunc: func(event.CreateEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldSimple, ok := e.ObjectOld.(*demov2.Simple)
+		if !ok {
+			return false
+		}
+		newSimple, ok := e.ObjectNew.(*demov2.Simple)
+		if !ok {
+			return false
+		}
+		return oldSimple.Status.MessageHash != newSimple.Status.MessageHash
+	},
+	GenericFunc: func(event.GenericEvent) bool { return false },
+}
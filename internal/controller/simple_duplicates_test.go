@@ -0,0 +1,84 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+var _ = Describe("Simple duplicate detection", func() {
+	It("should flag the newer Simples delivering the same messages", func() {
+		scheme := runtime.NewScheme()
+		Expect(demov2.AddToScheme(scheme)).To(Succeed())
+		created := metav1.NewTime(time.Now().Add(-time.Hour))
+		newSimple := func(name string, age time.Duration, text string) *demov2.Simple {
+			simple := &demov2.Simple{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name, Namespace: "default", UID: types.UID(name),
+					CreationTimestamp: metav1.NewTime(created.Add(-age)),
+				},
+				Status: demov2.SimpleStatus{Messages: []demov2.MessageStatus{{Message: text, Delivered: true}}},
+			}
+			simple.Status.MessageHash = messagesHash(simple)
+			return simple
+		}
+		first := newSimple("first", 2*time.Minute, "Deploy done")
+		second := newSimple("second", time.Minute, "Deploy done")
+		third := newSimple("third", 0, "Deploy done")
+		other := newSimple("other", 3*time.Minute, "Something else")
+		reconciler := &SimpleReconciler{
+			Client: fake.NewClientBuilder().WithScheme(scheme).
+				WithIndex(&demov2.Simple{}, messageHashIndex, indexMessageHash).
+				WithObjects(first, second, third, other).Build(),
+		}
+
+		By("Pointing the newer Simples to the oldest one")
+		Expect(reconciler.detectDuplicate(ctx, third)).To(Succeed())
+		Expect(third.Status.DuplicateOf).To(Equal("first"))
+		Expect(meta.IsStatusConditionTrue(third.Status.Conditions, demov2.ConditionDuplicate)).To(BeTrue())
+		Expect(reconciler.detectDuplicate(ctx, first)).To(Succeed())
+		Expect(first.Status.DuplicateOf).To(BeEmpty())
+		Expect(meta.FindStatusCondition(first.Status.Conditions, demov2.ConditionDuplicate)).To(BeNil())
+
+		By("Enqueueing the Simples with the same messages")
+		Expect(reconciler.simplesWithSameMessages(ctx, first)).To(ConsistOf(
+			reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "second"}},
+			reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "third"}},
+		))
+
+		By("Clearing the duplicate once the messages differ")
+		third.Status.Messages[0].Message = "Deploy rolled back"
+		third.Status.MessageHash = messagesHash(third)
+		Expect(reconciler.detectDuplicate(ctx, third)).To(Succeed())
+		Expect(third.Status.DuplicateOf).To(BeEmpty())
+		duplicate := meta.FindStatusCondition(third.Status.Conditions, demov2.ConditionDuplicate)
+		Expect(duplicate).NotTo(BeNil())
+		Expect(duplicate.Status).To(Equal(metav1.ConditionFalse))
+		Expect(duplicate.Reason).To(Equal(demov2.ReasonUniqueMessages))
+	})
+})
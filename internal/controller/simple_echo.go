@@ -0,0 +1,142 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+	"github.com/leobip/demo-operator/internal/format"
+)
+
+// echoDeploymentName returns the name of the echo Deployment owned by simple.
+func echoDeploymentName(simple *demov2.Simple) string {
+	return simple.Name + "-echo"
+}
+
+// messageHashAnnotation holds the SHA-256 of the text served by the echo
+// pods in their template, so they are replaced when the messages change.
+const messageHashAnnotation = "simple.example.com/message-hash"
+
+// echoLabels returns the labels selecting the echo pods of simple.
+func echoLabels(simple *demov2.Simple) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":     "simple-echo",
+		"app.kubernetes.io/instance": simple.Name,
+	}
+}
+
+// reconcileEcho makes sure the echo Deployment matches Spec.Echo and
+// Spec.Replicas, and reports its scale in the status. The Deployment is
+// removed when Spec.Echo is unset.
+func (r *SimpleReconciler) reconcileEcho(ctx context.Context, simple *demov2.Simple,
+	messages []demov2.MessageSpec) error {
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      echoDeploymentName(simple),
+			Namespace: simple.Namespace,
+		},
+	}
+
+	if simple.Spec.Echo == nil {
+		if err := r.deleteChild(ctx, simple, deploy); err != nil {
+			return err
+		}
+		simple.Status.Replicas = 0
+		simple.Status.ReadyReplicas = 0
+		simple.Status.Selector = ""
+		meta.RemoveStatusCondition(&simple.Status.Conditions, demov2.ConditionEchoProgressing)
+		meta.RemoveStatusCondition(&simple.Status.Conditions, demov2.ConditionEchoAvailable)
+		return nil
+	}
+
+	selector := echoLabels(simple)
+	text := format.Text(simple, messages)
+	sum := sha256.Sum256([]byte(text))
+
+	deploy.Spec = appsv1.DeploymentSpec{
+		Replicas: ptr.To(ptr.Deref(simple.Spec.Replicas, 1)),
+		Selector: &metav1.LabelSelector{MatchLabels: selector},
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels:      selector,
+				Annotations: map[string]string{messageHashAnnotation: hex.EncodeToString(sum[:])},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name:  "echo",
+					Image: simple.Spec.Echo.Image,
+					Args: []string{
+						fmt.Sprintf("-listen=:%d", simple.Spec.Echo.Port),
+						"-text=" + text,
+					},
+					Ports: []corev1.ContainerPort{{
+						Name:          "http",
+						ContainerPort: simple.Spec.Echo.Port,
+					}},
+				}},
+			},
+		},
+	}
+	podSpec := &deploy.Spec.Template.Spec
+	applyWorkload(simple.Spec.Echo.WorkloadSpec, podSpec, &podSpec.Containers[0])
+	// The Pods carry the propagated labels too, e.g. for cost allocation
+	r.propagate(simple, &deploy.Spec.Template)
+	if err := r.apply(ctx, simple, deploy); err != nil {
+		if apierrors.IsInvalid(err) {
+			return fmt.Errorf("%w: echo Deployment %s: %v", errInvalidSpec, deploy.Name, err)
+		}
+		return err
+	}
+
+	simple.Status.Replicas = deploy.Status.Replicas
+	simple.Status.ReadyReplicas = deploy.Status.ReadyReplicas
+	simple.Status.Selector = labels.SelectorFromSet(selector).String()
+	setRolloutConditions(simple, deploy)
+	return nil
+}
+
+// setRolloutConditions reports the rollout of deploy, the echo Deployment of
+// simple, in its EchoProgressing and EchoAvailable conditions.
+func setRolloutConditions(simple *demov2.Simple, deploy *appsv1.Deployment) {
+	mirror := func(conditionType string, deployType appsv1.DeploymentConditionType) {
+		if deploy.Status.ObservedGeneration >= deploy.Generation {
+			for _, condition := range deploy.Status.Conditions {
+				if condition.Type == deployType {
+					setCondition(simple, conditionType, metav1.ConditionStatus(condition.Status),
+						condition.Reason, condition.Message)
+					return
+				}
+			}
+		}
+		setCondition(simple, conditionType, metav1.ConditionUnknown, demov2.ReasonRolloutPending,
+			fmt.Sprintf("Waiting for the rollout of generation %d of Deployment %s", deploy.Generation, deploy.Name))
+	}
+	mirror(demov2.ConditionEchoProgressing, appsv1.DeploymentProgressing)
+	mirror(demov2.ConditionEchoAvailable, appsv1.DeploymentAvailable)
+}
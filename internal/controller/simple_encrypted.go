@@ -0,0 +1,180 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/nacl/box"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// encryptedMessageSecretIndex is the field index listing the Simples
+// decrypting their message with the private key or Vault token of a Secret
+const encryptedMessageSecretIndex = ".spec.encryptedMessage.secretRef.name"
+
+// setupEncryptedMessageIndex registers encryptedMessageSecretIndex.
+func setupEncryptedMessageIndex(ctx context.Context, mgr ctrl.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(ctx, &demov2.Simple{}, encryptedMessageSecretIndex,
+		func(obj client.Object) []string {
+			encrypted := obj.(*demov2.Simple).Spec.EncryptedMessage
+			switch {
+			case encrypted == nil:
+				return nil
+			case encrypted.SealedBox != nil:
+				return []string{encrypted.SealedBox.PrivateKeySecretRef.Name}
+			case encrypted.VaultTransit != nil:
+				return []string{encrypted.VaultTransit.TokenSecretRef.Name}
+			}
+			return nil
+		})
+}
+
+// maxVaultResponseSize bounds the responses of Vault read by decryptVaultTransit
+const maxVaultResponseSize = 1 << 20
+
+// decryptMessage returns the plaintext of Spec.EncryptedMessage. It is only
+// kept in memory, the callers must not write it anywhere but the sinks and
+// the message Secret.
+func (r *SimpleReconciler) decryptMessage(ctx context.Context, simple *demov2.Simple) (string, error) {
+	encrypted := simple.Spec.EncryptedMessage
+	switch {
+	case encrypted.SealedBox != nil:
+		return r.openSealedBox(ctx, simple, encrypted)
+	case encrypted.VaultTransit != nil:
+		return r.decryptVaultTransit(ctx, simple, encrypted)
+	default:
+		return "", fmt.Errorf("%w: encryptedMessage must set sealedBox or vaultTransit", errInvalidSpec)
+	}
+}
+
+// openSealedBox opens the sealed box of encrypted with the private key of
+// its Secret.
+func (r *SimpleReconciler) openSealedBox(ctx context.Context, simple *demov2.Simple,
+	encrypted *demov2.EncryptedMessage) (string, error) {
+	ref := encrypted.SealedBox.PrivateKeySecretRef
+	value, err := r.secretValue(ctx, simple, ref)
+	if err != nil {
+		return "", fmt.Errorf("loading the private key of encryptedMessage: %w", err)
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(value)))
+	if err != nil || len(key) != curve25519.ScalarSize {
+		return "", fmt.Errorf("%w: encryptedMessage: key %q of Secret %s isn't the base64 of a %d bytes X25519 private key",
+			errInvalidSpec, ref.Key, ref.Name, curve25519.ScalarSize)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encrypted.Ciphertext))
+	if err != nil {
+		return "", fmt.Errorf("%w: encryptedMessage.ciphertext isn't base64: %v", errInvalidSpec, err)
+	}
+
+	var privateKey, publicKey [32]byte
+	copy(privateKey[:], key)
+	public, err := curve25519.X25519(privateKey[:], curve25519.Basepoint)
+	if err != nil {
+		return "", fmt.Errorf("%w: encryptedMessage: key %q of Secret %s: %v", errInvalidSpec, ref.Key, ref.Name, err)
+	}
+	copy(publicKey[:], public)
+	plaintext, ok := box.OpenAnonymous(nil, sealed, &publicKey, &privateKey)
+	if !ok {
+		return "", fmt.Errorf("%w: encryptedMessage.ciphertext can't be opened with the key %q of Secret %s",
+			errInvalidSpec, ref.Key, ref.Name)
+	}
+	return string(plaintext), nil
+}
+
+// decryptVaultTransit has Vault decrypt encrypted with its transit key.
+func (r *SimpleReconciler) decryptVaultTransit(ctx context.Context, simple *demov2.Simple,
+	encrypted *demov2.EncryptedMessage) (string, error) {
+	vault := encrypted.VaultTransit
+	token, err := r.secretValue(ctx, simple, vault.TokenSecretRef)
+	if err != nil {
+		return "", fmt.Errorf("loading the Vault token of encryptedMessage: %w", err)
+	}
+	endpoint, err := url.JoinPath(vault.Address, "v1", cmp.Or(vault.Mount, "transit"), "decrypt", vault.KeyName)
+	if err != nil {
+		return "", fmt.Errorf("%w: encryptedMessage.vaultTransit: %v", errInvalidSpec, err)
+	}
+	body, err := json.Marshal(map[string]string{"ciphertext": strings.TrimSpace(encrypted.Ciphertext)})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("%w: encryptedMessage.vaultTransit: %v", errInvalidSpec, err)
+	}
+	req.Header.Set("X-Vault-Token", strings.TrimSpace(string(token)))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.httpClient().Do(req)
+	if err != nil {
+		return "", fmt.Errorf("decrypting encryptedMessage with Vault: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	var decrypted struct {
+		Data struct {
+			Plaintext string `json:"plaintext"`
+		} `json:"data"`
+		Errors []string `json:"errors"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxVaultResponseSize)).Decode(&decrypted); err != nil &&
+		resp.StatusCode == http.StatusOK {
+		return "", fmt.Errorf("decoding the response of Vault: %w", err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusBadRequest:
+		// Vault rejects ciphertexts it can't decrypt with the key
+		return "", fmt.Errorf("%w: Vault can't decrypt encryptedMessage.ciphertext with key %s: %s",
+			errInvalidSpec, vault.KeyName, strings.Join(decrypted.Errors, ", "))
+	case resp.StatusCode != http.StatusOK:
+		return "", fmt.Errorf("decrypting encryptedMessage with Vault: %s %s", resp.Status,
+			strings.Join(decrypted.Errors, ", "))
+	}
+	plaintext, err := base64.StdEncoding.DecodeString(decrypted.Data.Plaintext)
+	if err != nil {
+		return "", fmt.Errorf("decoding the plaintext returned by Vault: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// secretValue returns the key of the Secret of the namespace of simple ref selects.
+func (r *SimpleReconciler) secretValue(ctx context.Context, simple *demov2.Simple,
+	ref corev1.SecretKeySelector) ([]byte, error) {
+	var secret corev1.Secret
+	if err := r.liveReader().Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: simple.Namespace}, &secret); err != nil {
+		return nil, fmt.Errorf("loading Secret %s: %w", ref.Name, err)
+	}
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf("secret %s has no key %q", ref.Name, ref.Key)
+	}
+	return value, nil
+}
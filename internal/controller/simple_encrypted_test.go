@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/nacl/box"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+var _ = Describe("Simple encrypted message", func() {
+	var (
+		r      *SimpleReconciler
+		simple *demov2.Simple
+	)
+
+	keyRef := func(name, key string) corev1.SecretKeySelector {
+		return corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: name}, Key: key}
+	}
+
+	newReconciler := func(data map[string][]byte) {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "keys", Namespace: "default"}, Data: data}
+		r = &SimpleReconciler{
+			Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(secret).Build(),
+			Scheme: scheme.Scheme,
+		}
+	}
+
+	It("should open a sealed box with the private key of its Secret", func() {
+		publicKey, privateKey, err := box.GenerateKey(rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		sealed, err := box.SealAnonymous(nil, []byte("Hello"), publicKey, rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		newReconciler(map[string][]byte{"private": []byte(base64.StdEncoding.EncodeToString(privateKey[:]))})
+		simple = &demov2.Simple{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: demov2.SimpleSpec{EncryptedMessage: &demov2.EncryptedMessage{
+				Ciphertext: base64.StdEncoding.EncodeToString(sealed),
+				SealedBox:  &demov2.SealedBoxDecryption{PrivateKeySecretRef: keyRef("keys", "private")},
+			}},
+		}
+		Expect(r.decryptMessage(ctx, simple)).To(Equal("Hello"))
+
+		By("rejecting a ciphertext sealed for another key")
+		otherKey, _, err := box.GenerateKey(rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		sealed, err = box.SealAnonymous(nil, []byte("Hello"), otherKey, rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		simple.Spec.EncryptedMessage.Ciphertext = base64.StdEncoding.EncodeToString(sealed)
+		_, err = r.decryptMessage(ctx, simple)
+		Expect(err).To(MatchError(errInvalidSpec))
+		Expect(err).To(MatchError(ContainSubstring("can't be opened")))
+
+		By("failing while the key is missing")
+		simple.Spec.EncryptedMessage.SealedBox.PrivateKeySecretRef = keyRef("missing", "private")
+		_, err = r.decryptMessage(ctx, simple)
+		Expect(err).NotTo(MatchError(errInvalidSpec))
+	})
+
+	It("should decrypt with the transit key of Vault", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			var body struct {
+				Ciphertext string `json:"ciphertext"`
+			}
+			_ = json.NewDecoder(req.Body).Decode(&body)
+			switch {
+			case req.Header.Get("X-Vault-Token") != "s.token":
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			case req.URL.Path != "/v1/transit/decrypt/motd" || body.Ciphertext != "vault:v1:abc":
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"errors":["invalid ciphertext"]}`))
+			default:
+				_, _ = w.Write([]byte(`{"data":{"plaintext":"` + base64.StdEncoding.EncodeToString([]byte("Hello")) + `"}}`))
+			}
+		}))
+		DeferCleanup(server.Close)
+		newReconciler(map[string][]byte{"token": []byte("s.token\n"), "stale": []byte("s.stale")})
+		simple = &demov2.Simple{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: demov2.SimpleSpec{EncryptedMessage: &demov2.EncryptedMessage{
+				Ciphertext: "vault:v1:abc",
+				VaultTransit: &demov2.VaultTransitDecryption{
+					Address: server.URL, KeyName: "motd", TokenSecretRef: keyRef("keys", "token"),
+				},
+			}},
+		}
+		Expect(r.decryptMessage(ctx, simple)).To(Equal("Hello"))
+
+		simple.Spec.EncryptedMessage.Ciphertext = "vault:v1:other"
+		_, err := r.decryptMessage(ctx, simple)
+		Expect(err).To(MatchError(errInvalidSpec))
+		Expect(err).To(MatchError(ContainSubstring("invalid ciphertext")))
+
+		simple.Spec.EncryptedMessage.VaultTransit.TokenSecretRef = keyRef("keys", "stale")
+		_, err = r.decryptMessage(ctx, simple)
+		Expect(err).NotTo(MatchError(errInvalidSpec))
+		Expect(err).To(MatchError(ContainSubstring("permission denied")))
+	})
+})
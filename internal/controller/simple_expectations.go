@@ -0,0 +1,135 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// expectationsTTL is how long a creation or a deletion is waited for before
+// the expectation is dropped, in case its watch event was lost
+const expectationsTTL = 5 * time.Minute
+
+// expectationOp is the write awaited by an expectation
+type expectationOp string
+
+const (
+	opCreation expectationOp = "create"
+	opDeletion expectationOp = "delete"
+)
+
+// expectationKey is a write awaited on the child of kind namespace/name
+type expectationKey struct {
+	op   expectationOp
+	kind string
+	key  types.NamespacedName
+}
+
+// expectations tracks the children each Simple created or deleted that the
+// cache hasn't observed yet, by UID of the Simple, like the expectations of
+// the ReplicaSet controller. A reconciliation reading a stale cache then
+// knows the missing child was created, or the listed one deleted, rather
+// than writing it a second time. The zero value is ready to use.
+type expectations struct {
+	mu      sync.Mutex
+	pending map[types.UID]map[expectationKey]time.Time
+}
+
+// expect records that the child obj of kind of the Simple uid is about to be
+// written by op.
+func (e *expectations) expect(uid types.UID, op expectationOp, kind string, obj client.Object) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.pending == nil {
+		e.pending = map[types.UID]map[expectationKey]time.Time{}
+	}
+	if e.pending[uid] == nil {
+		e.pending[uid] = map[expectationKey]time.Time{}
+	}
+	e.pending[uid][expectationKey{op, kind, client.ObjectKeyFromObject(obj)}] = time.Now()
+}
+
+// observed drops the expectation of op on the child obj of kind of the
+// Simple uid, once the cache saw the write or it failed.
+func (e *expectations) observed(uid types.UID, op expectationOp, kind string, obj client.Object) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.pending[uid], expectationKey{op, kind, client.ObjectKeyFromObject(obj)})
+	if len(e.pending[uid]) == 0 {
+		delete(e.pending, uid)
+	}
+}
+
+// isPending tells whether op on the child obj of kind of the Simple uid is
+// still awaited, that is it wasn't observed and didn't expire.
+func (e *expectations) isPending(uid types.UID, op expectationOp, kind string, obj client.Object) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	k := expectationKey{op, kind, client.ObjectKeyFromObject(obj)}
+	timestamp, ok := e.pending[uid][k]
+	if !ok {
+		return false
+	}
+	if time.Since(timestamp) > expectationsTTL {
+		delete(e.pending[uid], k)
+		return false
+	}
+	return true
+}
+
+// forget drops the expectations of the deleted Simple uid.
+func (e *expectations) forget(uid types.UID) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.pending, uid)
+}
+
+// observer returns the predicate of the watch of the children of kind. It
+// lowers the expectations of the Simple controlling each child created or
+// deleted, and filters no event.
+func (e *expectations) observer(kind string) predicate.Predicate {
+	observe := func(op expectationOp, obj client.Object) bool {
+		if owner := metav1.GetControllerOf(obj); owner != nil && owner.Kind == "Simple" {
+			e.observed(owner.UID, op, kind, obj)
+		}
+		return true
+	}
+	return predicate.Funcs{
+		CreateFunc: func(ev event.CreateEvent) bool { return observe(opCreation, ev.Object) },
+		DeleteFunc: func(ev event.DeleteEvent) bool { return observe(opDeletion, ev.Object) },
+	}
+}
+
+// expect records the expectation of op on the child obj of kind of simple,
+// and tells whether it did: nothing is written in dry-run mode, so no event
+// would come.
+func (r *SimpleReconciler) expect(simple *demov2.Simple, op expectationOp, kind string, obj client.Object) bool {
+	if r.DryRun != nil {
+		return false
+	}
+	r.expectations.expect(simple.UID, op, kind, obj)
+	return true
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+var _ = Describe("Simple expectations", func() {
+	const uid = types.UID("simple-uid")
+	child := func(name string) client.Object {
+		return &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "demo.demo.local/v2", Kind: "Simple", Name: "test", UID: uid, Controller: ptr.To(true),
+			}},
+		}}
+	}
+
+	It("should hold each write until it is observed", func() {
+		var e expectations
+		e.expect(uid, opCreation, "Job", child("a"))
+		e.expect(uid, opDeletion, "Job", child("b"))
+		Expect(e.isPending(uid, opCreation, "Job", child("a"))).To(BeTrue())
+		Expect(e.isPending(uid, opDeletion, "Job", child("a"))).To(BeFalse())
+		Expect(e.isPending(uid, opCreation, "ConfigMap", child("a"))).To(BeFalse())
+		Expect(e.isPending("other-uid", opCreation, "Job", child("a"))).To(BeFalse())
+
+		e.observed(uid, opCreation, "Job", child("a"))
+		Expect(e.isPending(uid, opCreation, "Job", child("a"))).To(BeFalse())
+		Expect(e.isPending(uid, opDeletion, "Job", child("b"))).To(BeTrue())
+
+		By("forgetting the deleted Simples")
+		e.forget(uid)
+		Expect(e.isPending(uid, opDeletion, "Job", child("b"))).To(BeFalse())
+		Expect(e.pending).To(BeEmpty())
+	})
+
+	It("should give up on the writes never observed", func() {
+		var e expectations
+		e.expect(uid, opCreation, "Job", child("a"))
+		for k := range e.pending[uid] {
+			e.pending[uid][k] = time.Now().Add(-expectationsTTL - time.Second)
+		}
+		Expect(e.isPending(uid, opCreation, "Job", child("a"))).To(BeFalse())
+		Expect(e.pending[uid]).To(BeEmpty())
+	})
+
+	It("should observe the writes on the children controlled by a Simple", func() {
+		var e expectations
+		e.expect(uid, opCreation, "Job", child("a"))
+		e.expect(uid, opDeletion, "Job", child("b"))
+		e.expect(uid, opCreation, "ConfigMap", child("a"))
+		observer := e.observer("Job")
+
+		Expect(observer.Create(event.CreateEvent{Object: child("a")})).To(BeTrue())
+		Expect(observer.Delete(event.DeleteEvent{Object: child("b")})).To(BeTrue())
+		Expect(e.isPending(uid, opCreation, "Job", child("a"))).To(BeFalse())
+		Expect(e.isPending(uid, opDeletion, "Job", child("b"))).To(BeFalse())
+		Expect(e.isPending(uid, opCreation, "ConfigMap", child("a"))).To(BeTrue())
+
+		By("ignoring the objects not controlled by a Simple")
+		e.expect(uid, opCreation, "Job", child("a"))
+		orphan := child("a")
+		orphan.SetOwnerReferences(nil)
+		Expect(observer.Create(event.CreateEvent{Object: orphan})).To(BeTrue())
+		Expect(e.isPending(uid, opCreation, "Job", child("a"))).To(BeTrue())
+	})
+
+	Context("with a stale cache", func() {
+		var (
+			r        *SimpleReconciler
+			recorder *record.FakeRecorder
+			simple   *demov2.Simple
+			creates  int
+			deletes  int
+		)
+
+		BeforeEach(func() {
+			creates, deletes = 0, 0
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(demov2.AddToScheme(scheme)).To(Succeed())
+			// The children written are never seen by the reads, like a cache
+			// that didn't observe them yet
+			c := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+				Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object,
+					opts ...client.GetOption) error {
+					return apierrors.NewNotFound(corev1.Resource("configmaps"), key.Name)
+				},
+				Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					creates++
+					return c.Create(ctx, obj, opts...)
+				},
+				Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+					deletes++
+					return c.Delete(ctx, obj, opts...)
+				},
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch,
+					opts ...client.PatchOption) error {
+					if patch == client.Apply {
+						return nil
+					}
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+			}).Build()
+			recorder = record.NewFakeRecorder(10)
+			r = &SimpleReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+			simple = &demov2.Simple{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: uid},
+				Spec:       demov2.SimpleSpec{Run: &demov2.RunSpec{Image: "busybox"}},
+				Status:     demov2.SimpleStatus{ReplyCount: 1, MessageHash: "sha256:0123"},
+			}
+		})
+
+		It("should create the run Job of a delivery once", func() {
+			Expect(r.createRunJob(ctx, simple, nil)).To(Succeed())
+			name := simple.Status.Run.JobName
+
+			simple.Status.Run = nil
+			Expect(r.createRunJob(ctx, simple, nil)).To(Succeed())
+			Expect(creates).To(Equal(1))
+			Expect(simple.Status.Run).To(HaveField("JobName", name))
+
+			By("falling back to the live Job once the expectations are lost")
+			r.expectations.forget(uid)
+			simple.Status.Run = nil
+			Expect(r.createRunJob(ctx, simple, nil)).To(MatchError(ContainSubstring("getting Job")))
+			Expect(creates).To(Equal(2))
+		})
+
+		It("should report an applied child as created once", func() {
+			cm := func() *corev1.ConfigMap {
+				return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-message", Namespace: "default"}}
+			}
+			Expect(r.apply(ctx, simple, cm())).To(Succeed())
+			Expect(recorder.Events).To(Receive(ContainSubstring("Created ConfigMap test-message")))
+			Expect(r.apply(ctx, simple, cm())).To(Succeed())
+			Expect(recorder.Events).NotTo(Receive())
+
+			By("observing the creation")
+			observed := cm()
+			Expect(controllerutil.SetControllerReference(simple, observed, r.Scheme)).To(Succeed())
+			r.expectations.observer("ConfigMap").Create(event.CreateEvent{Object: observed})
+			Expect(r.expectations.pending).To(BeEmpty())
+		})
+
+		It("should not delete a pruned run Job twice", func() {
+			var listed []batchv1.Job
+			for i, name := range []string{"test-run-a", "test-run-b", "test-run-c"} {
+				job := batchv1.Job{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              name,
+						Namespace:         "default",
+						Labels:            ownerLabels(simple),
+						CreationTimestamp: metav1.NewTime(time.Now().Add(time.Duration(i) * time.Minute)),
+					},
+					Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{{
+						Type: batchv1.JobComplete, Status: corev1.ConditionTrue,
+					}}},
+				}
+				Expect(r.Create(ctx, job.DeepCopy())).To(Succeed())
+				listed = append(listed, job)
+			}
+			simple.Spec.Run.SuccessfulJobsHistoryLimit = ptr.To[int32](2)
+			// The cache keeps listing the Jobs deleted
+			r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+				List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+					list.(*batchv1.JobList).Items = append([]batchv1.Job(nil), listed...)
+					return nil
+				},
+			})
+
+			Expect(r.pruneRunJobs(ctx, simple)).To(Succeed())
+			Expect(r.pruneRunJobs(ctx, simple)).To(Succeed())
+			Expect(deletes).To(Equal(1))
+		})
+	})
+})
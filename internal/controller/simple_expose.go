@@ -0,0 +1,245 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/url"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// httpRouteGVK is the Gateway API HTTPRoute kind. It is handled as
+// unstructured so that the Gateway API CRDs are only needed when used.
+var httpRouteGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+
+// exposeName returns the name of the Service, Ingress and HTTPRoute owned by simple.
+func exposeName(simple *demov2.Simple) string {
+	return simple.Name + "-echo"
+}
+
+// reconcileExpose makes sure the Service, Ingress and HTTPRoute in front of
+// the echo Deployment match Spec.Expose, and reports where the messages are
+// served in Status.URL. The objects are removed when they are no longer set.
+func (r *SimpleReconciler) reconcileExpose(ctx context.Context, simple *demov2.Simple) error {
+	expose := simple.Spec.Expose
+	if simple.Spec.Echo == nil {
+		expose = nil
+	}
+	objectMeta := metav1.ObjectMeta{Name: exposeName(simple), Namespace: simple.Namespace}
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(httpRouteGVK)
+	route.SetName(objectMeta.Name)
+	route.SetNamespace(objectMeta.Namespace)
+
+	if expose == nil {
+		simple.Status.URL = ""
+		for _, obj := range []client.Object{
+			&networkingv1.Ingress{ObjectMeta: objectMeta}, route, &corev1.Service{ObjectMeta: objectMeta},
+		} {
+			if err := r.deleteChild(ctx, simple, obj); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	svc := &corev1.Service{
+		ObjectMeta: objectMeta,
+		Spec: corev1.ServiceSpec{
+			Selector: echoLabels(simple),
+			Ports: []corev1.ServicePort{{
+				Name:       "http",
+				Port:       expose.Port,
+				TargetPort: intstr.FromString("http"),
+			}},
+		},
+	}
+	if err := r.apply(ctx, simple, svc); err != nil {
+		return err
+	}
+	serviceURL := fmt.Sprintf("http://%s.%s.svc:%d", svc.Name, svc.Namespace, expose.Port)
+
+	ingressURL, err := r.reconcileIngress(ctx, simple, objectMeta)
+	if err != nil {
+		return err
+	}
+	routeURL, err := r.reconcileHTTPRoute(ctx, simple, route)
+	if err != nil {
+		return err
+	}
+	simple.Status.URL = cmp.Or(ingressURL, routeURL, serviceURL)
+	return nil
+}
+
+// reconcileIngress makes sure the Ingress of simple matches
+// Spec.Expose.Ingress, and returns its URL once it is known.
+func (r *SimpleReconciler) reconcileIngress(ctx context.Context, simple *demov2.Simple,
+	objectMeta metav1.ObjectMeta) (string, error) {
+	spec := simple.Spec.Expose.Ingress
+	ingress := &networkingv1.Ingress{ObjectMeta: objectMeta}
+	if spec == nil {
+		return "", r.deleteChild(ctx, simple, ingress)
+	}
+
+	path := cmp.Or(spec.Path, "/")
+	ingress.Spec = networkingv1.IngressSpec{
+		IngressClassName: spec.ClassName,
+		Rules: []networkingv1.IngressRule{{
+			Host: spec.Host,
+			IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+				Paths: []networkingv1.HTTPIngressPath{{
+					Path:     path,
+					PathType: ptr.To(networkingv1.PathTypePrefix),
+					Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{
+						Name: exposeName(simple),
+						Port: networkingv1.ServiceBackendPort{Name: "http"},
+					}},
+				}},
+			}},
+		}},
+	}
+	scheme := "http"
+	if spec.TLSSecretName != "" {
+		scheme = "https"
+		tls := networkingv1.IngressTLS{SecretName: spec.TLSSecretName}
+		if spec.Host != "" {
+			tls.Hosts = []string{spec.Host}
+		}
+		ingress.Spec.TLS = []networkingv1.IngressTLS{tls}
+	}
+	if err := r.apply(ctx, simple, ingress); err != nil {
+		if apierrors.IsInvalid(err) {
+			return "", fmt.Errorf("%w: Ingress %s: %v", errInvalidSpec, ingress.Name, err)
+		}
+		return "", err
+	}
+
+	host := spec.Host
+	if host == "" {
+		for _, lb := range ingress.Status.LoadBalancer.Ingress {
+			if host = cmp.Or(lb.Hostname, lb.IP); host != "" {
+				break
+			}
+		}
+	}
+	if host == "" {
+		return "", nil
+	}
+	return (&url.URL{Scheme: scheme, Host: host, Path: path}).String(), nil
+}
+
+// reconcileHTTPRoute makes sure the HTTPRoute of simple matches
+// Spec.Expose.HTTPRoute, and returns its URL when it serves a host.
+func (r *SimpleReconciler) reconcileHTTPRoute(ctx context.Context, simple *demov2.Simple,
+	route *unstructured.Unstructured) (string, error) {
+	spec := simple.Spec.Expose.HTTPRoute
+	if spec == nil {
+		return "", r.deleteChild(ctx, simple, route)
+	}
+
+	parent := map[string]any{"name": spec.GatewayName}
+	if spec.GatewayNamespace != "" {
+		parent["namespace"] = spec.GatewayNamespace
+	}
+	if spec.SectionName != "" {
+		parent["sectionName"] = spec.SectionName
+	}
+	path := cmp.Or(spec.Path, "/")
+	routeSpec := map[string]any{
+		"parentRefs": []any{parent},
+		"rules": []any{map[string]any{
+			"matches": []any{map[string]any{
+				"path": map[string]any{"type": "PathPrefix", "value": path},
+			}},
+			"backendRefs": []any{map[string]any{
+				"name": exposeName(simple),
+				"port": int64(simple.Spec.Expose.Port),
+			}},
+		}},
+	}
+	if spec.Host != "" {
+		routeSpec["hostnames"] = []any{spec.Host}
+	}
+	route.Object["spec"] = routeSpec
+	if err := r.apply(ctx, simple, route); err != nil {
+		if meta.IsNoMatchError(err) {
+			return "", fmt.Errorf("%w: HTTPRoute %s: the Gateway API CRDs are not installed", errInvalidSpec, route.GetName())
+		}
+		if apierrors.IsInvalid(err) {
+			return "", fmt.Errorf("%w: HTTPRoute %s: %v", errInvalidSpec, route.GetName(), err)
+		}
+		return "", err
+	}
+
+	if spec.Host == "" {
+		return "", nil
+	}
+	return (&url.URL{Scheme: "http", Host: spec.Host, Path: path}).String(), nil
+}
+
+// deleteChild deletes the child obj of simple if it exists and simple
+// controls it: an object of the same name may belong to the user. Kinds not
+// installed in the cluster are ignored.
+func (r *SimpleReconciler) deleteChild(ctx context.Context, simple *demov2.Simple, obj client.Object) error {
+	gvk, err := apiutil.GVKForObject(obj, r.Scheme)
+	if err != nil {
+		return err
+	}
+	current := currentObject(obj, gvk)
+	err = r.liveReader().Get(ctx, client.ObjectKeyFromObject(obj), current)
+	if err == nil {
+		// The cache may not have observed the deletion of an earlier reconciliation yet
+		if !metav1.IsControlledBy(current, simple) ||
+			r.expectations.isPending(simple.UID, opDeletion, gvk.Kind, current) {
+			return nil
+		}
+		c, clientErr := r.deleteClient(simple)
+		if clientErr != nil {
+			return clientErr
+		}
+		expected := r.expect(simple, opDeletion, gvk.Kind, current)
+		uid := current.GetUID()
+		err = c.Delete(ctx, current, client.Preconditions{UID: &uid})
+		if client.IgnoreNotFound(err) != nil && expected {
+			r.expectations.observed(simple.UID, opDeletion, gvk.Kind, current)
+		}
+	}
+	switch {
+	case apierrors.IsNotFound(err), meta.IsNoMatchError(err):
+	case err != nil:
+		return fmt.Errorf("deleting %s %s: %w", gvk.Kind, obj.GetName(), err)
+	default:
+		log.FromContext(ctx).Info("Deleted child object", "kind", gvk.Kind, "name", obj.GetName())
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+const (
+	// simpleFinalizer lets the controller clean up before a Simple goes away
+	simpleFinalizer = "simple.example.com/finalizer"
+
+	// ownerNameLabel and ownerNamespaceLabel mark the artifacts created for a
+	// Simple, so the ones owner references can't cover are found on deletion
+	ownerNameLabel      = "simple.example.com/owner-name"
+	ownerNamespaceLabel = "simple.example.com/owner-namespace"
+)
+
+// deletionNotification is the payload POSTed to Spec.DeletionNotificationURL
+type deletionNotification struct {
+	Event     string `json:"event"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	UID       string `json:"uid"`
+}
+
+// ownerLabels returns the labels identifying the artifacts created for simple.
+func ownerLabels(simple *demov2.Simple) map[string]string {
+	return map[string]string{
+		ownerNameLabel:      simple.Name,
+		ownerNamespaceLabel: simple.Namespace,
+	}
+}
+
+// labeledFor tells whether obj carries the ownerLabels of simple.
+func labeledFor(obj client.Object, simple *demov2.Simple) bool {
+	for k, v := range ownerLabels(simple) {
+		if obj.GetLabels()[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// finalize runs the cleanup of a deleted Simple and then removes its finalizer.
+func (r *SimpleReconciler) finalize(ctx context.Context, simple *demov2.Simple) error {
+	if !controllerutil.ContainsFinalizer(simple, simpleFinalizer) {
+		return nil
+	}
+
+	// 1. Delete the artifacts living outside of the Simple's namespace
+	if err := r.deleteForeignArtifacts(ctx, simple); err != nil {
+		return err
+	}
+
+	// 2. Delete the copies in the remote clusters
+	if err := r.deleteRemoteCopies(ctx, simple); err != nil {
+		return err
+	}
+
+	// 3. Tell the outside world
+	if simple.Spec.DeletionNotificationURL != "" {
+		if err := r.notifyDeletion(ctx, simple); err != nil {
+			return err
+		}
+	}
+	if r.Audit != nil {
+		if err := r.auditDeletion(ctx, simple); err != nil {
+			return err
+		}
+	}
+
+	// 4. Let the API server delete the Simple
+	patch := client.MergeFromWithOptions(simple.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	controllerutil.RemoveFinalizer(simple, simpleFinalizer)
+	return r.Patch(ctx, simple, patch)
+}
+
+// foreignArtifact is a labeled ConfigMap or Secret created for a Simple in another namespace.
+type foreignArtifact struct {
+	client.Object
+	kind demov2.OutputKind
+}
+
+// listForeignArtifacts returns the labeled ConfigMaps and Secrets created for
+// simple in other namespaces.
+func (r *SimpleReconciler) listForeignArtifacts(ctx context.Context, simple *demov2.Simple) ([]foreignArtifact, error) {
+	var configMaps corev1.ConfigMapList
+	if err := r.List(ctx, &configMaps, client.MatchingLabels(ownerLabels(simple))); err != nil {
+		return nil, fmt.Errorf("listing artifacts: %w", err)
+	}
+	var secrets corev1.SecretList
+	if err := r.List(ctx, &secrets, client.MatchingLabels(ownerLabels(simple))); err != nil {
+		return nil, fmt.Errorf("listing artifacts: %w", err)
+	}
+
+	var artifacts []foreignArtifact
+	for i := range configMaps.Items {
+		if configMaps.Items[i].Namespace != simple.Namespace {
+			artifacts = append(artifacts, foreignArtifact{&configMaps.Items[i], demov2.OutputKindConfigMap})
+		}
+	}
+	for i := range secrets.Items {
+		if secrets.Items[i].Namespace != simple.Namespace {
+			artifacts = append(artifacts, foreignArtifact{&secrets.Items[i], demov2.OutputKindSecret})
+		}
+	}
+	return artifacts, nil
+}
+
+// deleteForeignArtifacts deletes the labeled ConfigMaps and Secrets created
+// for simple in other namespaces. The ones in its own namespace are
+// garbage-collected through their owner references.
+func (r *SimpleReconciler) deleteForeignArtifacts(ctx context.Context, simple *demov2.Simple) error {
+	artifacts, err := r.listForeignArtifacts(ctx, simple)
+	if err != nil || len(artifacts) == 0 {
+		return err
+	}
+	c, err := r.deleteClient(simple)
+	if err != nil {
+		return err
+	}
+
+	for _, artifact := range artifacts {
+		if err := c.Delete(ctx, artifact.Object); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("deleting %s %s/%s: %w", artifact.kind, artifact.GetNamespace(), artifact.GetName(), err)
+		}
+		log.FromContext(ctx).Info("Deleted artifact", "kind", artifact.kind, "name", artifact.GetName(),
+			"namespace", artifact.GetNamespace())
+	}
+	return nil
+}
+
+// notifyDeletion POSTs a deletion notification to the Simple's notification URL.
+func (r *SimpleReconciler) notifyDeletion(ctx context.Context, simple *demov2.Simple) error {
+	if r.DryRun != nil {
+		r.DryRun.record(ctx, dryRunSimpleEntry(simple, "notify", "deletion to "+simple.Spec.DeletionNotificationURL))
+		return nil
+	}
+	body, err := json.Marshal(deletionNotification{
+		Event:     "deleted",
+		Name:      simple.Name,
+		Namespace: simple.Namespace,
+		UID:       string(simple.UID),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, simple.Spec.DeletionNotificationURL,
+		bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("building deletion notification: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.httpClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("sending deletion notification: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("deletion notification returned %s", resp.Status)
+	}
+	log.FromContext(ctx).Info("Sent deletion notification", "url", simple.Spec.DeletionNotificationURL)
+	return nil
+}
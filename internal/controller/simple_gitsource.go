@@ -0,0 +1,234 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	git "github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/storage/memory"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+const (
+	// defaultGitSyncInterval is used when Spec.GitSource.Interval is unset
+	defaultGitSyncInterval = 5 * time.Minute
+	// gitTimeout bounds a sync of Spec.GitSource, clone included
+	gitTimeout = time.Minute
+)
+
+// commitSHA matches a Spec.GitSource.Ref naming a commit
+var commitSHA = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// gitSourceSyncRemaining returns the time until Spec.GitSource is synced again.
+func gitSourceSyncRemaining(simple *demov2.Simple, now time.Time) (remaining time.Duration, ok bool) {
+	source := simple.Spec.GitSource
+	if source == nil {
+		return 0, false
+	}
+	status := syncedGitSource(simple)
+	if status == nil {
+		return 0, true
+	}
+	interval := defaultGitSyncInterval
+	if source.Interval != nil && source.Interval.Duration > 0 {
+		interval = source.Interval.Duration
+	}
+	return status.LastSyncTime.Add(interval).Sub(now), true
+}
+
+// syncedGitSource returns the status of the last sync of Spec.GitSource, or
+// nil if the repository, ref or path changed since.
+func syncedGitSource(simple *demov2.Simple) *demov2.GitSourceStatus {
+	source, status := simple.Spec.GitSource, simple.Status.GitSource
+	if status == nil || status.URL != source.URL || status.Ref != source.Ref || status.Path != source.Path {
+		return nil
+	}
+	return status
+}
+
+// resolveGitSource returns the message stored in the file of Spec.GitSource.
+// The ref is resolved once the sync interval elapsed, and the repository is
+// only cloned when it points to a new commit. When the sync fails the
+// previous content is kept.
+func (r *SimpleReconciler) resolveGitSource(ctx context.Context, simple *demov2.Simple) (string, error) {
+	source := simple.Spec.GitSource
+	now := time.Now()
+	previous := syncedGitSource(simple)
+	if remaining, _ := gitSourceSyncRemaining(simple, now); previous != nil && remaining > 0 {
+		return previous.Message, nil
+	}
+
+	status, err := r.syncGitSource(ctx, simple, previous)
+	if err != nil {
+		setCondition(simple, demov2.ConditionMessageFetched, metav1.ConditionFalse, demov2.ReasonFetchFailed, err.Error())
+		if previous == nil {
+			return "", err
+		}
+		// Keep delivering the previous content and try again on the next sync
+		previous.LastSyncTime = metav1.NewTime(now)
+		return previous.Message, nil
+	}
+	if previous != nil && status.Commit == previous.Commit {
+		setCondition(simple, demov2.ConditionMessageFetched, metav1.ConditionTrue,
+			demov2.ReasonNotModified, "Commit "+status.Commit+" of "+source.URL+" didn't change")
+	} else {
+		setCondition(simple, demov2.ConditionMessageFetched, metav1.ConditionTrue,
+			demov2.ReasonFetched, "Synced "+source.Path+" at commit "+status.Commit)
+	}
+	simple.Status.GitSource = status
+	return status.Message, nil
+}
+
+// syncGitSource resolves the ref of Spec.GitSource and reads its file,
+// reusing the content of previous when the commit didn't change.
+func (r *SimpleReconciler) syncGitSource(ctx context.Context, simple *demov2.Simple,
+	previous *demov2.GitSourceStatus) (*demov2.GitSourceStatus, error) {
+	source := simple.Spec.GitSource
+	ctx, cancel := context.WithTimeout(ctx, gitTimeout)
+	defer cancel()
+
+	auth, err := r.gitAuth(ctx, simple)
+	if err != nil {
+		return nil, err
+	}
+	commit, refName, err := resolveGitRef(ctx, source, auth)
+	if err != nil {
+		return nil, err
+	}
+	status := &demov2.GitSourceStatus{
+		URL:          source.URL,
+		Ref:          source.Ref,
+		Path:         source.Path,
+		Commit:       commit.String(),
+		LastSyncTime: metav1.Now(),
+	}
+	if previous != nil && previous.Commit == status.Commit {
+		status.Message = previous.Message
+		return status, nil
+	}
+
+	// A branch or tag is cloned shallowly, a commit needs the whole history
+	opts := &git.CloneOptions{URL: source.URL, Auth: auth, NoCheckout: true, Tags: git.NoTags}
+	if refName != "" {
+		opts.ReferenceName = refName
+		opts.SingleBranch = true
+		opts.Depth = 1
+	}
+	repo, err := git.CloneContext(ctx, memory.NewStorage(), nil, opts)
+	if err != nil {
+		return nil, fmt.Errorf("cloning %s: %w", source.URL, err)
+	}
+	if status.Message, err = gitFile(repo, commit, source.Path); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// gitAuth returns the credentials of Spec.GitSource.SecretRef, if any.
+func (r *SimpleReconciler) gitAuth(ctx context.Context, simple *demov2.Simple) (transport.AuthMethod, error) {
+	ref := simple.Spec.GitSource.SecretRef
+	if ref == nil {
+		return nil, nil
+	}
+	var secret corev1.Secret
+	if err := r.liveReader().Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: simple.Namespace}, &secret); err != nil {
+		return nil, fmt.Errorf("loading the Git credentials from Secret %s: %w", ref.Name, err)
+	}
+	password, ok := secret.Data["password"]
+	if !ok {
+		return nil, fmt.Errorf("secret %s has no key %q", ref.Name, "password")
+	}
+	return &githttp.BasicAuth{Username: string(secret.Data["username"]), Password: string(password)}, nil
+}
+
+// resolveGitRef returns the commit Spec.GitSource.Ref points to in the remote
+// repository, and the full name of the ref unless it is a commit SHA.
+func resolveGitRef(ctx context.Context, source *demov2.GitSource,
+	auth transport.AuthMethod) (plumbing.Hash, plumbing.ReferenceName, error) {
+	if commitSHA.MatchString(source.Ref) {
+		return plumbing.NewHash(source.Ref), "", nil
+	}
+
+	remote := git.NewRemote(memory.NewStorage(), &gitconfig.RemoteConfig{Name: "origin", URLs: []string{source.URL}})
+	refs, err := remote.ListContext(ctx, &git.ListOptions{Auth: auth})
+	if err != nil {
+		return plumbing.ZeroHash, "", fmt.Errorf("listing the refs of %s: %w", source.URL, err)
+	}
+	byName := make(map[plumbing.ReferenceName]*plumbing.Reference, len(refs))
+	for _, ref := range refs {
+		byName[ref.Name()] = ref
+	}
+
+	candidates := []plumbing.ReferenceName{plumbing.HEAD}
+	if source.Ref != "" {
+		candidates = []plumbing.ReferenceName{
+			plumbing.NewBranchReferenceName(source.Ref),
+			plumbing.NewTagReferenceName(source.Ref),
+			plumbing.ReferenceName(source.Ref),
+		}
+	}
+	for _, name := range candidates {
+		ref, ok := byName[name]
+		if !ok {
+			continue
+		}
+		if ref.Type() == plumbing.SymbolicReference {
+			if ref, ok = byName[ref.Target()]; !ok {
+				continue
+			}
+		}
+		// Annotated tags are advertised along with the commit they point to
+		if peeled, ok := byName[ref.Name()+"^{}"]; ok {
+			return peeled.Hash(), ref.Name(), nil
+		}
+		return ref.Hash(), ref.Name(), nil
+	}
+	return plumbing.ZeroHash, "", fmt.Errorf("%s has no ref %q", source.URL, source.Ref)
+}
+
+// gitFile returns the content of the file at path in commit.
+func gitFile(repo *git.Repository, commit plumbing.Hash, path string) (string, error) {
+	c, err := repo.CommitObject(commit)
+	if err != nil {
+		return "", fmt.Errorf("reading commit %s: %w", commit, err)
+	}
+	file, err := c.File(path)
+	if errors.Is(err, object.ErrFileNotFound) {
+		return "", fmt.Errorf("%w: %s not found at commit %s", errInvalidSpec, path, commit)
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading %s at commit %s: %w", path, commit, err)
+	}
+	if file.Size > maxFetchedMessageSize {
+		return "", fmt.Errorf("%s exceeds %d bytes", path, maxFetchedMessageSize)
+	}
+	return file.Contents()
+}
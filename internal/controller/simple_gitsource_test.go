@@ -0,0 +1,128 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+var _ = Describe("Git source", func() {
+	var (
+		dir      string
+		worktree *git.Worktree
+		repo     *git.Repository
+	)
+
+	commit := func(content string) plumbing.Hash {
+		GinkgoHelper()
+		Expect(os.WriteFile(filepath.Join(dir, "motd.txt"), []byte(content), 0o600)).To(Succeed())
+		_, err := worktree.Add("motd.txt")
+		Expect(err).NotTo(HaveOccurred())
+		hash, err := worktree.Commit("Update the message", &git.CommitOptions{
+			Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return hash
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		var err error
+		repo, err = git.PlainInitWithOptions(dir, &git.PlainInitOptions{
+			InitOptions: git.InitOptions{DefaultBranch: plumbing.NewBranchReferenceName("main")},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		worktree, err = repo.Worktree()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should read the file at the commit of the ref and reuse it while unchanged", func() {
+		first := commit("Hello from Git")
+		_, err := repo.CreateTag("v1", first, nil)
+		Expect(err).NotTo(HaveOccurred())
+		second := commit("Hello again from Git")
+
+		simple := &demov2.Simple{Spec: demov2.SimpleSpec{GitSource: &demov2.GitSource{URL: dir, Path: "motd.txt"}}}
+		r := &SimpleReconciler{}
+		ctx := context.Background()
+
+		By("Following the default branch")
+		status, err := r.syncGitSource(ctx, simple, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Commit).To(Equal(second.String()))
+		Expect(status.Message).To(Equal("Hello again from Git"))
+
+		By("Reusing the previous content when the commit didn't change")
+		previous := status.DeepCopy()
+		previous.Message = "cached"
+		status, err = r.syncGitSource(ctx, simple, previous)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Message).To(Equal("cached"))
+
+		By("Checking out a tag or a commit")
+		simple.Spec.GitSource.Ref = "v1"
+		status, err = r.syncGitSource(ctx, simple, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Commit).To(Equal(first.String()))
+		Expect(status.Message).To(Equal("Hello from Git"))
+
+		simple.Spec.GitSource.Ref = first.String()
+		status, err = r.syncGitSource(ctx, simple, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Message).To(Equal("Hello from Git"))
+
+		By("Reporting missing refs and files")
+		simple.Spec.GitSource.Ref = "missing"
+		_, err = r.syncGitSource(ctx, simple, nil)
+		Expect(err).To(MatchError(ContainSubstring(`no ref "missing"`)))
+
+		simple.Spec.GitSource.Ref = ""
+		simple.Spec.GitSource.Path = "missing.txt"
+		_, err = r.syncGitSource(ctx, simple, nil)
+		Expect(err).To(MatchError(errInvalidSpec))
+	})
+
+	It("should sync again once the interval elapsed", func() {
+		simple := &demov2.Simple{Spec: demov2.SimpleSpec{GitSource: &demov2.GitSource{
+			URL: dir, Path: "motd.txt", Interval: &metav1.Duration{Duration: time.Minute},
+		}}}
+		now := time.Now()
+		remaining, ok := gitSourceSyncRemaining(simple, now)
+		Expect(ok).To(BeTrue())
+		Expect(remaining).To(BeZero())
+
+		simple.Status.GitSource = &demov2.GitSourceStatus{URL: dir, Path: "motd.txt", LastSyncTime: metav1.NewTime(now)}
+		remaining, _ = gitSourceSyncRemaining(simple, now)
+		Expect(remaining).To(Equal(time.Minute))
+
+		simple.Spec.GitSource.Path = "other.txt"
+		remaining, _ = gitSourceSyncRemaining(simple, now)
+		Expect(remaining).To(BeZero())
+	})
+})
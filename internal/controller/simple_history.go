@@ -0,0 +1,97 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// defaultHistoryLimit is used when Spec.HistoryLimit is unset
+const defaultHistoryLimit = 10
+
+// messagesHash returns the SHA-256 of the messages in the status of simple.
+func messagesHash(simple *demov2.Simple) string {
+	hash := sha256.New()
+	for _, message := range simple.Status.Messages {
+		hash.Write([]byte(message.Name))
+		hash.Write([]byte{0})
+		hash.Write([]byte(message.Message))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// specHash returns the SHA-256 of the fields of the spec of simple making up
+// its messages.
+func specHash(simple *demov2.Simple) (string, error) {
+	spec := simple.Spec
+	data, err := json.Marshal(demov2.SimpleSpec{
+		Message:          spec.Message,
+		MessageFrom:      spec.MessageFrom,
+		MessageURL:       spec.MessageURL,
+		GitSource:        spec.GitSource,
+		EncryptedMessage: spec.EncryptedMessage,
+		MessageKey:       spec.MessageKey,
+		Locale:           spec.Locale,
+		Catalog:          spec.Catalog,
+		Messages:         spec.Messages,
+		Payload:          spec.Payload,
+		Format:           spec.Format,
+	})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// recordHistory appends an entry to Status.History and drops the oldest
+// entries beyond the history limit. A failure identical to the last entry is
+// not recorded again, so retries don't rewrite the status.
+func recordHistory(simple *demov2.Simple, outcome, message string) {
+	entry := demov2.HistoryEntry{
+		Time:        metav1.Now(),
+		MessageHash: messagesHash(simple),
+		Outcome:     outcome,
+		Message:     message,
+	}
+
+	history := simple.Status.History
+	if n := len(history); n > 0 && outcome == demov2.HistoryOutcomeFailed {
+		last := &history[n-1]
+		if last.Outcome == entry.Outcome && last.MessageHash == entry.MessageHash && last.Message == entry.Message {
+			return
+		}
+	}
+	history = append(history, entry)
+
+	limit := int(ptr.Deref(simple.Spec.HistoryLimit, defaultHistoryLimit))
+	if len(history) > limit {
+		history = history[len(history)-limit:]
+	}
+	if len(history) == 0 {
+		history = nil
+	}
+	simple.Status.History = history
+}
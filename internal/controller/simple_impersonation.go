@@ -0,0 +1,77 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// serviceAccountUser returns the user name the API server authenticates the
+// ServiceAccount name of namespace as.
+func serviceAccountUser(namespace, name string) string {
+	return "system:serviceaccount:" + namespace + ":" + name
+}
+
+// childClient returns the client the children of simple are written with.
+// With Spec.ServiceAccountName it impersonates that ServiceAccount, so a
+// Simple can't be used to create objects its ServiceAccount isn't allowed to;
+// otherwise it is the client of the reconciler.
+func (r *SimpleReconciler) childClient(simple *demov2.Simple) (client.Client, error) {
+	name := simple.Spec.ServiceAccountName
+	if name == "" {
+		if r.RequireServiceAccount {
+			return nil, fmt.Errorf("%w: spec.serviceAccountName is required", errInvalidSpec)
+		}
+		return r.Client, nil
+	}
+	if r.RESTConfig == nil {
+		return nil, fmt.Errorf("%w: spec.serviceAccountName: impersonation isn't enabled", errInvalidSpec)
+	}
+
+	user := serviceAccountUser(simple.Namespace, name)
+	if c, ok := r.impersonatingClients.Load(user); ok {
+		return c.(client.Client), nil
+	}
+	config := rest.CopyConfig(r.RESTConfig)
+	config.Impersonate = rest.ImpersonationConfig{UserName: user}
+	c, err := client.New(config, client.Options{Scheme: r.Scheme, Mapper: r.RESTMapper()})
+	if err != nil {
+		return nil, fmt.Errorf("impersonating ServiceAccount %s: %w", name, err)
+	}
+	c = client.WithFieldOwner(c, r.fieldManager())
+	if r.DryRun != nil {
+		c = NewDryRunClient(c, r.DryRun)
+	}
+	r.impersonatingClients.Store(user, c)
+	return c, nil
+}
+
+// deleteClient returns the client the children of simple are deleted with:
+// the childClient of a Simple with Spec.ServiceAccountName, so its
+// ServiceAccount may only delete what it may write, otherwise the client of
+// the reconciler, which wrote them.
+func (r *SimpleReconciler) deleteClient(simple *demov2.Simple) (client.Client, error) {
+	if simple.Spec.ServiceAccountName == "" {
+		return r.Client, nil
+	}
+	return r.childClient(simple)
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+var _ = Describe("Simple impersonation", func() {
+	var (
+		r      *SimpleReconciler
+		simple *demov2.Simple
+	)
+
+	BeforeEach(func() {
+		r = &SimpleReconciler{Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(), Scheme: scheme.Scheme}
+		simple = &demov2.Simple{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+	})
+
+	It("should write the children with the operator's client without a ServiceAccount", func() {
+		Expect(r.childClient(simple)).To(BeIdenticalTo(r.Client))
+
+		r.RequireServiceAccount = true
+		_, err := r.childClient(simple)
+		Expect(err).To(MatchError(errInvalidSpec))
+		Expect(err).To(MatchError(ContainSubstring("spec.serviceAccountName is required")))
+	})
+
+	It("should impersonate the ServiceAccount of the Simple", func() {
+		simple.Spec.ServiceAccountName = "tenant"
+		_, err := r.childClient(simple)
+		Expect(err).To(MatchError(ContainSubstring("impersonation isn't enabled")))
+
+		r.RESTConfig = &rest.Config{Host: "https://127.0.0.1:6443"}
+		c, err := r.childClient(simple)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c).NotTo(BeIdenticalTo(r.Client))
+		Expect(r.childClient(simple)).To(BeIdenticalTo(c))
+		cached, _ := r.impersonatingClients.Load("system:serviceaccount:default:tenant")
+		Expect(cached).To(BeIdenticalTo(c))
+	})
+
+	It("should delete the children with the client they are written with", func() {
+		r.RequireServiceAccount = true
+		Expect(r.deleteClient(simple)).To(BeIdenticalTo(r.Client))
+
+		simple.Spec.ServiceAccountName = "tenant"
+		r.RESTConfig = &rest.Config{Host: "https://127.0.0.1:6443"}
+		c, err := r.deleteClient(simple)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c).NotTo(BeIdenticalTo(r.Client))
+		Expect(r.childClient(simple)).To(BeIdenticalTo(c))
+	})
+
+	It("should annotate the Pods as the ServiceAccount of the Simple", func() {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{"app": "web"}}}
+		Expect(r.Create(ctx, pod)).To(Succeed())
+		simple.Spec.ServiceAccountName = "tenant"
+		simple.Spec.PodTarget = &demov2.PodTargetSpec{
+			Selector:      metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			AnnotationKey: "example.com/message",
+		}
+		r.RESTConfig = &rest.Config{Host: "https://127.0.0.1:6443"}
+		// The ServiceAccount may not patch Pods
+		denied := interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch,
+				opts ...client.PatchOption) error {
+				return apierrors.NewForbidden(corev1.Resource("pods"), obj.GetName(), errors.New("RBAC: access denied"))
+			},
+		})
+		r.impersonatingClients.Store(serviceAccountUser("default", "tenant"), denied)
+
+		Expect(r.reconcilePods(ctx, simple, "Hello")).To(Satisfy(apierrors.IsForbidden))
+		Expect(simple.Status.AnnotatedPods).To(BeZero())
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(pod), pod)).To(Succeed())
+		Expect(pod.Annotations).NotTo(HaveKey("example.com/message"))
+	})
+})
@@ -0,0 +1,76 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"github.com/go-logr/logr"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// simpleLogger returns the logger of a reconciliation of simple, keyed by
+// its generation in addition to the namespace and name set by
+// controller-runtime, and honoring Spec.LogLevel.
+func simpleLogger(logger logr.Logger, simple *demov2.Simple) logr.Logger {
+	logger = logger.WithValues("generation", simple.Generation)
+	switch simple.Spec.LogLevel {
+	case demov2.LogLevelDebug, demov2.LogLevelError:
+		sink := logger.GetSink()
+		if sink == nil {
+			return logger
+		}
+		if withDepth, ok := sink.(logr.CallDepthLogSink); ok {
+			sink = withDepth.WithCallDepth(1)
+		}
+		return logger.WithSink(levelSink{LogSink: sink, level: simple.Spec.LogLevel})
+	default:
+		return logger
+	}
+}
+
+// levelSink overrides the level of the info logs of a Simple. Debug logs
+// them all at the info level, Error drops them. Errors are always logged.
+type levelSink struct {
+	logr.LogSink
+	level demov2.LogLevel
+}
+
+func (s levelSink) Enabled(level int) bool {
+	if s.level == demov2.LogLevelError {
+		return false
+	}
+	return s.LogSink.Enabled(0)
+}
+
+func (s levelSink) Info(_ int, msg string, keysAndValues ...any) {
+	s.LogSink.Info(0, msg, keysAndValues...)
+}
+
+func (s levelSink) WithValues(keysAndValues ...any) logr.LogSink {
+	return levelSink{LogSink: s.LogSink.WithValues(keysAndValues...), level: s.level}
+}
+
+func (s levelSink) WithName(name string) logr.LogSink {
+	return levelSink{LogSink: s.LogSink.WithName(name), level: s.level}
+}
+
+func (s levelSink) WithCallDepth(depth int) logr.LogSink {
+	if withDepth, ok := s.LogSink.(logr.CallDepthLogSink); ok {
+		return levelSink{LogSink: withDepth.WithCallDepth(depth), level: s.level}
+	}
+	return s
+}
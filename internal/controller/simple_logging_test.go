@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+
+	"github.com/go-logr/logr/funcr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+var _ = Describe("Simple logger", func() {
+	var lines []string
+	log := func(level demov2.LogLevel) {
+		lines = nil
+		logger := funcr.New(func(prefix, args string) {
+			lines = append(lines, args)
+		}, funcr.Options{Verbosity: 0})
+		simple := &demov2.Simple{
+			ObjectMeta: metav1.ObjectMeta{Name: "greeter", Generation: 3},
+			Spec:       demov2.SimpleSpec{LogLevel: level},
+		}
+		logger = simpleLogger(logger, simple)
+		logger.Info("delivered")
+		logger.V(1).Info("details")
+		logger.Error(errors.New("boom"), "failed")
+	}
+
+	It("should follow the level of the operator by default", func() {
+		log("")
+		Expect(lines).To(HaveLen(2))
+		Expect(lines[0]).To(ContainSubstring(`"msg"="delivered" "generation"=3`))
+		Expect(lines[1]).To(ContainSubstring(`"msg"="failed"`))
+	})
+
+	It("should log the details of Simples at the Debug level", func() {
+		log(demov2.LogLevelDebug)
+		Expect(lines).To(HaveLen(3))
+		Expect(lines[1]).To(ContainSubstring(`"msg"="details"`))
+	})
+
+	It("should only log the failures of Simples at the Error level", func() {
+		log(demov2.LogLevelError)
+		Expect(lines).To(HaveLen(1))
+		Expect(lines[0]).To(ContainSubstring(`"msg"="failed"`))
+	})
+})
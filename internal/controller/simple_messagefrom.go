@@ -0,0 +1,143 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// Field indexes listing the Simples that load their message from a source
+const (
+	messageFromConfigMapIndex = ".spec.messageFrom.configMapKeyRef.name"
+	messageFromSecretIndex    = ".spec.messageFrom.secretKeyRef.name"
+)
+
+// setupMessageFromIndexes registers the indexes used to find the Simples
+// referencing a ConfigMap or Secret.
+func setupMessageFromIndexes(ctx context.Context, mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &demov2.Simple{}, messageFromConfigMapIndex,
+		func(obj client.Object) []string {
+			from := obj.(*demov2.Simple).Spec.MessageFrom
+			if from == nil || from.ConfigMapKeyRef == nil {
+				return nil
+			}
+			return []string{from.ConfigMapKeyRef.Name}
+		}); err != nil {
+		return err
+	}
+
+	return mgr.GetFieldIndexer().IndexField(ctx, &demov2.Simple{}, messageFromSecretIndex,
+		func(obj client.Object) []string {
+			from := obj.(*demov2.Simple).Spec.MessageFrom
+			if from == nil || from.SecretKeyRef == nil {
+				return nil
+			}
+			return []string{from.SecretKeyRef.Name}
+		})
+}
+
+// simplesForConfigMap maps a ConfigMap to the Simples loading their message or
+// message catalog from it, or to the Simple it is a replica of.
+func (r *SimpleReconciler) simplesForConfigMap(ctx context.Context, obj client.Object) []reconcile.Request {
+	requests := r.simplesReferencing(ctx, obj, messageFromConfigMapIndex)
+	requests = append(requests, r.simplesReferencing(ctx, obj, messageCatalogIndex)...)
+	return append(requests, r.simpleForReplica(ctx, obj)...)
+}
+
+// simplesForSecret maps a Secret to the Simples loading their message, the key
+// decrypting it or the kubeconfig of a cluster from it, or to the Simple it is
+// a replica of.
+func (r *SimpleReconciler) simplesForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	requests := r.simplesReferencing(ctx, obj, messageFromSecretIndex)
+	requests = append(requests, r.simplesReferencing(ctx, obj, kubeconfigSecretIndex)...)
+	requests = append(requests, r.simplesReferencing(ctx, obj, encryptedMessageSecretIndex)...)
+	return append(requests, r.simpleForReplica(ctx, obj)...)
+}
+
+func (r *SimpleReconciler) simplesReferencing(ctx context.Context, obj client.Object, index string) []reconcile.Request {
+	var simples demov2.SimpleList
+	if err := r.List(ctx, &simples, client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{index: obj.GetName()}); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list Simples referencing source", "name", obj.GetName())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(simples.Items))
+	for _, simple := range simples.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: simple.Name, Namespace: simple.Namespace},
+		})
+	}
+	return requests
+}
+
+// resolveMessageFrom loads the message referenced by Spec.MessageFrom. It
+// returns an empty string when the reference is optional and missing.
+func (r *SimpleReconciler) resolveMessageFrom(ctx context.Context, simple *demov2.Simple) (string, error) {
+	from := simple.Spec.MessageFrom
+	switch {
+	case from.ConfigMapKeyRef != nil:
+		ref := from.ConfigMapKeyRef
+		var cm corev1.ConfigMap
+		err := r.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: simple.Namespace}, &cm)
+		if err != nil {
+			if apierrors.IsNotFound(err) && isOptional(ref.Optional) {
+				return "", nil
+			}
+			return "", fmt.Errorf("loading message from ConfigMap %s: %w", ref.Name, err)
+		}
+		value, ok := cm.Data[ref.Key]
+		if !ok && !isOptional(ref.Optional) {
+			return "", fmt.Errorf("ConfigMap %s has no key %q", ref.Name, ref.Key)
+		}
+		return value, nil
+
+	case from.SecretKeyRef != nil:
+		ref := from.SecretKeyRef
+		var secret corev1.Secret
+		err := r.liveReader().Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: simple.Namespace}, &secret)
+		if err != nil {
+			if apierrors.IsNotFound(err) && isOptional(ref.Optional) {
+				return "", nil
+			}
+			return "", fmt.Errorf("loading message from Secret %s: %w", ref.Name, err)
+		}
+		value, ok := secret.Data[ref.Key]
+		if !ok && !isOptional(ref.Optional) {
+			return "", fmt.Errorf("secret %s has no key %q", ref.Name, ref.Key)
+		}
+		return string(value), nil
+
+	default:
+		return "", fmt.Errorf("%w: messageFrom must set configMapKeyRef or secretKeyRef", errInvalidSpec)
+	}
+}
+
+func isOptional(optional *bool) bool {
+	return optional != nil && *optional
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+const (
+	// defaultMessageURLPollInterval is used when Spec.MessageURL.PollInterval is unset
+	defaultMessageURLPollInterval = 5 * time.Minute
+	// maxFetchedMessageSize bounds the messages of Spec.MessageURL and
+	// Spec.GitSource, which are kept in the status
+	maxFetchedMessageSize = 64 << 10
+)
+
+// messageURLPollRemaining returns the time until Spec.MessageURL is fetched again.
+func messageURLPollRemaining(simple *demov2.Simple, now time.Time) (remaining time.Duration, ok bool) {
+	source, status := simple.Spec.MessageURL, simple.Status.MessageURL
+	if source == nil {
+		return 0, false
+	}
+	if status == nil || status.URL != source.URL {
+		return 0, true
+	}
+	interval := defaultMessageURLPollInterval
+	if source.PollInterval != nil && source.PollInterval.Duration > 0 {
+		interval = source.PollInterval.Duration
+	}
+	return status.LastFetchTime.Add(interval).Sub(now), true
+}
+
+// resolveMessageURL returns the message served at Spec.MessageURL. The URL
+// is fetched once its poll interval elapsed, with the ETag of the previous
+// response so unchanged content isn't downloaded again. When the fetch
+// fails the previous content is kept.
+func (r *SimpleReconciler) resolveMessageURL(ctx context.Context, simple *demov2.Simple) (string, error) {
+	source := simple.Spec.MessageURL
+	now := time.Now()
+	previous := simple.Status.MessageURL
+	if previous != nil && previous.URL != source.URL {
+		previous = nil
+	}
+	if remaining, _ := messageURLPollRemaining(simple, now); previous != nil && remaining > 0 {
+		return previous.Message, nil
+	}
+
+	status, err := r.fetchMessageURL(ctx, simple, previous)
+	if err != nil {
+		setCondition(simple, demov2.ConditionMessageFetched, metav1.ConditionFalse, demov2.ReasonFetchFailed, err.Error())
+		if previous == nil {
+			return "", err
+		}
+		// Keep delivering the previous content and try again on the next poll
+		previous.LastFetchTime = metav1.NewTime(now)
+		return previous.Message, nil
+	}
+	if previous != nil && status.ETag != "" && status.ETag == previous.ETag && status.Message == previous.Message {
+		setCondition(simple, demov2.ConditionMessageFetched, metav1.ConditionTrue,
+			demov2.ReasonNotModified, "The content of "+source.URL+" didn't change")
+	} else {
+		setCondition(simple, demov2.ConditionMessageFetched, metav1.ConditionTrue,
+			demov2.ReasonFetched, "Fetched "+source.URL)
+	}
+	simple.Status.MessageURL = status
+	return status.Message, nil
+}
+
+// fetchMessageURL GETs Spec.MessageURL. A 304 Not Modified answer to the
+// ETag of previous returns the previous content.
+func (r *SimpleReconciler) fetchMessageURL(ctx context.Context, simple *demov2.Simple,
+	previous *demov2.MessageURLStatus) (*demov2.MessageURLStatus, error) {
+	source := simple.Spec.MessageURL
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: messageURL: %w", errInvalidSpec, err)
+	}
+	if ref := source.AuthorizationSecretRef; ref != nil {
+		var secret corev1.Secret
+		if err := r.liveReader().Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: simple.Namespace}, &secret); err != nil {
+			return nil, fmt.Errorf("loading the authorization of messageURL from Secret %s: %w", ref.Name, err)
+		}
+		value, ok := secret.Data[ref.Key]
+		if !ok {
+			return nil, fmt.Errorf("secret %s has no key %q", ref.Name, ref.Key)
+		}
+		req.Header.Set("Authorization", string(value))
+	}
+	if previous != nil && previous.ETag != "" {
+		req.Header.Set("If-None-Match", previous.ETag)
+	}
+
+	resp, err := r.httpClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching messageURL: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	status := &demov2.MessageURLStatus{URL: source.URL, LastFetchTime: metav1.Now()}
+	switch {
+	case resp.StatusCode == http.StatusNotModified && previous != nil:
+		status.ETag = previous.ETag
+		status.Message = previous.Message
+		return status, nil
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return nil, fmt.Errorf("fetching messageURL: GET %s returned %s", source.URL, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxFetchedMessageSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("fetching messageURL: %w", err)
+	}
+	if len(body) > maxFetchedMessageSize {
+		return nil, fmt.Errorf("fetching messageURL: the response exceeds %d bytes", maxFetchedMessageSize)
+	}
+	status.ETag = resp.Header.Get("ETag")
+	status.Message = string(body)
+	return status, nil
+}
+
+// fetchMessage returns the message of Spec.MessageURL or Spec.GitSource, and
+// drops the cached state of the sources that are no longer set.
+func (r *SimpleReconciler) fetchMessage(ctx context.Context, simple *demov2.Simple) (string, error) {
+	if simple.Spec.MessageURL == nil {
+		simple.Status.MessageURL = nil
+	}
+	if simple.Spec.GitSource == nil {
+		simple.Status.GitSource = nil
+	}
+	switch {
+	case simple.Spec.MessageURL != nil:
+		return r.resolveMessageURL(ctx, simple)
+	case simple.Spec.GitSource != nil:
+		return r.resolveGitSource(ctx, simple)
+	case simple.Spec.EncryptedMessage != nil:
+		meta.RemoveStatusCondition(&simple.Status.Conditions, demov2.ConditionMessageFetched)
+		return r.decryptMessage(ctx, simple)
+	default:
+		meta.RemoveStatusCondition(&simple.Status.Conditions, demov2.ConditionMessageFetched)
+		return "", nil
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+var _ = Describe("Simple metrics", func() {
+	var (
+		simple   *demov2.Simple
+		r        *SimpleReconciler
+		applyErr error
+	)
+
+	BeforeEach(func() {
+		applyErr = nil
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(demov2.AddToScheme(scheme)).To(Succeed())
+		simple = &demov2.Simple{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "metrics"},
+			Spec:       demov2.SimpleSpec{Messages: []demov2.MessageSpec{{Text: "Hello"}, {Text: "World!"}}},
+		}
+		// The fake client doesn't support server-side apply, the children are dropped instead
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(simple).WithStatusSubresource(simple).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch,
+					opts ...client.PatchOption) error {
+					if patch == client.Apply {
+						return applyErr
+					}
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+			}).Build()
+		r = &SimpleReconciler{Client: c, Scheme: scheme}
+		DeferCleanup(phases.forget, client.ObjectKeyFromObject(simple))
+	})
+
+	messageLengths := func() (count uint64, sum float64) {
+		var metric dto.Metric
+		Expect(messageLengthBytes.Write(&metric)).To(Succeed())
+		return metric.GetHistogram().GetSampleCount(), metric.GetHistogram().GetSampleSum()
+	}
+
+	It("should count the delivered messages and their length once", func() {
+		replies := testutil.ToFloat64(repliesTotal.WithLabelValues("metrics"))
+		failures := testutil.ToFloat64(reconcileErrorsTotal)
+		replied := testutil.ToFloat64(simplesByPhase.WithLabelValues(string(demov2.PhaseReplied)))
+		count, sum := messageLengths()
+
+		for range 2 {
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(simple)})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(testutil.ToFloat64(repliesTotal.WithLabelValues("metrics"))).To(Equal(replies + 2))
+		Expect(testutil.ToFloat64(reconcileErrorsTotal)).To(Equal(failures))
+		Expect(testutil.ToFloat64(simplesByPhase.WithLabelValues(string(demov2.PhaseReplied)))).To(Equal(replied + 1))
+		newCount, newSum := messageLengths()
+		Expect(newCount).To(Equal(count + 2))
+		Expect(newSum).To(Equal(sum + float64(len("Hello")+len("World!"))))
+
+		By("forgetting the phase of a deleted Simple")
+		phases.forget(client.ObjectKeyFromObject(simple))
+		Expect(testutil.ToFloat64(simplesByPhase.WithLabelValues(string(demov2.PhaseReplied)))).To(Equal(replied))
+	})
+
+	It("should count the failed reconciles without counting replies", func() {
+		applyErr = apierrors.NewForbidden(schema.GroupResource{Resource: "configmaps"}, "test-message", nil)
+		replies := testutil.ToFloat64(repliesTotal.WithLabelValues("metrics"))
+		failures := testutil.ToFloat64(reconcileErrorsTotal)
+		count, _ := messageLengths()
+
+		_, _ = r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(simple)})
+		Expect(testutil.ToFloat64(reconcileErrorsTotal)).To(Equal(failures + 1))
+		Expect(testutil.ToFloat64(repliesTotal.WithLabelValues("metrics"))).To(Equal(replies))
+		newCount, _ := messageLengths()
+		Expect(newCount).To(Equal(count))
+	})
+})
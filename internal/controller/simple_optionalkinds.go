@@ -0,0 +1,145 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// optionalKindsInterval is how often the CRDs of the optional kinds not
+// installed yet are looked up
+const optionalKindsInterval = 30 * time.Second
+
+// optionalKind is a kind of child whose CRD may not be installed. Its
+// children are only watched once it is, possibly after the operator started.
+type optionalKind struct {
+	gvk schema.GroupVersionKind
+	// uses tells whether a Simple has a child of the kind
+	uses func(simple *demov2.Simple) bool
+}
+
+// optionalKinds are the kinds of children owned once their CRD is installed
+var optionalKinds = []optionalKind{{
+	gvk: httpRouteGVK,
+	uses: func(simple *demov2.Simple) bool {
+		return simple.Spec.Echo != nil && simple.Spec.Expose != nil && simple.Spec.Expose.HTTPRoute != nil
+	},
+}}
+
+// watcher is the part of controller.Controller starting watches.
+type watcher interface {
+	Watch(src source.Source) error
+}
+
+// optionalWatches is a manager.Runnable waiting for the CRDs of optionalKinds
+// to be installed. It then watches the children of the kind, and requeues the
+// Simples using it through events, as they couldn't create their children.
+type optionalWatches struct {
+	client.Reader
+	Scheme     *runtime.Scheme
+	RESTMapper meta.RESTMapper
+	Cache      cache.Cache
+	Controller watcher
+	// Shard restricts the Simples requeued to the ones of the shard
+	Shard Shard
+	// Observer lowers the expectations of the children observed
+	Observer *expectations
+
+	kinds  []optionalKind
+	events chan event.GenericEvent
+}
+
+// Start implements manager.Runnable.
+func (w *optionalWatches) Start(ctx context.Context) error {
+	ticker := time.NewTicker(optionalKindsInterval)
+	defer ticker.Stop()
+	for {
+		w.watchInstalled(ctx)
+		if len(w.kinds) == 0 {
+			<-ctx.Done()
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// watchInstalled watches the kinds whose CRD is now installed and drops them
+// from w.kinds.
+func (w *optionalWatches) watchInstalled(ctx context.Context) {
+	log := log.FromContext(ctx)
+	pending := w.kinds[:0]
+	for _, kind := range w.kinds {
+		if _, err := w.RESTMapper.RESTMapping(kind.gvk.GroupKind(), kind.gvk.Version); err != nil {
+			if !meta.IsNoMatchError(err) {
+				log.Error(err, "unable to look up an optional kind", "kind", kind.gvk.String())
+			}
+			pending = append(pending, kind)
+			continue
+		}
+
+		obj := &metav1.PartialObjectMetadata{}
+		obj.SetGroupVersionKind(kind.gvk)
+		if err := w.Controller.Watch(source.Kind[client.Object](w.Cache, obj,
+			handler.EnqueueRequestForOwner(w.Scheme, w.RESTMapper, &demov2.Simple{}, handler.OnlyControllerOwner()),
+			w.Observer.observer(kind.gvk.Kind))); err != nil {
+			log.Error(err, "unable to watch an optional kind", "kind", kind.gvk.String())
+			pending = append(pending, kind)
+			continue
+		}
+		log.Info("Watching the children of an optional kind, its CRD is installed", "kind", kind.gvk.String())
+		w.requeueUsers(ctx, kind)
+	}
+	w.kinds = pending
+}
+
+// requeueUsers requeues the Simples with a child of kind.
+func (w *optionalWatches) requeueUsers(ctx context.Context, kind optionalKind) {
+	var simples demov2.SimpleList
+	if err := w.List(ctx, &simples); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list the Simples using an optional kind", "kind", kind.gvk.String())
+		return
+	}
+	for i := range simples.Items {
+		simple := &simples.Items[i]
+		if !kind.uses(simple) || !w.Shard.Owns(simple.Namespace, simple.Name) {
+			continue
+		}
+		select {
+		case w.events <- event.GenericEvent{Object: simple}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
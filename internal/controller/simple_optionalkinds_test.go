@@ -0,0 +1,84 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// recordingWatcher records the sources it is asked to watch.
+type recordingWatcher struct {
+	sources []source.Source
+}
+
+func (w *recordingWatcher) Watch(src source.Source) error {
+	w.sources = append(w.sources, src)
+	return nil
+}
+
+var _ = Describe("Simple optional kinds", func() {
+	It("should only watch a kind once its CRD is installed, and requeue its users", func() {
+		scheme := runtime.NewScheme()
+		Expect(demov2.AddToScheme(scheme)).To(Succeed())
+		routed := &demov2.Simple{
+			ObjectMeta: metav1.ObjectMeta{Name: "routed", Namespace: "default"},
+			Spec: demov2.SimpleSpec{
+				Messages: []demov2.MessageSpec{{Text: "Hello"}},
+				Echo:     &demov2.EchoSpec{},
+				Expose:   &demov2.ExposeSpec{HTTPRoute: &demov2.HTTPRouteSpec{GatewayName: "public"}},
+			},
+		}
+		plain := &demov2.Simple{
+			ObjectMeta: metav1.ObjectMeta{Name: "plain", Namespace: "default"},
+			Spec:       demov2.SimpleSpec{Messages: []demov2.MessageSpec{{Text: "Hello"}}},
+		}
+		mapper := meta.NewDefaultRESTMapper(nil)
+		controller := &recordingWatcher{}
+		w := &optionalWatches{
+			Reader:     fake.NewClientBuilder().WithScheme(scheme).WithObjects(routed, plain).Build(),
+			Scheme:     scheme,
+			RESTMapper: mapper,
+			Controller: controller,
+			Observer:   &expectations{},
+			kinds:      slices.Clone(optionalKinds),
+			events:     make(chan event.GenericEvent, 2),
+		}
+
+		w.watchInstalled(ctx)
+		Expect(controller.sources).To(BeEmpty())
+		Expect(w.kinds).To(HaveLen(1))
+
+		By("installing the Gateway API CRDs")
+		mapper.Add(httpRouteGVK, meta.RESTScopeNamespace)
+		w.watchInstalled(ctx)
+		Expect(controller.sources).To(HaveLen(1))
+		Expect(w.kinds).To(BeEmpty())
+		Expect(w.events).To(HaveLen(1))
+		Expect((<-w.events).Object.GetName()).To(Equal("routed"))
+	})
+})
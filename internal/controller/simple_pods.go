@@ -0,0 +1,105 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// reconcilePods writes message to the Spec.PodTarget annotation of the
+// selected Pods and counts them in Status.AnnotatedPods.
+func (r *SimpleReconciler) reconcilePods(ctx context.Context, simple *demov2.Simple, message string) error {
+	target := simple.Spec.PodTarget
+	if target == nil {
+		simple.Status.AnnotatedPods = 0
+		return nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(&target.Selector)
+	if err != nil {
+		return fmt.Errorf("%w: spec.podTarget.selector: %v", errInvalidSpec, err)
+	}
+	// The Pods are only watched and cached by their metadata
+	pods := &metav1.PartialObjectMetadataList{}
+	pods.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("PodList"))
+	if err := r.List(ctx, pods, client.InNamespace(simple.Namespace),
+		client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return fmt.Errorf("listing Pods: %w", err)
+	}
+	c, err := r.childClient(simple)
+	if err != nil {
+		return err
+	}
+
+	var annotated int32
+	var errs []error
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if pod.Annotations[target.AnnotationKey] != message {
+			patch := client.MergeFrom(pod.DeepCopy())
+			if pod.Annotations == nil {
+				pod.Annotations = map[string]string{}
+			}
+			pod.Annotations[target.AnnotationKey] = message
+			if err := c.Patch(ctx, pod, patch); err != nil {
+				if client.IgnoreNotFound(err) != nil {
+					errs = append(errs, fmt.Errorf("annotating Pod %s: %w", pod.Name, err))
+				}
+				continue
+			}
+			log.FromContext(ctx).V(1).Info("Annotated Pod", "pod", pod.Name, "annotation", target.AnnotationKey)
+		}
+		annotated++
+	}
+	simple.Status.AnnotatedPods = annotated
+	return errors.Join(errs...)
+}
+
+// simplesForPod enqueues the Simples whose Spec.PodTarget selects pod, so
+// that new Pods are annotated and edits of the annotation are reverted.
+func (r *SimpleReconciler) simplesForPod(ctx context.Context, obj client.Object) []reconcile.Request {
+	var list demov2.SimpleList
+	if err := r.List(ctx, &list, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list Simples")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, simple := range list.Items {
+		if simple.Spec.PodTarget == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(&simple.Spec.PodTarget.Selector)
+		if err != nil || !selector.Matches(labels.Set(obj.GetLabels())) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&simple)})
+	}
+	return requests
+}
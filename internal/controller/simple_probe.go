@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+	"github.com/leobip/demo-operator/internal/format"
+)
+
+const (
+	// endpointProbeTimeout bounds a probe of Status.URL, which holds up the
+	// reconciliation of the Simple
+	endpointProbeTimeout = 2 * time.Second
+	// endpointProbeInterval is how often Status.URL is probed again while it
+	// doesn't serve the messages
+	endpointProbeInterval = 10 * time.Second
+)
+
+// probeEndpoint checks that Status.URL of an exposed simple answers with the
+// text of messages, and reports the outcome in its EndpointUnavailable
+// condition. The condition is removed when the messages aren't exposed.
+func (r *SimpleReconciler) probeEndpoint(ctx context.Context, simple *demov2.Simple, messages []demov2.MessageSpec) {
+	if !r.ProbeEndpoints || r.DryRun != nil || simple.Status.URL == "" {
+		meta.RemoveStatusCondition(&simple.Status.Conditions, demov2.ConditionEndpointUnavailable)
+		return
+	}
+	if err := r.getEndpoint(ctx, simple.Status.URL, format.Text(simple, messages)); err != nil {
+		log.FromContext(ctx).Info("The endpoint doesn't serve the messages yet", "url", simple.Status.URL, "reason", err.Error())
+		setCondition(simple, demov2.ConditionEndpointUnavailable, metav1.ConditionTrue, demov2.ReasonProbeFailed, err.Error())
+		return
+	}
+	setCondition(simple, demov2.ConditionEndpointUnavailable, metav1.ConditionFalse, demov2.ReasonProbeSucceeded,
+		"The endpoint serves the messages")
+}
+
+// getEndpoint returns an error unless url answers with a 200 and text.
+func (r *SimpleReconciler) getEndpoint(ctx context.Context, url, text string) error {
+	ctx, cancel := context.WithTimeout(ctx, endpointProbeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := r.httpClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returned %s", url, resp.Status)
+	}
+	// The body is never reported, it may hold redacted messages
+	body, err := io.ReadAll(io.LimitReader(resp.Body, int64(len(text))+1024))
+	if err != nil {
+		return fmt.Errorf("reading the response of GET %s: %w", url, err)
+	}
+	if strings.TrimSpace(string(body)) != strings.TrimSpace(text) {
+		return fmt.Errorf("GET %s didn't return the current messages", url)
+	}
+	return nil
+}
+
+// waitingForEndpoint tells whether simple can't be Ready because it isn't
+// served at Status.URL yet.
+func waitingForEndpoint(simple *demov2.Simple) bool {
+	return meta.IsStatusConditionTrue(simple.Status.Conditions, demov2.ConditionEndpointUnavailable)
+}
+
+// setWaitingForEndpointConditions marks the Simple, whose messages were
+// delivered, as waiting for Status.URL to serve them.
+func setWaitingForEndpointConditions(simple *demov2.Simple) {
+	message := "Waiting for " + simple.Status.URL + " to serve the messages"
+	setCondition(simple, demov2.ConditionReady, metav1.ConditionFalse, demov2.ReasonEndpointUnavailable, message)
+	setCondition(simple, demov2.ConditionProgressing, metav1.ConditionTrue, demov2.ReasonEndpointUnavailable, message)
+	setCondition(simple, demov2.ConditionDegraded, metav1.ConditionFalse, demov2.ReasonEndpointUnavailable, message)
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+var _ = Describe("Simple endpoint probe", func() {
+	var (
+		r        *SimpleReconciler
+		simple   *demov2.Simple
+		messages []demov2.MessageSpec
+		body     string
+	)
+
+	BeforeEach(func() {
+		body = "Hello\n"
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(body))
+		}))
+		DeferCleanup(server.Close)
+		r = &SimpleReconciler{ProbeEndpoints: true}
+		simple = &demov2.Simple{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Status:     demov2.SimpleStatus{URL: server.URL},
+		}
+		messages = []demov2.MessageSpec{{Text: "Hello"}}
+	})
+
+	It("should only report the endpoint available when it serves the messages", func() {
+		r.probeEndpoint(ctx, simple, messages)
+		Expect(waitingForEndpoint(simple)).To(BeFalse())
+		Expect(meta.FindStatusCondition(simple.Status.Conditions, demov2.ConditionEndpointUnavailable)).To(
+			HaveField("Reason", demov2.ReasonProbeSucceeded))
+
+		body = "Stale message\n"
+		r.probeEndpoint(ctx, simple, messages)
+		Expect(waitingForEndpoint(simple)).To(BeTrue())
+		condition := meta.FindStatusCondition(simple.Status.Conditions, demov2.ConditionEndpointUnavailable)
+		Expect(condition.Reason).To(Equal(demov2.ReasonProbeFailed))
+		Expect(condition.Message).To(ContainSubstring("didn't return the current messages"))
+		Expect(condition.Message).NotTo(ContainSubstring("Stale"))
+
+		setWaitingForEndpointConditions(simple)
+		Expect(simplePhase(simple)).To(Equal(demov2.PhaseDelivering))
+	})
+
+	It("should give up on an endpoint slower than the probe timeout", func() {
+		slow := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+			select {
+			case <-req.Context().Done():
+			case <-time.After(2 * endpointProbeTimeout):
+			}
+		}))
+		DeferCleanup(slow.Close)
+		simple.Status.URL = slow.URL
+
+		start := time.Now()
+		r.probeEndpoint(ctx, simple, messages)
+		Expect(time.Since(start)).To(BeNumerically("<", endpointProbeTimeout+time.Second))
+		Expect(waitingForEndpoint(simple)).To(BeTrue())
+	})
+
+	It("should not probe when disabled or not exposed", func() {
+		setCondition(simple, demov2.ConditionEndpointUnavailable, metav1.ConditionTrue, demov2.ReasonProbeFailed, "")
+		r.ProbeEndpoints = false
+		r.probeEndpoint(ctx, simple, messages)
+		Expect(simple.Status.Conditions).To(BeEmpty())
+
+		r.ProbeEndpoints = true
+		simple.Status.URL = ""
+		r.probeEndpoint(ctx, simple, messages)
+		Expect(simple.Status.Conditions).To(BeEmpty())
+	})
+})
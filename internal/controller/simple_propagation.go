@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// unpropagatedPrefixes are the prefixes of the keys never copied onto the
+// children: those of the operator and of kubectl describe the Simple itself.
+var unpropagatedPrefixes = []string{"simple.example.com/", "kubectl.kubernetes.io/"}
+
+// selectKeys returns the entries of values whose key matches one of patterns,
+// nil if none does.
+func selectKeys(values map[string]string, patterns ...[]string) map[string]string {
+	var selected map[string]string
+	for key, value := range values {
+		if hasAnyPrefix(key, unpropagatedPrefixes) || !matchesAny(key, patterns...) {
+			continue
+		}
+		if selected == nil {
+			selected = map[string]string{}
+		}
+		selected[key] = value
+	}
+	return selected
+}
+
+func hasAnyPrefix(key string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAny tells whether key is one of patterns, or has the prefix of one ending with "*".
+func matchesAny(key string, patterns ...[]string) bool {
+	for _, list := range patterns {
+		for _, pattern := range list {
+			if key == pattern {
+				return true
+			}
+			if prefix, ok := strings.CutSuffix(pattern, "*"); ok && strings.HasPrefix(key, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// propagatedLabels returns the labels of simple copied onto its children.
+func (r *SimpleReconciler) propagatedLabels(simple *demov2.Simple) map[string]string {
+	var keys []string
+	if simple.Spec.Propagation != nil {
+		keys = simple.Spec.Propagation.Labels
+	}
+	return selectKeys(simple.Labels, r.DefaultChildLabels, keys)
+}
+
+// propagatedAnnotations returns the annotations of simple copied onto its children.
+func (r *SimpleReconciler) propagatedAnnotations(simple *demov2.Simple) map[string]string {
+	if simple.Spec.Propagation == nil {
+		return nil
+	}
+	return selectKeys(simple.Annotations, simple.Spec.Propagation.Annotations)
+}
+
+// propagate copies the propagated labels and annotations of simple onto obj,
+// leaving alone the ones obj sets itself. Applied children lose the ones
+// the Simple no longer has on their next apply.
+func (r *SimpleReconciler) propagate(simple *demov2.Simple, obj metav1.Object) {
+	if labels := mergeMissing(obj.GetLabels(), r.propagatedLabels(simple)); labels != nil {
+		obj.SetLabels(labels)
+	}
+	if annotations := mergeMissing(obj.GetAnnotations(), r.propagatedAnnotations(simple)); annotations != nil {
+		obj.SetAnnotations(annotations)
+	}
+}
+
+// mergeMissing adds the entries of extra missing from values, allocating
+// values if needed. It returns nil if both are empty.
+func mergeMissing(values, extra map[string]string) map[string]string {
+	if len(extra) == 0 {
+		return values
+	}
+	if values == nil {
+		values = make(map[string]string, len(extra))
+	}
+	for key, value := range extra {
+		if _, ok := values[key]; !ok {
+			values[key] = value
+		}
+	}
+	return values
+}
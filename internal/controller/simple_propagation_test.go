@@ -0,0 +1,66 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+var _ = Describe("Simple propagation", func() {
+	It("should copy the selected labels and annotations without overriding those of the child", func() {
+		r := &SimpleReconciler{DefaultChildLabels: []string{"team"}}
+		simple := &demov2.Simple{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{
+					"team":                           "payments",
+					"finops.example.com/cost-center": "4711",
+					"app":                            "greeter",
+					"tier":                           "backend",
+					"simple.example.com/owner-name":  "other",
+				},
+				Annotations: map[string]string{
+					"owner.example.com/contact":                        "payments@example.com",
+					"kubectl.kubernetes.io/last-applied-configuration": "{}",
+				},
+			},
+			Spec: demov2.SimpleSpec{Propagation: &demov2.PropagationSpec{
+				Labels:      []string{"finops.example.com/*", "app", "simple.example.com/*"},
+				Annotations: []string{"owner.example.com/contact", "kubectl.kubernetes.io/*"},
+			}},
+		}
+		child := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "echo"}}}
+
+		r.propagate(simple, child)
+		Expect(child.Labels).To(Equal(map[string]string{
+			"team":                           "payments",
+			"finops.example.com/cost-center": "4711",
+			"app":                            "echo",
+		}))
+		Expect(child.Annotations).To(Equal(map[string]string{"owner.example.com/contact": "payments@example.com"}))
+
+		simple.Spec.Propagation = nil
+		child = &corev1.ConfigMap{}
+		r.propagate(simple, child)
+		Expect(child.Labels).To(Equal(map[string]string{"team": "payments"}))
+		Expect(child.Annotations).To(BeNil())
+	})
+})
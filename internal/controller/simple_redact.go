@@ -0,0 +1,46 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// redactedText replaces the messages of a Simple with Spec.Redact in its
+// status and logs.
+const redactedText = "[REDACTED]"
+
+// statusMessage returns how text is reported in Status.Messages: as is, or
+// as its SHA-256 with Spec.Redact, which still tells when it changed.
+func statusMessage(simple *demov2.Simple, text string) string {
+	if !simple.Spec.Redacted() {
+		return text
+	}
+	sum := sha256.Sum256([]byte(text))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// loggedMessage returns how text is logged, see redactedText.
+func loggedMessage(simple *demov2.Simple, text string) string {
+	if simple.Spec.Redacted() {
+		return redactedText
+	}
+	return text
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// acknowledgeResend removes the ResendAnnotation of simple once the
+// re-delivery it asked for is recorded in Status.LastResend. A new value set
+// in the meantime makes the patch conflict and is handled on the next
+// reconciliation.
+func (r *SimpleReconciler) acknowledgeResend(ctx context.Context, simple *demov2.Simple) error {
+	resend, ok := simple.Annotations[demov2.ResendAnnotation]
+	if !ok || resend != simple.Status.LastResend {
+		return nil
+	}
+	patch := client.MergeFromWithOptions(simple.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	delete(simple.Annotations, demov2.ResendAnnotation)
+	return r.Patch(ctx, simple, patch)
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+var _ = Describe("Simple resend", func() {
+	It("should remove the resend annotation once the re-delivery is recorded", func() {
+		scheme := runtime.NewScheme()
+		Expect(demov2.AddToScheme(scheme)).To(Succeed())
+		simple := &demov2.Simple{ObjectMeta: metav1.ObjectMeta{
+			Name:        "test",
+			Namespace:   "default",
+			Annotations: map[string]string{demov2.ResendAnnotation: "2026-10-14T08:00:00Z"},
+		}}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(simple).Build()
+		r := &SimpleReconciler{Client: c, Scheme: scheme}
+
+		resend, ok := simple.ResendRequested()
+		Expect(ok).To(BeTrue())
+		Expect(resend).To(Equal("2026-10-14T08:00:00Z"))
+		Expect(r.acknowledgeResend(ctx, simple)).To(Succeed())
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(simple), simple)).To(Succeed())
+		Expect(simple.Annotations).To(HaveKey(demov2.ResendAnnotation))
+
+		simple.Status.LastResend = resend
+		_, ok = simple.ResendRequested()
+		Expect(ok).To(BeFalse())
+		Expect(r.acknowledgeResend(ctx, simple)).To(Succeed())
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(simple), simple)).To(Succeed())
+		Expect(simple.Annotations).NotTo(HaveKey(demov2.ResendAnnotation))
+	})
+})
@@ -0,0 +1,330 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// Limits of the Job log captured in Status.Output
+const (
+	runOutputTailLines = 20
+	runOutputMaxBytes  = 4096
+)
+
+// runJobName returns the name of the Job running the delivery of simple
+// counted by Status.ReplyCount. It is the same for every reconciliation of
+// that delivery, so one reading a stale cache doesn't create a second Job.
+// The name is kept within the 63 characters of the job-name label.
+func runJobName(simple *demov2.Simple) string {
+	sum := sha256.Sum256(fmt.Appendf(nil, "%s/%d/%s", simple.UID, simple.Status.ReplyCount, simple.Status.MessageHash))
+	prefix := simple.Name
+	if len(prefix) > 48 {
+		prefix = prefix[:48]
+	}
+	return prefix + "-run-" + hex.EncodeToString(sum[:])[:10]
+}
+
+// reconcileRun creates a Job running the messages when they were delivered,
+// tracks the last Job in the status and prunes finished Jobs beyond the
+// history limits.
+func (r *SimpleReconciler) reconcileRun(ctx context.Context, simple *demov2.Simple,
+	messages []demov2.MessageSpec, delivered bool) error {
+	run := simple.Spec.Run
+	if run == nil {
+		simple.Status.Run = nil
+		return nil
+	}
+
+	if delivered || simple.Status.Run == nil {
+		if err := r.createRunJob(ctx, simple, messages); err != nil {
+			return err
+		}
+	}
+
+	if err := r.trackRunJob(ctx, simple); err != nil {
+		return err
+	}
+	return r.pruneRunJobs(ctx, simple)
+}
+
+// createRunJob creates the Job for the current messages and records it as the last Job.
+func (r *SimpleReconciler) createRunJob(ctx context.Context, simple *demov2.Simple,
+	messages []demov2.MessageSpec) error {
+	run := simple.Spec.Run
+	command := make([]string, 0, len(run.Command))
+	for i, arg := range run.Command {
+		rendered, err := r.render(ctx, simple, fmt.Sprintf("command[%d]", i), arg)
+		if err != nil {
+			return err
+		}
+		command = append(command, rendered)
+	}
+	if len(command) == 0 {
+		texts := make([]string, 0, len(messages))
+		for _, message := range messages {
+			texts = append(texts, message.Text)
+		}
+		command = []string{"sh", "-c", strings.Join(texts, "\n")}
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      runJobName(simple),
+			Namespace: simple.Namespace,
+			Labels:    ownerLabels(simple),
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To[int32](0),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: simple.Spec.ServiceAccountName,
+					Containers: []corev1.Container{{
+						Name:    "run",
+						Image:   run.Image,
+						Command: command,
+					}},
+				},
+			},
+		},
+	}
+	podSpec := &job.Spec.Template.Spec
+	applyWorkload(run.WorkloadSpec, podSpec, &podSpec.Containers[0])
+	r.propagate(simple, job)
+	r.propagate(simple, &job.Spec.Template)
+	if err := controllerutil.SetControllerReference(simple, job, r.Scheme); err != nil {
+		return err
+	}
+	c, err := r.childClient(simple)
+	if err != nil {
+		return err
+	}
+	switch err := r.createJob(ctx, c, simple, job); {
+	case errors.Is(err, errJobPending):
+		// An earlier reconciliation created the Job, the cache hasn't observed it yet
+		if simple.Status.Run != nil && simple.Status.Run.JobName == job.Name {
+			return nil
+		}
+	case apierrors.IsAlreadyExists(err):
+		// An earlier reconciliation created the Job of this delivery
+		if err := r.liveReader().Get(ctx, client.ObjectKeyFromObject(job), job); err != nil {
+			return fmt.Errorf("getting Job %s: %w", job.Name, err)
+		}
+		if !metav1.IsControlledBy(job, simple) {
+			return fmt.Errorf("%w: Job %s already exists and isn't controlled by the Simple", errInvalidSpec, job.Name)
+		}
+		if simple.Status.Run != nil && simple.Status.Run.JobName == job.Name {
+			return nil
+		}
+	case apierrors.IsInvalid(err):
+		return fmt.Errorf("%w: run Job: %v", errInvalidSpec, err)
+	case err != nil:
+		return fmt.Errorf("creating Job: %w", err)
+	default:
+		r.event(simple, corev1.EventTypeNormal, eventReasonChildCreated, "Created Job %s", job.Name)
+		log.FromContext(ctx).Info("Created run Job", "job", job.Name)
+	}
+
+	now := metav1.Now()
+	if !job.CreationTimestamp.IsZero() {
+		now = job.CreationTimestamp
+	}
+	simple.Status.Run = &demov2.RunStatus{JobName: job.Name, StartTime: &now, Result: demov2.RunResultRunning}
+	simple.Status.Output = ""
+	return nil
+}
+
+// errJobPending reports a Job created by an earlier reconciliation that the
+// cache hasn't observed yet
+var errJobPending = errors.New("job creation not observed yet")
+
+// createJob creates job for simple through c, unless its creation is still
+// expected, which is reported as errJobPending without a request.
+func (r *SimpleReconciler) createJob(ctx context.Context, c client.Client, simple *demov2.Simple,
+	job *batchv1.Job) error {
+	if r.expectations.isPending(simple.UID, opCreation, "Job", job) {
+		return errJobPending
+	}
+	expected := r.expect(simple, opCreation, "Job", job)
+	err := c.Create(ctx, job)
+	if err != nil && expected {
+		// No event will come for a Job that wasn't created
+		r.expectations.observed(simple.UID, opCreation, "Job", job)
+	}
+	return err
+}
+
+// trackRunJob updates the status from the last Job and captures its log once it finished.
+func (r *SimpleReconciler) trackRunJob(ctx context.Context, simple *demov2.Simple) error {
+	status := simple.Status.Run
+	if status.Result != demov2.RunResultRunning {
+		return nil
+	}
+
+	var job batchv1.Job
+	if err := r.Get(ctx, types.NamespacedName{Name: status.JobName, Namespace: simple.Namespace}, &job); err != nil {
+		if apierrors.IsNotFound(err) {
+			// The Job may not be in the cache yet
+			return nil
+		}
+		return err
+	}
+
+	finished, result := jobResult(&job)
+	if !finished {
+		return nil
+	}
+	status.Result = result
+	status.CompletionTime = job.Status.CompletionTime
+	if status.CompletionTime == nil {
+		now := metav1.Now()
+		status.CompletionTime = &now
+	}
+	simple.Status.Output = r.jobOutput(ctx, &job)
+	return nil
+}
+
+// jobResult reports whether job finished and with which RunStatus result.
+func jobResult(job *batchv1.Job) (bool, string) {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return true, demov2.RunResultSucceeded
+		case batchv1.JobFailed:
+			return true, demov2.RunResultFailed
+		}
+	}
+	return false, demov2.RunResultRunning
+}
+
+// jobOutput returns the tail of the log of the last pod of job. Failures are
+// logged and reported in the output, they don't fail the reconcile.
+func (r *SimpleReconciler) jobOutput(ctx context.Context, job *batchv1.Job) string {
+	log := log.FromContext(ctx)
+	if r.Clientset == nil {
+		return ""
+	}
+
+	// Pods are read directly rather than through a cluster-wide cache
+	pods, err := r.Clientset.CoreV1().Pods(job.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: batchv1.JobNameLabel + "=" + job.Name,
+	})
+	if err != nil || len(pods.Items) == 0 {
+		log.Info("No pod found to capture the Job output", "job", job.Name, "error", err)
+		return ""
+	}
+	sort.Slice(pods.Items, func(i, j int) bool {
+		return pods.Items[i].CreationTimestamp.After(pods.Items[j].CreationTimestamp.Time)
+	})
+
+	stream, err := r.Clientset.CoreV1().Pods(job.Namespace).GetLogs(pods.Items[0].Name, &corev1.PodLogOptions{
+		TailLines:  ptr.To[int64](runOutputTailLines),
+		LimitBytes: ptr.To[int64](runOutputMaxBytes),
+	}).Stream(ctx)
+	if err != nil {
+		log.Error(err, "unable to capture the Job output", "job", job.Name)
+		return fmt.Sprintf("unable to capture the output: %v", err)
+	}
+	defer func() { _ = stream.Close() }()
+
+	output, err := io.ReadAll(io.LimitReader(stream, runOutputMaxBytes))
+	if err != nil {
+		log.Error(err, "unable to read the Job output", "job", job.Name)
+	}
+	return string(output)
+}
+
+// pruneRunJobs deletes the oldest finished Jobs beyond the history limits.
+func (r *SimpleReconciler) pruneRunJobs(ctx context.Context, simple *demov2.Simple) error {
+	var jobs batchv1.JobList
+	if err := r.List(ctx, &jobs, client.InNamespace(simple.Namespace), client.MatchingLabels(ownerLabels(simple))); err != nil {
+		return fmt.Errorf("listing Jobs: %w", err)
+	}
+
+	var succeeded, failed []batchv1.Job
+	for _, job := range jobs.Items {
+		// The cache may still list a Job an earlier reconciliation deleted
+		if r.expectations.isPending(simple.UID, opDeletion, "Job", &job) {
+			continue
+		}
+		switch _, result := jobResult(&job); result {
+		case demov2.RunResultSucceeded:
+			succeeded = append(succeeded, job)
+		case demov2.RunResultFailed:
+			failed = append(failed, job)
+		}
+	}
+
+	run := simple.Spec.Run
+	toDelete := oldestBeyond(succeeded, ptr.Deref(run.SuccessfulJobsHistoryLimit, 3))
+	toDelete = append(toDelete, oldestBeyond(failed, ptr.Deref(run.FailedJobsHistoryLimit, 1))...)
+	if len(toDelete) == 0 {
+		return nil
+	}
+	c, err := r.deleteClient(simple)
+	if err != nil {
+		return err
+	}
+	for i := range toDelete {
+		job := &toDelete[i]
+		expected := r.expect(simple, opDeletion, "Job", job)
+		err := c.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if client.IgnoreNotFound(err) != nil {
+			// No event will come for a Job that wasn't deleted
+			if expected {
+				r.expectations.observed(simple.UID, opDeletion, "Job", job)
+			}
+			return fmt.Errorf("deleting Job %s: %w", job.Name, err)
+		}
+		log.FromContext(ctx).Info("Deleted finished run Job", "job", job.Name)
+	}
+	return nil
+}
+
+// oldestBeyond returns the oldest jobs exceeding limit.
+func oldestBeyond(jobs []batchv1.Job, limit int32) []batchv1.Job {
+	if len(jobs) <= int(limit) {
+		return nil
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].CreationTimestamp.Before(&jobs[j].CreationTimestamp)
+	})
+	return jobs[:len(jobs)-int(limit)]
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+var _ = Describe("Simple run Jobs", func() {
+	var (
+		r      *SimpleReconciler
+		simple *demov2.Simple
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(demov2.AddToScheme(scheme)).To(Succeed())
+		r = &SimpleReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).Build(), Scheme: scheme}
+		simple = &demov2.Simple{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: "simple-uid"},
+			Spec:       demov2.SimpleSpec{Run: &demov2.RunSpec{Image: "busybox"}},
+			Status:     demov2.SimpleStatus{ReplyCount: 1, MessageHash: "sha256:0123"},
+		}
+	})
+
+	jobs := func() []batchv1.Job {
+		var list batchv1.JobList
+		Expect(r.List(ctx, &list)).To(Succeed())
+		return list.Items
+	}
+
+	It("should create a single Job for each delivery", func() {
+		messages := []demov2.MessageSpec{{Text: "echo hello"}}
+		Expect(r.createRunJob(ctx, simple, messages)).To(Succeed())
+		Expect(jobs()).To(HaveLen(1))
+		name := simple.Status.Run.JobName
+		Expect(name).To(Equal(runJobName(simple)))
+
+		By("treating the Job created by a reconciliation that read a stale status as its own")
+		simple.Status.Run = nil
+		Expect(r.createRunJob(ctx, simple, messages)).To(Succeed())
+		Expect(jobs()).To(HaveLen(1))
+		Expect(simple.Status.Run).To(HaveField("JobName", name))
+		Expect(simple.Status.Run).To(HaveField("Result", demov2.RunResultRunning))
+
+		By("creating another Job for the next delivery")
+		simple.Status.ReplyCount++
+		Expect(r.createRunJob(ctx, simple, messages)).To(Succeed())
+		Expect(jobs()).To(HaveLen(2))
+		Expect(simple.Status.Run.JobName).NotTo(Equal(name))
+	})
+
+	It("should not take over a Job it doesn't control", func() {
+		job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: runJobName(simple), Namespace: "default"}}
+		Expect(r.Create(ctx, job)).To(Succeed())
+		Expect(r.createRunJob(ctx, simple, nil)).To(MatchError(errInvalidSpec))
+		Expect(simple.Status.Run).To(BeNil())
+	})
+
+	It("should keep the Job names within the label length", func() {
+		simple.Name = "a-very-long-simple-name-that-goes-well-beyond-the-sixty-three-characters"
+		Expect(len(runJobName(simple))).To(BeNumerically("<=", 63))
+		Expect(runJobName(simple)).To(HavePrefix(simple.Name[:48] + "-run-"))
+	})
+})
@@ -0,0 +1,49 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// replySchedule parses Spec.Schedule of simple. It returns nil when no
+// schedule is set.
+func replySchedule(simple *demov2.Simple) (cron.Schedule, error) {
+	if simple.Spec.Schedule == "" {
+		return nil, nil
+	}
+	schedule, err := cron.ParseStandard(simple.Spec.Schedule)
+	if err != nil {
+		return nil, fmt.Errorf("%w: schedule %q: %v", errInvalidSpec, simple.Spec.Schedule, err)
+	}
+	return schedule, nil
+}
+
+// missedDeadline reports whether the scheduled delivery in Status.NextReplyTime
+// is later than Spec.StartingDeadlineSeconds allows.
+func missedDeadline(simple *demov2.Simple, now time.Time) bool {
+	deadline := simple.Spec.StartingDeadlineSeconds
+	if deadline == nil || simple.Status.NextReplyTime == nil {
+		return false
+	}
+	return now.Sub(simple.Status.NextReplyTime.Time) > time.Duration(*deadline)*time.Second
+}
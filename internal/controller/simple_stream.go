@@ -0,0 +1,120 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+	"github.com/leobip/demo-operator/internal/auditstream"
+)
+
+// streamRecord returns the record of event on simple.
+func (r *SimpleReconciler) streamRecord(simple *demov2.Simple, event string) auditstream.Record {
+	record := auditstream.Record{
+		Time:            time.Now(),
+		Event:           event,
+		Namespace:       simple.Namespace,
+		Name:            simple.Name,
+		UID:             simple.UID,
+		Generation:      simple.Generation,
+		ResourceVersion: simple.ResourceVersion,
+		DryRun:          r.DryRun != nil,
+	}
+	if hash, err := specHash(simple); err == nil {
+		record.SpecHash = hash
+	}
+	return record
+}
+
+// streamDelivery emits the outcome of the delivery attempt of a
+// reconciliation, which failed with reconcileErr when it isn't nil.
+func (r *SimpleReconciler) streamDelivery(ctx context.Context, simple *demov2.Simple, original *demov2.SimpleStatus,
+	reconcileErr error) {
+	record := r.streamRecord(simple, auditstream.EventDelivery)
+	record.MessageHash = messagesHash(simple)
+	record.Outcome = demov2.HistoryOutcomeDelivered
+	if reconcileErr != nil {
+		record.Outcome = demov2.HistoryOutcomeFailed
+		record.Message = reconcileErr.Error()
+	}
+	record.Sinks = attemptedSinks(simple, original)
+	r.Stream.Emit(ctx, record)
+}
+
+// streamChanges emits the creations, deletions and updates of the Simples of
+// the shard seen by the informer until ctx is done. The status-only updates
+// are left out, the deliveries are streamed by the reconciliations.
+func (r *SimpleReconciler) streamChanges(ctx context.Context, informers cache.Informers) error {
+	informer, err := informers.GetInformer(ctx, &demov2.Simple{})
+	if err != nil {
+		return fmt.Errorf("getting the Simple informer: %w", err)
+	}
+	emit := func(obj any, event string) {
+		if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		simple, ok := obj.(*demov2.Simple)
+		if !ok || !r.Shard.Owns(simple.Namespace, simple.Name) {
+			return
+		}
+		r.Stream.Emit(ctx, r.streamRecord(simple, event))
+	}
+	registration, err := informer.AddEventHandler(toolscache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(obj any, isInInitialList bool) {
+			// The Simples existing when the leader started were reported by the previous one
+			if !isInInitialList {
+				emit(obj, auditstream.EventCreated)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj any) {
+			oldSimple, ok := oldObj.(*demov2.Simple)
+			newSimple, ok2 := newObj.(*demov2.Simple)
+			if ok && ok2 && !specOrMetadataChanged(oldSimple, newSimple) {
+				return
+			}
+			emit(newObj, auditstream.EventUpdated)
+		},
+		DeleteFunc: func(obj any) {
+			emit(obj, auditstream.EventDeleted)
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("watching the Simples for the audit stream: %w", err)
+	}
+	<-ctx.Done()
+	if err := informer.RemoveEventHandler(registration); err != nil {
+		log.FromContext(ctx).Error(err, "failed to stop watching the Simples for the audit stream")
+	}
+	return nil
+}
+
+// specOrMetadataChanged tells whether an update of a Simple changed more than its status.
+func specOrMetadataChanged(oldSimple, newSimple *demov2.Simple) bool {
+	return oldSimple.Generation != newSimple.Generation ||
+		!equality.Semantic.DeepEqual(oldSimple.Labels, newSimple.Labels) ||
+		!equality.Semantic.DeepEqual(oldSimple.Annotations, newSimple.Annotations) ||
+		!equality.Semantic.DeepEqual(oldSimple.Finalizers, newSimple.Finalizers) ||
+		!equality.Semantic.DeepEqual(oldSimple.DeletionTimestamp, newSimple.DeletionTimestamp)
+}
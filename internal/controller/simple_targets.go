@@ -0,0 +1,205 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// targetNamespaces returns the namespaces the message ConfigMap of simple is
+// replicated to, sorted. The namespace of simple and terminating namespaces
+// are left out.
+func (r *SimpleReconciler) targetNamespaces(ctx context.Context, simple *demov2.Simple) ([]string, error) {
+	targets := sets.New(simple.Spec.TargetNamespaces...)
+	if simple.Spec.NamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(simple.Spec.NamespaceSelector)
+		if err != nil {
+			return nil, fmt.Errorf("%w: spec.namespaceSelector: %v", errInvalidSpec, err)
+		}
+		var namespaces corev1.NamespaceList
+		if err := r.List(ctx, &namespaces, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, fmt.Errorf("listing namespaces: %w", err)
+		}
+		for _, ns := range namespaces.Items {
+			if ns.Status.Phase != corev1.NamespaceTerminating {
+				targets.Insert(ns.Name)
+			}
+		}
+	}
+	targets.Delete(simple.Namespace)
+	return sets.List(targets), nil
+}
+
+// reconcileTargets replicates the message ConfigMap of simple, with data, to
+// its target namespaces and deletes the copies of namespaces no longer
+// targeted or no longer accepting replicas. The copies can't be owned by the
+// Simple across namespaces: they carry its ownerLabels and are deleted by its
+// finalizer.
+func (r *SimpleReconciler) reconcileTargets(ctx context.Context, simple *demov2.Simple, data map[string]string) error {
+	targets, err := r.targetNamespaces(ctx, simple)
+	if err != nil {
+		return err
+	}
+
+	previous := map[string]demov2.NamespacePropagationStatus{}
+	for _, status := range simple.Status.Targets {
+		previous[status.Namespace] = status
+	}
+	statuses := make([]demov2.NamespacePropagationStatus, 0, len(targets))
+	accepting := make([]string, 0, len(targets))
+	var errs []error
+	for _, namespace := range targets {
+		status := previous[namespace]
+		status.Namespace = namespace
+		err := r.acceptsReplicas(ctx, namespace)
+		if err == nil {
+			accepting = append(accepting, namespace)
+			err = r.replicateConfigMap(ctx, simple, namespace, data)
+		}
+		if err != nil {
+			status.Propagated = false
+			status.LastError = err.Error()
+			errs = append(errs, err)
+		} else {
+			if !status.Propagated || simple.Status.ObservedGeneration != simple.Generation {
+				now := metav1.Now()
+				status.LastPropagatedTime = &now
+			}
+			status.Propagated = true
+			status.LastError = ""
+		}
+		statuses = append(statuses, status)
+	}
+	simple.Status.Targets = nil
+	if len(statuses) > 0 {
+		simple.Status.Targets = statuses
+	}
+
+	if err := r.pruneTargets(ctx, simple, accepting); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// acceptsReplicas returns an error unless namespace opted in to the replicas
+// of the Simples of other namespaces with the AcceptReplicasLabel, so that
+// creating a Simple doesn't allow writing to namespaces its author can't
+// write to.
+func (r *SimpleReconciler) acceptsReplicas(ctx context.Context, namespace string) error {
+	var ns corev1.Namespace
+	if err := r.Get(ctx, client.ObjectKey{Name: namespace}, &ns); err != nil {
+		return fmt.Errorf("namespace %s: %w", namespace, err)
+	}
+	if ns.Labels[demov2.AcceptReplicasLabel] != "true" {
+		return fmt.Errorf("%w: namespace %s doesn't accept replicas, label it with %s=true",
+			errInvalidSpec, namespace, demov2.AcceptReplicasLabel)
+	}
+	return nil
+}
+
+// replicateConfigMap applies the copy of the message ConfigMap, or Secret
+// with Spec.Output, of simple to namespace. An existing object that isn't a
+// copy of simple is only taken over as checkAdoption allows, without forcing
+// the fields other managers own.
+func (r *SimpleReconciler) replicateConfigMap(ctx context.Context, simple *demov2.Simple, namespace string,
+	data map[string]string) error {
+	kind := simple.Spec.OutputKind()
+	obj := messageObject(simple, kind, namespace, data)
+	obj.SetLabels(ownerLabels(simple))
+	r.propagate(simple, obj)
+	opts, err := r.adoptionPatchOptions(ctx, r.liveReader(), simple, obj, string(kind))
+	if err != nil {
+		return fmt.Errorf("namespace %s: %w", namespace, err)
+	}
+	c, err := r.childClient(simple)
+	if err != nil {
+		return err
+	}
+	if err := c.Patch(ctx, obj, client.Apply, opts...); err != nil {
+		return fmt.Errorf("namespace %s: applying %s %s: %w%s", namespace, kind, obj.GetName(), err,
+			forbiddenHint(err, string(kind)))
+	}
+	return nil
+}
+
+// pruneTargets deletes the copies of the message ConfigMap of simple outside
+// of targets, and the copies of the kind Spec.Output no longer asks for.
+func (r *SimpleReconciler) pruneTargets(ctx context.Context, simple *demov2.Simple, targets []string) error {
+	replicas, err := r.listForeignArtifacts(ctx, simple)
+	if err != nil {
+		return err
+	}
+	c, err := r.deleteClient(simple)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, replica := range replicas {
+		if replica.GetName() != messageConfigMapName(simple) ||
+			(replica.kind == simple.Spec.OutputKind() && slices.Contains(targets, replica.GetNamespace())) {
+			continue
+		}
+		if err := c.Delete(ctx, replica.Object); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("namespace %s: deleting %s %s: %w",
+				replica.GetNamespace(), replica.kind, replica.GetName(), err))
+			continue
+		}
+		log.FromContext(ctx).Info("Deleted replica of untargeted namespace", "namespace", replica.GetNamespace(),
+			"kind", replica.kind, "name", replica.GetName())
+	}
+	return errors.Join(errs...)
+}
+
+// simplesForNamespace enqueues the Simples replicating to namespaces, as a
+// created or relabeled namespace may start or stop matching their selector.
+func (r *SimpleReconciler) simplesForNamespace(ctx context.Context, obj client.Object) []reconcile.Request {
+	var list demov2.SimpleList
+	if err := r.List(ctx, &list); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list Simples")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, simple := range list.Items {
+		if simple.Spec.NamespaceSelector != nil || slices.Contains(simple.Spec.TargetNamespaces, obj.GetName()) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&simple)})
+		}
+	}
+	return requests
+}
+
+// simpleForReplica enqueues the Simple a replicated ConfigMap belongs to, so
+// that manual changes and deletions of the copies are reverted.
+func (r *SimpleReconciler) simpleForReplica(_ context.Context, obj client.Object) []reconcile.Request {
+	labels := obj.GetLabels()
+	name, namespace := labels[ownerNameLabel], labels[ownerNamespaceLabel]
+	if name == "" || namespace == "" || namespace == obj.GetNamespace() {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: client.ObjectKey{Namespace: namespace, Name: name}}}
+}
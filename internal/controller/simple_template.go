@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// errRenderFailed wraps reconcile errors caused by a message template
+var errRenderFailed = errors.New("render failed")
+
+// ClusterInfo describes the cluster the controller runs in. It is exposed to
+// message templates as .Cluster.
+type ClusterInfo struct {
+	// Name is the name the cluster was given with --cluster-name
+	Name string
+	// Version is the Kubernetes version of the API server
+	Version string
+}
+
+// templateData is the data message templates are rendered with.
+type templateData struct {
+	Name        string
+	Namespace   string
+	Labels      map[string]string
+	Annotations map[string]string
+	Cluster     ClusterInfo
+}
+
+// renderMessages returns messages with their texts rendered as Go templates.
+// Texts without template actions are returned unchanged.
+func (r *SimpleReconciler) renderMessages(ctx context.Context, simple *demov2.Simple,
+	messages []demov2.MessageSpec) ([]demov2.MessageSpec, error) {
+	rendered := make([]demov2.MessageSpec, 0, len(messages))
+	for i, message := range messages {
+		text, err := r.render(ctx, simple, fmt.Sprintf("message[%d]", i), message.Text)
+		if err != nil {
+			return nil, err
+		}
+		message.Text = text
+		rendered = append(rendered, message)
+	}
+	return rendered, nil
+}
+
+// render renders text as a Go template with the data of simple. Errors wrap
+// errRenderFailed.
+func (r *SimpleReconciler) render(ctx context.Context, simple *demov2.Simple, name, text string) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	data := templateData{
+		Name:        simple.Name,
+		Namespace:   simple.Namespace,
+		Labels:      simple.Labels,
+		Annotations: simple.Annotations,
+		Cluster:     r.ClusterInfo,
+	}
+	funcs := template.FuncMap{
+		"configMap": func(name, key string) (string, error) {
+			var cm corev1.ConfigMap
+			if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: simple.Namespace}, &cm); err != nil {
+				return "", err
+			}
+			value, ok := cm.Data[key]
+			if !ok {
+				return "", fmt.Errorf("ConfigMap %s has no key %q", name, key)
+			}
+			return value, nil
+		},
+	}
+
+	tmpl, err := template.New(name).Funcs(funcs).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", errRenderFailed, err)
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("%w: %v", errRenderFailed, err)
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// deliveryLimiters holds the token bucket of every Simple limiting how often
+// its edits are re-delivered. The zero value is ready to use.
+type deliveryLimiters struct {
+	mu       sync.Mutex
+	limiters map[types.NamespacedName]*rate.Limiter
+}
+
+// take takes a token of the Simple key, whose bucket holds perMinute tokens
+// refilled over a minute, and returns how long until one is available when
+// there is none. perMinute 0 doesn't limit the Simple.
+func (l *deliveryLimiters) take(key types.NamespacedName, perMinute int, now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if perMinute <= 0 {
+		delete(l.limiters, key)
+		return 0
+	}
+	limit := rate.Every(time.Minute / time.Duration(perMinute))
+	limiter, ok := l.limiters[key]
+	switch {
+	case !ok:
+		if l.limiters == nil {
+			l.limiters = map[types.NamespacedName]*rate.Limiter{}
+		}
+		limiter = rate.NewLimiter(limit, perMinute)
+		l.limiters[key] = limiter
+	case limiter.Limit() != limit:
+		limiter.SetLimitAt(now, limit)
+		limiter.SetBurstAt(now, perMinute)
+	}
+	reservation := limiter.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		// Only re-deliveries made take a token, the Simple is reconciled again anyway
+		reservation.CancelAt(now)
+		return delay
+	}
+	return 0
+}
+
+// forget drops the bucket of the deleted Simple key.
+func (l *deliveryLimiters) forget(key types.NamespacedName) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.limiters, key)
+}
+
+// deliveriesPerMinute returns how many times per minute the edits of simple
+// may be re-delivered, 0 when unlimited. Its MaxDeliveriesPerMinuteAnnotation
+// may only lower MaxDeliveriesPerMinute, so whoever edits the Simple can't
+// lift the limit of the operator.
+func (r *SimpleReconciler) deliveriesPerMinute(ctx context.Context, simple *demov2.Simple) int {
+	value, ok := simple.Annotations[demov2.MaxDeliveriesPerMinuteAnnotation]
+	if !ok {
+		return r.MaxDeliveriesPerMinute
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		log.FromContext(ctx).Info("Ignoring the invalid annotation",
+			"annotation", demov2.MaxDeliveriesPerMinuteAnnotation, "value", value)
+		return r.MaxDeliveriesPerMinute
+	}
+	if r.MaxDeliveriesPerMinute > 0 {
+		return min(n, r.MaxDeliveriesPerMinute)
+	}
+	return n
+}
+
+// throttleDelivery returns how long the re-delivery of the edited spec of
+// simple has to wait for its token bucket, 0 when it may be delivered now.
+// Only edits are limited, the first delivery and the ones of Spec.Interval
+// and Spec.Schedule aren't. The edits made while waiting are delivered
+// together, from the latest spec.
+func (r *SimpleReconciler) throttleDelivery(ctx context.Context, simple *demov2.Simple) (time.Duration, error) {
+	if simple.Status.SpecHash == "" {
+		return 0, nil
+	}
+	hash, err := specHash(simple)
+	if err != nil || hash == simple.Status.SpecHash {
+		return 0, err
+	}
+	return r.deliveryLimiters.take(client.ObjectKeyFromObject(simple), r.deliveriesPerMinute(ctx, simple), time.Now()), nil
+}
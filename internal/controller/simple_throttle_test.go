@@ -0,0 +1,91 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+var _ = Describe("Simple delivery throttling", func() {
+	key := types.NamespacedName{Namespace: "default", Name: "test"}
+
+	It("should let perMinute re-deliveries through per minute", func() {
+		var l deliveryLimiters
+		now := time.Now()
+		for range 3 {
+			Expect(l.take(key, 3, now)).To(BeZero())
+		}
+		delay := l.take(key, 3, now)
+		Expect(delay).To(BeNumerically("~", 20*time.Second, time.Second))
+
+		By("not taking a token for the postponed re-deliveries")
+		Expect(l.take(key, 3, now)).To(Equal(delay))
+		Expect(l.take(key, 3, now.Add(delay))).To(BeZero())
+
+		By("not limiting the Simples without a limit")
+		Expect(l.take(key, 0, now)).To(BeZero())
+		Expect(l.limiters).NotTo(HaveKey(key))
+	})
+
+	It("should only limit the edits of the Simples already delivered", func() {
+		r := &SimpleReconciler{MaxDeliveriesPerMinute: 1}
+		simple := &demov2.Simple{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec:       demov2.SimpleSpec{Messages: []demov2.MessageSpec{{Text: "first"}}},
+		}
+		Expect(r.throttleDelivery(ctx, simple)).To(BeZero())
+
+		hash, err := specHash(simple)
+		Expect(err).NotTo(HaveOccurred())
+		simple.Status.SpecHash = hash
+		Expect(r.throttleDelivery(ctx, simple)).To(BeZero())
+
+		simple.Spec.Messages[0].Text = "second"
+		Expect(r.throttleDelivery(ctx, simple)).To(BeZero())
+		simple.Spec.Messages[0].Text = "third"
+		Expect(r.throttleDelivery(ctx, simple)).To(BeNumerically(">", 0))
+
+		By("not letting the annotation lift the limit")
+		simple.Annotations = map[string]string{demov2.MaxDeliveriesPerMinuteAnnotation: "0"}
+		Expect(r.throttleDelivery(ctx, simple)).To(BeNumerically(">", 0))
+		simple.Annotations[demov2.MaxDeliveriesPerMinuteAnnotation] = "invalid"
+		Expect(r.deliveriesPerMinute(ctx, simple)).To(Equal(1))
+	})
+
+	It("should only let the annotation lower the limit", func() {
+		r := &SimpleReconciler{MaxDeliveriesPerMinute: 6}
+		simple := &demov2.Simple{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}}
+		for value, perMinute := range map[string]int{"2": 2, "6": 6, "60": 6, "0": 6, "-1": 6} {
+			simple.Annotations = map[string]string{demov2.MaxDeliveriesPerMinuteAnnotation: value}
+			Expect(r.deliveriesPerMinute(ctx, simple)).To(Equal(perMinute), "annotation %q", value)
+		}
+
+		By("limiting a Simple without a limit of the operator")
+		r.MaxDeliveriesPerMinute = 0
+		simple.Annotations[demov2.MaxDeliveriesPerMinuteAnnotation] = "60"
+		Expect(r.deliveriesPerMinute(ctx, simple)).To(Equal(60))
+		simple.Annotations[demov2.MaxDeliveriesPerMinuteAnnotation] = "0"
+		Expect(r.deliveriesPerMinute(ctx, simple)).To(BeZero())
+	})
+})
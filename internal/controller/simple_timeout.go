@@ -0,0 +1,85 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// errReconcileTimeout is wrapped by the errors of reconciliations aborted at their deadline
+var errReconcileTimeout = errors.New("reconcile timed out")
+
+// statusWriteTimeout bounds the status write ending a reconciliation once the
+// manager stopped
+const statusWriteTimeout = 10 * time.Second
+
+// drainContext returns a context outliving the cancellation of ctx, when the
+// manager stops, by DrainTimeout: the deliveries in flight get to finish
+// instead of being aborted midway.
+func (r *SimpleReconciler) drainContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if r.DrainTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	drainCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(ctx, func() {
+		time.AfterFunc(r.DrainTimeout, cancel)
+	})
+	return drainCtx, func() {
+		stop()
+		cancel()
+	}
+}
+
+// reconcileTimeout returns the deadline of a reconciliation of simple, or 0 if it has none.
+func (r *SimpleReconciler) reconcileTimeout(simple *demov2.Simple) time.Duration {
+	if simple.Spec.TimeoutSeconds != nil {
+		return time.Duration(*simple.Spec.TimeoutSeconds) * time.Second
+	}
+	return r.ReconcileTimeout
+}
+
+// replyWithTimeout runs reply within the deadline of simple. An error caused
+// by the deadline wraps errReconcileTimeout, and the ReconcileTimeout
+// condition reports whether it was hit.
+func (r *SimpleReconciler) replyWithTimeout(ctx context.Context, simple *demov2.Simple) error {
+	timeout := r.reconcileTimeout(simple)
+	if timeout <= 0 {
+		meta.RemoveStatusCondition(&simple.Status.Conditions, demov2.ConditionReconcileTimeout)
+		return r.reply(ctx, simple)
+	}
+
+	replyCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	err := r.reply(replyCtx, simple)
+	if err != nil && errors.Is(replyCtx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("%w after %s: %w", errReconcileTimeout, timeout, err)
+		setCondition(simple, demov2.ConditionReconcileTimeout, metav1.ConditionTrue,
+			demov2.ReasonTimedOut, err.Error())
+		return err
+	}
+	setCondition(simple, demov2.ConditionReconcileTimeout, metav1.ConditionFalse,
+		demov2.ReasonCompleted, fmt.Sprintf("Reconciliation completed within %s", timeout))
+	return err
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Drain context", func() {
+	It("should outlive the stop of the manager by the drain timeout", func() {
+		managerCtx, stop := context.WithCancel(context.Background())
+		r := &SimpleReconciler{DrainTimeout: 100 * time.Millisecond}
+		drainCtx, cancel := r.drainContext(managerCtx)
+		defer cancel()
+
+		stop()
+		Consistently(drainCtx.Done(), 50*time.Millisecond).ShouldNot(BeClosed())
+		Eventually(drainCtx.Done()).Should(BeClosed())
+	})
+
+	It("should be canceled with the manager without a drain timeout", func() {
+		managerCtx, stop := context.WithCancel(context.Background())
+		drainCtx, cancel := (&SimpleReconciler{}).drainContext(managerCtx)
+		defer cancel()
+
+		stop()
+		Expect(drainCtx.Done()).To(BeClosed())
+	})
+})
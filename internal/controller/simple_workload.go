@@ -0,0 +1,79 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// applyWorkload sets the resources of container and the scheduling of pod
+// from workload.
+func applyWorkload(workload demov2.WorkloadSpec, pod *corev1.PodSpec, container *corev1.Container) {
+	if workload.Resources != nil {
+		container.Resources = *workload.Resources
+	}
+	pod.NodeSelector = workload.NodeSelector
+	pod.Tolerations = workload.Tolerations
+	pod.Affinity = workload.Affinity
+	pod.PriorityClassName = workload.PriorityClassName
+	pod.TopologySpreadConstraints = workload.TopologySpreadConstraints
+}
+
+// setWorkloadReadyCondition aggregates the state of the echo Deployment and
+// of the last run Job of simple, as reported in its status, into its
+// WorkloadReady condition. The condition is removed when simple has no
+// workload. The status is refreshed by the events of the Deployment and the
+// Jobs, which requeue simple.
+func setWorkloadReadyCondition(simple *demov2.Simple) {
+	if simple.Spec.Echo == nil && simple.Spec.Run == nil {
+		meta.RemoveStatusCondition(&simple.Status.Conditions, demov2.ConditionWorkloadReady)
+		return
+	}
+
+	var notReady []string
+	if simple.Spec.Echo != nil {
+		desired := ptr.Deref(simple.Spec.Replicas, 1)
+		// Replicas above the desired count are old ones still terminating
+		if status := simple.Status; status.ReadyReplicas < desired || status.Replicas > desired {
+			notReady = append(notReady, fmt.Sprintf("%d/%d echo replicas ready", status.ReadyReplicas, desired))
+		}
+	}
+	if simple.Spec.Run != nil {
+		switch run := simple.Status.Run; {
+		case run == nil:
+			notReady = append(notReady, "no run Job created yet")
+		case run.Result != demov2.RunResultSucceeded:
+			notReady = append(notReady, fmt.Sprintf("run Job %s is %s", run.JobName, strings.ToLower(run.Result)))
+		}
+	}
+
+	if len(notReady) > 0 {
+		setCondition(simple, demov2.ConditionWorkloadReady, metav1.ConditionFalse, demov2.ReasonWorkloadNotReady,
+			strings.Join(notReady, ", "))
+		return
+	}
+	setCondition(simple, demov2.ConditionWorkloadReady, metav1.ConditionTrue, demov2.ReasonWorkloadReady,
+		"Every workload is ready")
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+var _ = Describe("Simple workload readiness", func() {
+	It("should aggregate the echo replicas and the last run Job", func() {
+		simple := &demov2.Simple{Spec: demov2.SimpleSpec{
+			Replicas: ptr.To[int32](2),
+			Echo:     &demov2.EchoSpec{Image: "hashicorp/http-echo:1.0", Port: 5678},
+			Run:      &demov2.RunSpec{Image: "busybox"},
+		}}
+		condition := func() *metav1.Condition {
+			setWorkloadReadyCondition(simple)
+			return meta.FindStatusCondition(simple.Status.Conditions, demov2.ConditionWorkloadReady)
+		}
+		Expect(condition()).To(HaveField("Message", "0/2 echo replicas ready, no run Job created yet"))
+
+		simple.Status.Replicas, simple.Status.ReadyReplicas = 3, 2
+		simple.Status.Run = &demov2.RunStatus{JobName: "test-run-abcde", Result: demov2.RunResultRunning}
+		Expect(condition()).To(HaveField("Message", "2/2 echo replicas ready, run Job test-run-abcde is running"))
+
+		simple.Status.Replicas = 2
+		simple.Status.Run.Result = demov2.RunResultSucceeded
+		Expect(condition()).To(HaveField("Status", metav1.ConditionTrue))
+
+		simple.Spec.Echo, simple.Spec.Run = nil, nil
+		Expect(condition()).To(BeNil())
+	})
+})
@@ -0,0 +1,237 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// digestConfigMapKey is the key of the digest ConfigMap holding the combined
+// report of every Simple, the other keys hold the messages of one Simple each
+const digestConfigMapKey = "digest"
+
+// SimpleDigestReconciler reconciles a SimpleDigest object
+type SimpleDigestReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// FieldManager is the field manager the ConfigMaps are applied with, defaults to defaultFieldManager
+	FieldManager string
+}
+
+// +kubebuilder:rbac:groups=demo.demo.local,resources=simpledigests,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=demo.demo.local,resources=simpledigests/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=demo.demo.local,resources=simpledigests/finalizers,verbs=update
+
+// Reconcile writes the delivered messages of the Simples selected by a
+// SimpleDigest to its ConfigMap. It runs again whenever a Simple of the
+// namespace changes, so the digest follows Simples as they come and go. The
+// ConfigMap is owned by the SimpleDigest and goes away with it.
+func (r *SimpleDigestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	// 1. Fetch the SimpleDigest instance
+	var digest demov2.SimpleDigest
+	if err := r.Get(ctx, req.NamespacedName, &digest); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !digest.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	base := digest.DeepCopy()
+	original := &base.Status
+
+	// 2. List the selected Simples and apply the digest ConfigMap
+	simples, err := r.simples(ctx, &digest)
+	if err == nil {
+		err = r.applyConfigMap(ctx, &digest, simples)
+	}
+	digest.Status.ConfigMapName = digestConfigMapName(&digest)
+	digest.Status.Simples = int32(len(simples))
+	digest.Status.Messages = 0
+	for _, simple := range simples {
+		for _, message := range simple.Status.Messages {
+			if message.Delivered {
+				digest.Status.Messages++
+			}
+		}
+	}
+
+	// 3. Record the outcome in the status conditions
+	condition := metav1.Condition{
+		Type:               demov2.ConditionReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             demov2.ReasonDigestUpdated,
+		Message:            fmt.Sprintf("%d messages of %d Simples", digest.Status.Messages, digest.Status.Simples),
+		ObservedGeneration: digest.Generation,
+	}
+	if err != nil {
+		log.Error(err, "failed to reconcile digest", "name", digest.Name)
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = failureReason(err)
+		condition.Message = err.Error()
+	}
+	meta.SetStatusCondition(&digest.Status.Conditions, condition)
+	digest.Status.ObservedGeneration = digest.Generation
+
+	if !equality.Semantic.DeepEqual(original, &digest.Status) {
+		if err := patchStatus(ctx, r.Client, &digest, base); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{}, err
+}
+
+// digestConfigMapName returns the name of the ConfigMap of digest.
+func digestConfigMapName(digest *demov2.SimpleDigest) string {
+	return cmp.Or(digest.Spec.ConfigMapName, digest.Name+"-digest")
+}
+
+// simples returns the Simples selected by digest sorted by name, leaving out
+// the ones being deleted.
+func (r *SimpleDigestReconciler) simples(ctx context.Context, digest *demov2.SimpleDigest) ([]demov2.Simple, error) {
+	selector := labels.Everything()
+	if digest.Spec.Selector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(digest.Spec.Selector); err != nil {
+			return nil, fmt.Errorf("%w: spec.selector: %v", errInvalidSpec, err)
+		}
+	}
+	var list demov2.SimpleList
+	if err := r.List(ctx, &list, client.InNamespace(digest.Namespace),
+		client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("listing Simples: %w", err)
+	}
+	simples := slices.DeleteFunc(list.Items, func(simple demov2.Simple) bool {
+		return !simple.DeletionTimestamp.IsZero()
+	})
+	slices.SortFunc(simples, func(a, b demov2.Simple) int { return strings.Compare(a.Name, b.Name) })
+	return simples, nil
+}
+
+// digestConfigMapData returns the data of the digest ConfigMap of simples:
+// the delivered messages of every Simple under its name, one per line, and
+// all of them prefixed with the name of their Simple under digestConfigMapKey.
+// A Simple named like digestConfigMapKey only appears in the report.
+// The messages of Simples with Spec.Redact are replaced by redactedText.
+func digestConfigMapData(simples []demov2.Simple) map[string]string {
+	data := map[string]string{}
+	var report strings.Builder
+	for i := range simples {
+		simple := &simples[i]
+		var lines []string
+		for _, message := range simple.Status.Messages {
+			if !message.Delivered {
+				continue
+			}
+			text := loggedMessage(simple, message.Message)
+			lines = append(lines, text)
+			fmt.Fprintf(&report, "%s: %s\n", simple.Name, text)
+		}
+		if simple.Name != digestConfigMapKey {
+			data[simple.Name] = strings.Join(lines, "\n")
+		}
+	}
+	data[digestConfigMapKey] = report.String()
+	return data
+}
+
+// applyConfigMap applies the digest ConfigMap of simples for digest. An
+// existing ConfigMap of that name is only taken over as checkAdoptionBy says,
+// so a spec.configMapName naming a ConfigMap of the user doesn't overwrite it.
+func (r *SimpleDigestReconciler) applyConfigMap(ctx context.Context, digest *demov2.SimpleDigest,
+	simples []demov2.Simple) error {
+	cm := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      digestConfigMapName(digest),
+			Namespace: digest.Namespace,
+		},
+		Data: digestConfigMapData(simples),
+	}
+	if err := controllerutil.SetControllerReference(digest, cm, r.Scheme); err != nil {
+		return err
+	}
+	opts := []client.PatchOption{client.FieldOwner(fieldManagerOrDefault(r.FieldManager))}
+	var current corev1.ConfigMap
+	err := r.Get(ctx, client.ObjectKeyFromObject(cm), &current)
+	switch {
+	case apierrors.IsNotFound(err):
+		opts = append(opts, client.ForceOwnership)
+	case err != nil:
+		return fmt.Errorf("getting ConfigMap %s: %w", cm.Name, err)
+	default:
+		adopted, err := checkAdoptionBy(digest, "SimpleDigest", &current, "ConfigMap")
+		if err != nil {
+			return err
+		}
+		if !adopted {
+			opts = append(opts, client.ForceOwnership)
+		}
+	}
+	if err := r.Patch(ctx, cm, client.Apply, opts...); err != nil {
+		return fmt.Errorf("applying ConfigMap %s: %w", cm.Name, err)
+	}
+	return nil
+}
+
+// simpleDigestsForSimple enqueues every SimpleDigest in the namespace of a
+// Simple, as the Simple may be added to, updated in or removed from its digest.
+func (r *SimpleDigestReconciler) simpleDigestsForSimple(ctx context.Context, obj client.Object) []reconcile.Request {
+	var list demov2.SimpleDigestList
+	if err := r.List(ctx, &list, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list SimpleDigests")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(list.Items))
+	for _, digest := range list.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&digest)})
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SimpleDigestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&demov2.SimpleDigest{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Owns(&corev1.ConfigMap{}).
+		Watches(&demov2.Simple{}, handler.EnqueueRequestsFromMapFunc(r.simpleDigestsForSimple)).
+		Named("simpledigest").
+		Complete(r)
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+var _ = Describe("SimpleDigest Controller", func() {
+	Context("When reconciling a resource", func() {
+		const resourceName = "test-digest"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+		digest := &demov2.SimpleDigest{}
+
+		createSimple := func(name, team, text string) {
+			simple := &demov2.Simple{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"team": team}},
+				Spec:       demov2.SimpleSpec{Messages: []demov2.MessageSpec{{Text: text}}},
+			}
+			Expect(k8sClient.Create(ctx, simple)).To(Succeed())
+			simple.Status.Messages = []demov2.MessageStatus{{Message: text, Delivered: true}}
+			Expect(k8sClient.Status().Update(ctx, simple)).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			By("creating the custom resource for the Kind SimpleDigest")
+			err := k8sClient.Get(ctx, typeNamespacedName, digest)
+			if err != nil && errors.IsNotFound(err) {
+				resource := &demov2.SimpleDigest{
+					ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+					Spec: demov2.SimpleDigestSpec{
+						Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "digest"}},
+					},
+				}
+				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			}
+			createSimple("test-digest-a", "digest", "Hello a")
+			createSimple("test-digest-b", "digest", "Hello b")
+			createSimple("test-digest-other", "other", "Hello other")
+		})
+
+		AfterEach(func() {
+			resource := &demov2.SimpleDigest{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+
+			By("Cleanup the specific resource instance SimpleDigest")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			for _, name := range []string{"test-digest-a", "test-digest-b", "test-digest-other"} {
+				simple := &demov2.Simple{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, simple))).To(Succeed())
+			}
+		})
+
+		It("should aggregate the messages of the selected Simples", func() {
+			controllerReconciler := &SimpleDigestReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Checking the digest ConfigMap")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, digest)).To(Succeed())
+			cm := &corev1.ConfigMap{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: resourceName + "-digest", Namespace: "default"}, cm)).To(Succeed())
+			Expect(metav1.IsControlledBy(cm, digest)).To(BeTrue())
+			Expect(cm.Data).To(Equal(map[string]string{
+				"test-digest-a":    "Hello a",
+				"test-digest-b":    "Hello b",
+				digestConfigMapKey: "test-digest-a: Hello a\ntest-digest-b: Hello b\n",
+			}))
+
+			By("Checking the status")
+			Expect(digest.Status.ConfigMapName).To(Equal(resourceName + "-digest"))
+			Expect(digest.Status.Simples).To(Equal(int32(2)))
+			Expect(digest.Status.Messages).To(Equal(int32(2)))
+			ready := meta.FindStatusCondition(digest.Status.Conditions, demov2.ConditionReady)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Status).To(Equal(metav1.ConditionTrue))
+			Expect(ready.Reason).To(Equal(demov2.ReasonDigestUpdated))
+
+			By("Removing a Simple")
+			simple := &demov2.Simple{ObjectMeta: metav1.ObjectMeta{Name: "test-digest-b", Namespace: "default"}}
+			Expect(k8sClient.Delete(ctx, simple)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cm), cm)).To(Succeed())
+			Expect(cm.Data).NotTo(HaveKey("test-digest-b"))
+			Expect(cm.Data).To(HaveKeyWithValue(digestConfigMapKey, "test-digest-a: Hello a\n"))
+		})
+	})
+
+	It("should redact the messages of redacted Simples", func() {
+		simples := []demov2.Simple{
+			{ObjectMeta: metav1.ObjectMeta{Name: "a"}, Status: demov2.SimpleStatus{
+				Messages: []demov2.MessageStatus{{Message: "one", Delivered: true}, {Message: "two", Delivered: true}},
+			}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "b"}, Spec: demov2.SimpleSpec{Redact: true}, Status: demov2.SimpleStatus{
+				Messages: []demov2.MessageStatus{{Message: "sha256:abc", Delivered: true}},
+			}},
+		}
+		Expect(digestConfigMapData(simples)).To(Equal(map[string]string{
+			"a":                "one\ntwo",
+			"b":                redactedText,
+			digestConfigMapKey: "a: one\na: two\nb: " + redactedText + "\n",
+		}))
+	})
+})
+
+var _ = Describe("SimpleDigest ConfigMap", func() {
+	var (
+		c       client.Client
+		r       *SimpleDigestReconciler
+		digest  *demov2.SimpleDigest
+		applied map[string]*client.PatchOptions
+	)
+
+	BeforeEach(func() {
+		applied = map[string]*client.PatchOptions{}
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(demov2.AddToScheme(scheme)).To(Succeed())
+		digest = &demov2.SimpleDigest{
+			ObjectMeta: metav1.ObjectMeta{Name: "report", Namespace: "default", UID: "digest-uid"},
+			Spec:       demov2.SimpleDigestSpec{ConfigMapName: "team-report"},
+		}
+		// The fake client doesn't support server-side apply, the applies are recorded instead
+		c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(digest).WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				if patch != client.Apply {
+					return c.Patch(ctx, obj, patch, opts...)
+				}
+				applied[obj.GetName()] = (&client.PatchOptions{}).ApplyOptions(opts)
+				return nil
+			},
+		}).Build()
+		r = &SimpleDigestReconciler{Client: c, Scheme: scheme}
+	})
+
+	It("should only digest the delivered messages", func() {
+		simples := []demov2.Simple{{ObjectMeta: metav1.ObjectMeta{Name: "a"}, Status: demov2.SimpleStatus{
+			Messages: []demov2.MessageStatus{{Message: "sent", Delivered: true}, {Message: "failed"}},
+		}}}
+		Expect(digestConfigMapData(simples)).To(Equal(map[string]string{
+			"a":                "sent",
+			digestConfigMapKey: "a: sent\n",
+		}))
+	})
+
+	It("should force the conflicts on its own ConfigMap only", func() {
+		Expect(r.applyConfigMap(ctx, digest, nil)).To(Succeed())
+		Expect(applied["team-report"].Force).To(HaveValue(BeTrue()))
+
+		By("leaving a ConfigMap of the user alone")
+		Expect(c.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "team-report", Namespace: "default"}})).
+			To(Succeed())
+		delete(applied, "team-report")
+		err := r.applyConfigMap(ctx, digest, nil)
+		Expect(err).To(MatchError(errInvalidSpec))
+		Expect(err).To(MatchError(ContainSubstring("isn't owned by the SimpleDigest")))
+		Expect(applied).NotTo(HaveKey("team-report"))
+
+		By("adopting it on request, without forcing the conflicts")
+		digest.Annotations = map[string]string{demov2.AdoptAnnotation: "true"}
+		Expect(r.applyConfigMap(ctx, digest, nil)).To(Succeed())
+		Expect(applied["team-report"].Force).To(BeNil())
+	})
+})
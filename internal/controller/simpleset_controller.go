@@ -0,0 +1,254 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// simpleSetLabel marks the Simples created for a SimpleSet, so the ones of
+// removed targets are found
+const simpleSetLabel = "simple.example.com/simple-set"
+
+// SimpleSetReconciler reconciles a SimpleSet object
+type SimpleSetReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// FieldManager is the field manager the Simples are applied with, defaults to defaultFieldManager
+	FieldManager string
+}
+
+// +kubebuilder:rbac:groups=demo.demo.local,resources=simplesets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=demo.demo.local,resources=simplesets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=demo.demo.local,resources=simplesets/finalizers,verbs=update
+
+// Reconcile creates a Simple from the template of a SimpleSet for each of its
+// targets, deletes the Simples of removed targets and aggregates the
+// readiness of the Simples in the status. The Simples are owned by the
+// SimpleSet and go away with it.
+func (r *SimpleSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	// 1. Fetch the SimpleSet instance
+	var set demov2.SimpleSet
+	if err := r.Get(ctx, req.NamespacedName, &set); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !set.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	base := set.DeepCopy()
+	original := &base.Status
+
+	// 2. Apply a Simple for every target
+	targets, err := r.targets(ctx, &set)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	wanted := map[types.NamespacedName]bool{}
+	var errs []error
+	for _, target := range targets {
+		key := types.NamespacedName{Namespace: target.Namespace, Name: target.Name}
+		if wanted[key] {
+			continue
+		}
+		wanted[key] = true
+		if err := r.applyChild(ctx, &set, target); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// 3. Delete the Simples of removed targets and report the others
+	var children demov2.SimpleList
+	if err := r.List(ctx, &children, client.MatchingLabels{simpleSetLabel: set.Name}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("listing Simples: %w", err)
+	}
+	set.Status.Children = nil
+	set.Status.ReadyReplicas = 0
+	for i := range children.Items {
+		child := &children.Items[i]
+		if !metav1.IsControlledBy(child, &set) {
+			continue
+		}
+		if !wanted[client.ObjectKeyFromObject(child)] {
+			if err := r.Delete(ctx, child); client.IgnoreNotFound(err) != nil {
+				errs = append(errs, fmt.Errorf("deleting Simple %s/%s: %w", child.Namespace, child.Name, err))
+				continue
+			}
+			log.Info("Deleted Simple of removed target", "namespace", child.Namespace, "simple", child.Name)
+			continue
+		}
+		ready := meta.IsStatusConditionTrue(child.Status.Conditions, demov2.ConditionReady) &&
+			child.Status.ObservedGeneration == child.Generation
+		if ready {
+			set.Status.ReadyReplicas++
+		}
+		set.Status.Children = append(set.Status.Children, demov2.SimpleSetChildStatus{
+			Name:      child.Name,
+			Namespace: child.Namespace,
+			Phase:     child.Status.Phase,
+			Ready:     ready,
+		})
+	}
+	set.Status.Replicas = int32(len(wanted))
+
+	// 4. Record the outcome in the status conditions
+	reconcileErr := errors.Join(errs...)
+	condition := metav1.Condition{
+		Type:               demov2.ConditionReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             demov2.ReasonChildrenReady,
+		Message:            fmt.Sprintf("%d of %d Simples are Ready", set.Status.ReadyReplicas, set.Status.Replicas),
+		ObservedGeneration: set.Generation,
+	}
+	switch {
+	case reconcileErr != nil:
+		log.Error(reconcileErr, "failed to reconcile Simples", "name", set.Name)
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = demov2.ReasonReconcileFailed
+		condition.Message = reconcileErr.Error()
+	case set.Status.ReadyReplicas < set.Status.Replicas:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = demov2.ReasonChildrenNotReady
+	}
+	meta.SetStatusCondition(&set.Status.Conditions, condition)
+	set.Status.ObservedGeneration = set.Generation
+
+	if !equality.Semantic.DeepEqual(original, &set.Status) {
+		if err := patchStatus(ctx, r.Client, &set, base); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{}, reconcileErr
+}
+
+// targets returns the Simples set should have: its targets followed by one
+// Simple named after it in every selected namespace. The first of duplicate
+// targets wins.
+func (r *SimpleSetReconciler) targets(ctx context.Context, set *demov2.SimpleSet) ([]demov2.SimpleSetTarget, error) {
+	targets := append([]demov2.SimpleSetTarget{}, set.Spec.Targets...)
+	if set.Spec.NamespaceSelector == nil {
+		return targets, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(set.Spec.NamespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("%w: spec.namespaceSelector: %v", errInvalidSpec, err)
+	}
+	var namespaces corev1.NamespaceList
+	if err := r.List(ctx, &namespaces, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("listing namespaces: %w", err)
+	}
+	for _, ns := range namespaces.Items {
+		if ns.Status.Phase == corev1.NamespaceTerminating {
+			continue
+		}
+		targets = append(targets, demov2.SimpleSetTarget{Name: set.Name, Namespace: ns.Name})
+	}
+	return targets, nil
+}
+
+// applyChild applies the Simple of target from the template of set.
+func (r *SimpleSetReconciler) applyChild(ctx context.Context, set *demov2.SimpleSet, target demov2.SimpleSetTarget) error {
+	labels := map[string]string{}
+	for k, v := range set.Spec.Template.Labels {
+		labels[k] = v
+	}
+	for k, v := range target.Labels {
+		labels[k] = v
+	}
+	labels[simpleSetLabel] = set.Name
+
+	simple := &demov2.Simple{
+		TypeMeta: metav1.TypeMeta{APIVersion: demov2.GroupVersion.String(), Kind: "Simple"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        target.Name,
+			Namespace:   target.Namespace,
+			Labels:      labels,
+			Annotations: set.Spec.Template.Annotations,
+		},
+		Spec: *set.Spec.Template.Spec.DeepCopy(),
+	}
+	if err := controllerutil.SetControllerReference(set, simple, r.Scheme); err != nil {
+		return err
+	}
+
+	// Don't take over a Simple created by someone else
+	var existing demov2.Simple
+	switch err := r.Get(ctx, client.ObjectKeyFromObject(simple), &existing); {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		return fmt.Errorf("getting Simple %s/%s: %w", target.Namespace, target.Name, err)
+	case !metav1.IsControlledBy(&existing, set):
+		return fmt.Errorf("simple %s/%s already exists and is not owned by the SimpleSet", target.Namespace, target.Name)
+	}
+
+	if err := r.Patch(ctx, simple, client.Apply, client.FieldOwner(fieldManagerOrDefault(r.FieldManager)),
+		client.ForceOwnership); err != nil {
+		return fmt.Errorf("applying Simple %s/%s: %w", target.Namespace, target.Name, err)
+	}
+	return nil
+}
+
+// simpleSetsForNamespace enqueues the SimpleSets with a namespace selector,
+// as they may select or stop selecting a namespace when it is created or relabeled.
+func (r *SimpleSetReconciler) simpleSetsForNamespace(ctx context.Context, _ client.Object) []reconcile.Request {
+	var list demov2.SimpleSetList
+	if err := r.List(ctx, &list); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list SimpleSets")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, set := range list.Items {
+		if set.Spec.NamespaceSelector != nil {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&set)})
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SimpleSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&demov2.SimpleSet{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Owns(&demov2.Simple{}).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.simpleSetsForNamespace),
+			builder.WithPredicates(predicate.LabelChangedPredicate{})).
+		Named("simpleset").
+		Complete(r)
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+var _ = Describe("SimpleSet Controller", func() {
+	Context("When reconciling a resource", func() {
+		const resourceName = "test-set"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{Name: resourceName}
+		set := &demov2.SimpleSet{}
+
+		BeforeEach(func() {
+			By("creating the custom resource for the Kind SimpleSet")
+			err := k8sClient.Get(ctx, typeNamespacedName, set)
+			if err != nil && errors.IsNotFound(err) {
+				resource := &demov2.SimpleSet{
+					ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+					Spec: demov2.SimpleSetSpec{
+						Template: demov2.SimpleTemplate{
+							Labels: map[string]string{"team": "none"},
+							Spec: demov2.SimpleSpec{
+								Messages: []demov2.MessageSpec{{Text: "Hello team {{ .Labels.team }}"}},
+							},
+						},
+						Targets: []demov2.SimpleSetTarget{
+							{Name: "test-set-a", Namespace: "default", Labels: map[string]string{"team": "a"}},
+							{Name: "test-set-b", Namespace: "default"},
+						},
+					},
+				}
+				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			resource := &demov2.SimpleSet{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+
+			By("Cleanup the specific resource instance SimpleSet")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			for _, name := range []string{"test-set-a", "test-set-b"} {
+				simple := &demov2.Simple{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, simple))).To(Succeed())
+			}
+		})
+
+		It("should create a Simple for every target and delete removed ones", func() {
+			controllerReconciler := &SimpleSetReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Checking the created Simples")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, set)).To(Succeed())
+			simple := &demov2.Simple{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-set-a", Namespace: "default"}, simple)).To(Succeed())
+			Expect(simple.Labels).To(HaveKeyWithValue("team", "a"))
+			Expect(simple.Labels).To(HaveKeyWithValue(simpleSetLabel, resourceName))
+			Expect(simple.Spec.Messages).To(Equal([]demov2.MessageSpec{{Text: "Hello team {{ .Labels.team }}"}}))
+			Expect(metav1.IsControlledBy(simple, set)).To(BeTrue())
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-set-b", Namespace: "default"}, simple)).To(Succeed())
+			Expect(simple.Labels).To(HaveKeyWithValue("team", "none"))
+
+			By("Checking the aggregated status")
+			Expect(set.Status.Replicas).To(Equal(int32(2)))
+			Expect(set.Status.ReadyReplicas).To(BeZero())
+			ready := meta.FindStatusCondition(set.Status.Conditions, demov2.ConditionReady)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+			Expect(ready.Reason).To(Equal(demov2.ReasonChildrenNotReady))
+
+			By("Removing a target")
+			set.Spec.Targets = set.Spec.Targets[:1]
+			Expect(k8sClient.Update(ctx, set)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-set-b", Namespace: "default"},
+				&demov2.Simple{})).To(Satisfy(errors.IsNotFound))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, set)).To(Succeed())
+			Expect(set.Status.Replicas).To(Equal(int32(1)))
+		})
+	})
+})
@@ -0,0 +1,32 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// patchStatus writes the status of obj with a merge patch of its changes
+// since base, a copy of obj as read. Unlike an update, the patch carries no
+// resourceVersion: it doesn't conflict with the writes to the spec and
+// metadata made since obj was read, which would fail an update with a 409 and
+// requeue the object, and only sends the fields that changed.
+func patchStatus(ctx context.Context, c client.Client, obj, base client.Object) error {
+	return c.Status().Patch(ctx, obj, client.MergeFrom(base))
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package format renders the messages of a Simple in its Spec.Format.
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"sigs.k8s.io/yaml"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// Envelope is the JSON and YAML document of the messages of a Simple.
+type Envelope struct {
+	Name       string    `json:"name"`
+	Namespace  string    `json:"namespace"`
+	Generation int64     `json:"generation"`
+	Messages   []Message `json:"messages"`
+}
+
+// Message is a message of an Envelope.
+type Message struct {
+	Name string `json:"name,omitempty"`
+	Text string `json:"text"`
+}
+
+// Text renders messages of simple as a document in Spec.Format: the texts one
+// per line, an Envelope in JSON or YAML, or Markdown source with the texts as
+// paragraphs.
+func Text(simple *demov2.Simple, messages []demov2.MessageSpec) string {
+	// An Envelope only holds strings, encoding it can't fail
+	switch simple.Spec.Format {
+	case demov2.FormatJSON:
+		data, _ := json.Marshal(envelope(simple, messages))
+		return string(data)
+	case demov2.FormatYAML:
+		data, _ := yaml.Marshal(envelope(simple, messages))
+		return string(data)
+	case demov2.FormatMarkdown:
+		return strings.Join(texts(messages), "\n\n")
+	default:
+		return strings.Join(texts(messages), "\n")
+	}
+}
+
+// HTML renders messages of simple as HTML if Spec.Format is Markdown. It
+// returns false for the other formats, which have no HTML rendering.
+func HTML(simple *demov2.Simple, messages []demov2.MessageSpec) (string, bool) {
+	if simple.Spec.Format != demov2.FormatMarkdown {
+		return "", false
+	}
+	// Converting to a buffer can't fail
+	var html bytes.Buffer
+	_ = goldmark.Convert([]byte(Text(simple, messages)), &html)
+	return html.String(), true
+}
+
+func envelope(simple *demov2.Simple, messages []demov2.MessageSpec) Envelope {
+	env := Envelope{
+		Name:       simple.Name,
+		Namespace:  simple.Namespace,
+		Generation: simple.Generation,
+		Messages:   make([]Message, 0, len(messages)),
+	}
+	for _, message := range messages {
+		env.Messages = append(env.Messages, Message{Name: message.Name, Text: message.Text})
+	}
+	return env
+}
+
+func texts(messages []demov2.MessageSpec) []string {
+	result := make([]string, 0, len(messages))
+	for _, message := range messages {
+		result = append(result, message.Text)
+	}
+	return result
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package format
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+var _ = Describe("Format", func() {
+	var (
+		simple   *demov2.Simple
+		messages []demov2.MessageSpec
+	)
+
+	BeforeEach(func() {
+		simple = &demov2.Simple{ObjectMeta: metav1.ObjectMeta{Name: "greeter", Namespace: "default", Generation: 2}}
+		messages = []demov2.MessageSpec{{Text: "Hello"}, {Name: "second", Text: "**World**"}}
+	})
+
+	It("Should render the texts one per line by default", func() {
+		Expect(Text(simple, messages)).To(Equal("Hello\n**World**"))
+		_, ok := HTML(simple, messages)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("Should render a JSON envelope", func() {
+		simple.Spec.Format = demov2.FormatJSON
+		Expect(Text(simple, messages)).To(MatchJSON(`{"name":"greeter","namespace":"default","generation":2,
+			"messages":[{"text":"Hello"},{"name":"second","text":"**World**"}]}`))
+	})
+
+	It("Should render a YAML envelope", func() {
+		simple.Spec.Format = demov2.FormatYAML
+		Expect(Text(simple, messages)).To(MatchYAML(`
+name: greeter
+namespace: default
+generation: 2
+messages:
+- text: Hello
+- name: second
+  text: '**World**'
+`))
+	})
+
+	It("Should render Markdown paragraphs as HTML", func() {
+		simple.Spec.Format = demov2.FormatMarkdown
+		Expect(Text(simple, messages)).To(Equal("Hello\n\n**World**"))
+		html, ok := HTML(simple, messages)
+		Expect(ok).To(BeTrue())
+		Expect(html).To(Equal("<p>Hello</p>\n<p><strong>World</strong></p>\n"))
+	})
+})
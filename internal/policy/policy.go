@@ -0,0 +1,119 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package policy checks Simples against the SimplePolicies of the cluster.
+package policy
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// Violation is a constraint of a SimplePolicy a Simple doesn't satisfy
+type Violation struct {
+	// Policy is the name of the violated SimplePolicy
+	Policy string
+	// Message describes the violation
+	Message string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("SimplePolicy %s: %s", v.Policy, v.Message)
+}
+
+// Check returns the violations of simple, delivering texts, against every
+// SimplePolicy selecting it.
+func Check(ctx context.Context, c client.Reader, simple *demov2.Simple, texts []string) ([]Violation, error) {
+	var policies demov2.SimplePolicyList
+	if err := c.List(ctx, &policies); err != nil {
+		return nil, fmt.Errorf("listing SimplePolicies: %w", err)
+	}
+
+	var violations []Violation
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		selected, err := selects(policy, simple)
+		if err != nil {
+			violations = append(violations, Violation{policy.Name, err.Error()})
+			continue
+		}
+		if selected {
+			for _, msg := range check(&policy.Spec, simple, texts) {
+				violations = append(violations, Violation{policy.Name, msg})
+			}
+		}
+	}
+	return violations, nil
+}
+
+// selects reports whether policy applies to simple.
+func selects(policy *demov2.SimplePolicy, simple *demov2.Simple) (bool, error) {
+	if policy.Spec.Selector == nil {
+		return true, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(policy.Spec.Selector)
+	if err != nil {
+		return false, fmt.Errorf("invalid selector: %w", err)
+	}
+	return selector.Matches(labels.Set(simple.Labels)), nil
+}
+
+// check returns the constraints of spec simple doesn't satisfy.
+func check(spec *demov2.SimplePolicySpec, simple *demov2.Simple, texts []string) []string {
+	var msgs []string
+
+	if len(spec.AllowedNamespaces) > 0 && !slices.Contains(spec.AllowedNamespaces, simple.Namespace) {
+		msgs = append(msgs, fmt.Sprintf("namespace %s is not allowed", simple.Namespace))
+	}
+
+	var patterns []*regexp.Regexp
+	for _, pattern := range spec.AllowedPatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			msgs = append(msgs, fmt.Sprintf("invalid allowed pattern %q: %v", pattern, err))
+			continue
+		}
+		patterns = append(patterns, re)
+	}
+	for i, text := range texts {
+		if spec.MaxMessageLength != nil && len(text) > int(*spec.MaxMessageLength) {
+			msgs = append(msgs, fmt.Sprintf("message %d is longer than %d bytes", i, *spec.MaxMessageLength))
+		}
+		if len(patterns) > 0 && !slices.ContainsFunc(patterns, func(re *regexp.Regexp) bool {
+			return re.MatchString(text)
+		}) {
+			msgs = append(msgs, fmt.Sprintf("message %d does not match any allowed pattern", i))
+		}
+	}
+
+	if len(spec.AllowedSinks) > 0 {
+		for _, sink := range simple.Spec.Sinks.Names() {
+			if !slices.Contains(spec.AllowedSinks, sink) {
+				msgs = append(msgs, fmt.Sprintf("sink %s is not allowed", sink))
+			}
+		}
+	}
+
+	return msgs
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+var _ = Describe("Check", func() {
+	var (
+		ctx    context.Context
+		scheme *runtime.Scheme
+		simple *demov2.Simple
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(demov2.AddToScheme(scheme)).To(Succeed())
+		simple = &demov2.Simple{
+			ObjectMeta: metav1.ObjectMeta{Name: "greeting", Namespace: "team-a", Labels: map[string]string{"tier": "prod"}},
+			Spec: demov2.SimpleSpec{
+				Sinks: &demov2.SinksSpec{Slack: &demov2.SlackSink{}, HTTP: &demov2.HTTPSink{URL: "https://example.com"}},
+			},
+		}
+	})
+
+	It("reports the violations of the policies selecting the Simple", func() {
+		matching := &demov2.SimplePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+			Spec: demov2.SimplePolicySpec{
+				Selector:          &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "prod"}},
+				AllowedNamespaces: []string{"team-b"},
+				AllowedPatterns:   []string{`^\[team-a\]`},
+				MaxMessageLength:  ptr.To[int32](16),
+				AllowedSinks:      []string{"slack"},
+			},
+		}
+		other := &demov2.SimplePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "dev"},
+			Spec: demov2.SimplePolicySpec{
+				Selector:         &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "dev"}},
+				MaxMessageLength: ptr.To[int32](1),
+			},
+		}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(matching, other).Build()
+
+		violations, err := Check(ctx, c, simple, []string{"[team-a] hello", "a message way too long"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(violations).To(ConsistOf(
+			Violation{"prod", "namespace team-a is not allowed"},
+			Violation{"prod", "message 1 is longer than 16 bytes"},
+			Violation{"prod", "message 1 does not match any allowed pattern"},
+			Violation{"prod", "sink http is not allowed"},
+		))
+		Expect(violations[0].String()).To(HavePrefix("SimplePolicy prod: "))
+	})
+
+	It("admits everything without policies", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme).Build()
+		Expect(Check(ctx, c, simple, []string{"hello"})).To(BeEmpty())
+	})
+})
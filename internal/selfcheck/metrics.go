@@ -0,0 +1,34 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selfcheck
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var degraded = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "simple_operator_degraded",
+		Help: "Whether the last self-check of the CRDs or the webhooks failed, by check",
+	},
+	[]string{"check"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(degraded)
+}
@@ -0,0 +1,295 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package selfcheck verifies that the cluster is set up the way the operator
+// expects: that the installed CRDs know every field of the API types the
+// binary was built with, and that the webhook configurations reach a live
+// webhook server. A mismatch otherwise shows up as silent misbehavior, e.g.
+// fields of the spec pruned by an outdated CRD, or Simples never admitted.
+package selfcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get
+// +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations;validatingwebhookconfigurations,verbs=get
+// +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=list
+
+// defaultInterval is used when Interval is unset
+const defaultInterval = time.Minute
+
+// CRD is a CustomResourceDefinition the operator expects.
+type CRD struct {
+	// Name is the name of the CRD, e.g. simples.demo.demo.local
+	Name string
+	// StorageVersion is the version the objects must be stored as
+	StorageVersion string
+	// Versions are the Go objects of the versions that must be served, by version
+	Versions map[string]runtime.Object
+}
+
+// Checker checks the CRDs at once when started, then the CRDs and the
+// webhooks every Interval, and reports the failing checks in the
+// simple_operator_degraded metric. The webhooks are only checked after the
+// first interval, so the replicas have time to become ready.
+type Checker struct {
+	// Reader reads the CRDs, webhook configurations and EndpointSlices, from
+	// the API server since none of them is cached
+	Reader client.Reader
+	// CRDs are the CRDs to check
+	CRDs []CRD
+	// MutatingWebhooks and ValidatingWebhooks are the names of the webhook
+	// configurations to check, none when the webhooks are disabled
+	MutatingWebhooks, ValidatingWebhooks []string
+	// Interval is the period of the checks, defaults to a minute
+	Interval time.Duration
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, every replica reports its own view.
+func (c *Checker) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements manager.Runnable, checking until ctx is done.
+func (c *Checker) Start(ctx context.Context) error {
+	c.report(ctx, "crds", c.CheckCRDs(ctx))
+	interval := c.Interval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			c.report(ctx, "crds", c.CheckCRDs(ctx))
+			c.report(ctx, "webhooks", c.CheckWebhooks(ctx))
+		}
+	}
+}
+
+// report logs err and sets the simple_operator_degraded metric of check.
+func (c *Checker) report(ctx context.Context, check string, err error) {
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Self-check failed, the operator may misbehave", "check", check)
+		degraded.WithLabelValues(check).Set(1)
+		return
+	}
+	degraded.WithLabelValues(check).Set(0)
+}
+
+// CheckCRDs returns an error listing the CRDs that are missing, don't serve
+// or store the expected versions, or lack fields of the Go types.
+func (c *Checker) CheckCRDs(ctx context.Context) error {
+	var errs []error
+	for _, expected := range c.CRDs {
+		crd := &unstructured.Unstructured{}
+		crd.SetAPIVersion("apiextensions.k8s.io/v1")
+		crd.SetKind("CustomResourceDefinition")
+		if err := c.Reader.Get(ctx, types.NamespacedName{Name: expected.Name}, crd); err != nil {
+			errs = append(errs, fmt.Errorf("getting CRD %s: %w", expected.Name, err))
+			continue
+		}
+		errs = append(errs, checkCRD(crd, expected)...)
+	}
+	return errors.Join(errs...)
+}
+
+// checkCRD compares crd with expected.
+func checkCRD(crd *unstructured.Unstructured, expected CRD) []error {
+	versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	installed := map[string]map[string]any{}
+	for _, v := range versions {
+		if version, ok := v.(map[string]any); ok {
+			name, _, _ := unstructured.NestedString(version, "name")
+			installed[name] = version
+		}
+	}
+
+	var errs []error
+	if storage := storageVersion(installed); storage != expected.StorageVersion {
+		errs = append(errs, fmt.Errorf("CRD %s stores version %s, not %s", expected.Name, storage, expected.StorageVersion))
+	}
+	for _, name := range sortedKeys(expected.Versions) {
+		version, ok := installed[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("CRD %s has no version %s", expected.Name, name))
+			continue
+		}
+		if served, _, _ := unstructured.NestedBool(version, "served"); !served {
+			errs = append(errs, fmt.Errorf("CRD %s doesn't serve version %s", expected.Name, name))
+		}
+		schema, _, _ := unstructured.NestedMap(version, "schema", "openAPIV3Schema")
+		if missing := missingFields(reflect.TypeOf(expected.Versions[name]), schema, ""); len(missing) > 0 {
+			errs = append(errs, fmt.Errorf("CRD %s version %s lacks the fields %s, install the CRDs of this release",
+				expected.Name, name, strings.Join(missing, ", ")))
+		}
+	}
+	return errs
+}
+
+// storageVersion returns the storage version among installed.
+func storageVersion(installed map[string]map[string]any) string {
+	for name, version := range installed {
+		if storage, _, _ := unstructured.NestedBool(version, "storage"); storage {
+			return name
+		}
+	}
+	return "none"
+}
+
+// missingFields returns the paths of the JSON fields of t, and of the types
+// of its package it is made of, that schema doesn't have. The fields of the
+// types of other packages, e.g. of the Kubernetes API, aren't looked into.
+func missingFields(t reflect.Type, schema map[string]any, path string) []string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if preserve, _, _ := unstructured.NestedBool(schema, "x-kubernetes-preserve-unknown-fields"); preserve {
+		return nil
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		items, _, _ := unstructured.NestedMap(schema, "items")
+		return missingFields(t.Elem(), items, path+"[]")
+	case reflect.Map:
+		values, _, _ := unstructured.NestedMap(schema, "additionalProperties")
+		return missingFields(t.Elem(), values, path+"{}")
+	case reflect.Struct:
+	default:
+		return nil
+	}
+
+	properties, _, _ := unstructured.NestedMap(schema, "properties")
+	var missing []string
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" && (field.Anonymous || strings.Contains(options, "inline")) {
+			if field.Type.PkgPath() == t.PkgPath() {
+				missing = append(missing, missingFields(field.Type, schema, path)...)
+			}
+			continue
+		}
+		fieldPath := strings.TrimPrefix(path+"."+name, ".")
+		property, ok := properties[name].(map[string]any)
+		if !ok {
+			missing = append(missing, fieldPath)
+			continue
+		}
+		if ownType(field.Type, t.PkgPath()) {
+			missing = append(missing, missingFields(field.Type, property, fieldPath)...)
+		}
+	}
+	return missing
+}
+
+// ownType tells whether t, or the type of its elements, is declared in pkgPath.
+func ownType(t reflect.Type, pkgPath string) bool {
+	for slices.Contains([]reflect.Kind{reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map}, t.Kind()) {
+		t = t.Elem()
+	}
+	return t.PkgPath() == pkgPath
+}
+
+// CheckWebhooks returns an error listing the webhook configurations that
+// are missing, have no CA bundle or point at a Service without ready
+// endpoints.
+func (c *Checker) CheckWebhooks(ctx context.Context) error {
+	var errs []error
+	for _, name := range c.MutatingWebhooks {
+		var configuration admissionregistrationv1.MutatingWebhookConfiguration
+		if err := c.Reader.Get(ctx, types.NamespacedName{Name: name}, &configuration); err != nil {
+			errs = append(errs, fmt.Errorf("getting MutatingWebhookConfiguration %s: %w", name, err))
+			continue
+		}
+		for _, webhook := range configuration.Webhooks {
+			errs = append(errs, c.checkClientConfig(ctx, webhook.Name, webhook.ClientConfig)...)
+		}
+	}
+	for _, name := range c.ValidatingWebhooks {
+		var configuration admissionregistrationv1.ValidatingWebhookConfiguration
+		if err := c.Reader.Get(ctx, types.NamespacedName{Name: name}, &configuration); err != nil {
+			errs = append(errs, fmt.Errorf("getting ValidatingWebhookConfiguration %s: %w", name, err))
+			continue
+		}
+		for _, webhook := range configuration.Webhooks {
+			errs = append(errs, c.checkClientConfig(ctx, webhook.Name, webhook.ClientConfig)...)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkClientConfig checks that the API server can call the webhook name
+// through config. Webhooks called by URL are only checked for a CA bundle.
+func (c *Checker) checkClientConfig(ctx context.Context, name string,
+	config admissionregistrationv1.WebhookClientConfig) []error {
+	var errs []error
+	if len(config.CABundle) == 0 {
+		errs = append(errs, fmt.Errorf("webhook %s has no CA bundle, "+
+			"the certificate isn't injected by cert-manager or --webhook-cert-rotation", name))
+	}
+	if config.Service == nil {
+		return errs
+	}
+	service := config.Service
+	var endpointSlices discoveryv1.EndpointSliceList
+	if err := c.Reader.List(ctx, &endpointSlices, client.InNamespace(service.Namespace),
+		client.MatchingLabels{discoveryv1.LabelServiceName: service.Name}); err != nil {
+		return append(errs, fmt.Errorf("listing the endpoints of webhook %s: %w", name, err))
+	}
+	for _, slice := range endpointSlices.Items {
+		for _, endpoint := range slice.Endpoints {
+			if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+				return errs
+			}
+		}
+	}
+	return append(errs, fmt.Errorf("webhook %s points at Service %s/%s, which has no ready endpoint",
+		name, service.Namespace, service.Name))
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
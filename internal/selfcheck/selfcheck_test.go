@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selfcheck
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	demov1 "github.com/leobip/demo-operator/api/v1"
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// installedCRD reads the CRD of the simples generated in config/crd/bases.
+func installedCRD() *unstructured.Unstructured {
+	data, err := os.ReadFile(filepath.Join("..", "..", "config", "crd", "bases", "demo.demo.local_simples.yaml"))
+	Expect(err).NotTo(HaveOccurred())
+	crd := &unstructured.Unstructured{}
+	Expect(yaml.Unmarshal(data, &crd.Object)).To(Succeed())
+	return crd
+}
+
+// editVersion applies edit to the version name of crd.
+func editVersion(crd *unstructured.Unstructured, name string, edit func(version map[string]any)) {
+	versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	for _, v := range versions {
+		if version := v.(map[string]any); version["name"] == name {
+			edit(version)
+		}
+	}
+	Expect(unstructured.SetNestedSlice(crd.Object, versions, "spec", "versions")).To(Succeed())
+}
+
+var _ = Describe("Checker", func() {
+	var (
+		ctx      context.Context
+		expected = []CRD{{
+			Name:           "simples.demo.demo.local",
+			StorageVersion: "v2",
+			Versions:       map[string]runtime.Object{"v1": &demov1.Simple{}, "v2": &demov2.Simple{}},
+		}}
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	check := func(objects ...runtime.Object) *Checker {
+		return &Checker{
+			Reader:             fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithRuntimeObjects(objects...).Build(),
+			CRDs:               expected,
+			ValidatingWebhooks: []string{"validating-webhook-configuration"},
+		}
+	}
+
+	It("should accept the CRDs generated from the API types", func() {
+		Expect(check(installedCRD()).CheckCRDs(ctx)).To(Succeed())
+	})
+
+	It("should reject a missing CRD", func() {
+		Expect(check().CheckCRDs(ctx)).To(MatchError(ContainSubstring("getting CRD simples.demo.demo.local")))
+	})
+
+	It("should report the fields and versions the installed CRD lacks", func() {
+		crd := installedCRD()
+		editVersion(crd, "v2", func(version map[string]any) {
+			unstructured.RemoveNestedField(version, "schema", "openAPIV3Schema", "properties", "spec", "properties", "message")
+			version["storage"] = false
+		})
+		editVersion(crd, "v1", func(version map[string]any) {
+			version["storage"] = true
+			version["served"] = false
+		})
+
+		err := check(crd).CheckCRDs(ctx)
+		Expect(err).To(MatchError(ContainSubstring("stores version v1, not v2")))
+		Expect(err).To(MatchError(ContainSubstring("doesn't serve version v1")))
+		Expect(err).To(MatchError(ContainSubstring("version v2 lacks the fields spec.message,")))
+	})
+
+	It("should require a CA bundle and a ready endpoint behind the webhooks", func() {
+		configuration := &admissionregistrationv1.ValidatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "validating-webhook-configuration"},
+			Webhooks: []admissionregistrationv1.ValidatingWebhook{{
+				Name: "vsimple-v2.kb.io",
+				ClientConfig: admissionregistrationv1.WebhookClientConfig{
+					Service: &admissionregistrationv1.ServiceReference{Namespace: "system", Name: "webhook-service"},
+				},
+			}},
+		}
+		err := check(configuration.DeepCopy()).CheckWebhooks(ctx)
+		Expect(err).To(MatchError(ContainSubstring("webhook vsimple-v2.kb.io has no CA bundle")))
+		Expect(err).To(MatchError(ContainSubstring("Service system/webhook-service, which has no ready endpoint")))
+
+		By("accepting a webhook served by a ready endpoint")
+		configuration.Webhooks[0].ClientConfig.CABundle = []byte("ca")
+		slice := &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "system",
+				Name:      "webhook-service-abcde",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: "webhook-service"},
+			},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Endpoints: []discoveryv1.Endpoint{
+				{Addresses: []string{"10.0.0.1"}, Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(false)}},
+				{Addresses: []string{"10.0.0.2"}, Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)}},
+			},
+		}
+		Expect(check(configuration, slice).CheckWebhooks(ctx)).To(Succeed())
+	})
+})
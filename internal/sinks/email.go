@@ -0,0 +1,164 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sinks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+	"github.com/leobip/demo-operator/internal/format"
+)
+
+// Keys of the SMTP Secret
+const (
+	smtpHostKey     = "host"
+	smtpPortKey     = "port"
+	smtpFromKey     = "from"
+	smtpUsernameKey = "username"
+	smtpPasswordKey = "password"
+)
+
+// The default rate limit of the email sink, shared by all Simples
+const (
+	defaultEmailsPerMinute = 30
+	defaultEmailBurst      = 10
+)
+
+var errRateLimited = errors.New("email rate limit exceeded, will retry")
+
+// Email sends the messages as an email to the recipients of Spec.Sinks.Email.
+// Every email takes a token from a rate limiter shared by all Simples.
+type Email struct {
+	client   client.Reader
+	limiter  *rate.Limiter
+	sendMail func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewEmail returns the email sink, reading the SMTP Secret through c. A nil
+// limiter allows 30 emails per minute with bursts of 10.
+func NewEmail(c client.Reader, limiter *rate.Limiter) *Email {
+	if limiter == nil {
+		limiter = rate.NewLimiter(rate.Every(time.Minute/defaultEmailsPerMinute), defaultEmailBurst)
+	}
+	return &Email{client: c, limiter: limiter, sendMail: smtp.SendMail}
+}
+
+// Name implements Sink.
+func (s *Email) Name() string {
+	return "email"
+}
+
+// ConditionType implements ConditionReporter.
+func (s *Email) ConditionType() string {
+	return demov2.ConditionEmailSinkDelivered
+}
+
+// Configured implements Sink.
+func (s *Email) Configured(simple *demov2.Simple) bool {
+	return simple.Spec.Sinks != nil && simple.Spec.Sinks.Email != nil
+}
+
+// Deliver implements Sink, sending one email per recipient and recording the
+// outcome of each. It fails if any recipient could not be reached. A retry
+// only sends to the recipients the last delivery failed for.
+func (s *Email) Deliver(ctx context.Context, simple *demov2.Simple) error {
+	sink := simple.Spec.Sinks.Email
+	ref := sink.SMTPSecretRef
+	var secret corev1.Secret
+	if err := s.client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: simple.Namespace}, &secret); err != nil {
+		return fmt.Errorf("loading SMTP settings from Secret %s: %w", ref.Name, err)
+	}
+	host, port := string(secret.Data[smtpHostKey]), string(secret.Data[smtpPortKey])
+	from := string(secret.Data[smtpFromKey])
+	if host == "" || port == "" || from == "" {
+		return fmt.Errorf("secret %s must set %q, %q and %q", ref.Name, smtpHostKey, smtpPortKey, smtpFromKey)
+	}
+
+	var auth smtp.Auth
+	if username := string(secret.Data[smtpUsernameKey]); username != "" {
+		auth = smtp.PlainAuth("", username, string(secret.Data[smtpPasswordKey]), host)
+	}
+
+	subject := sink.Subject
+	if subject == "" {
+		subject = fmt.Sprintf("Message from %s/%s", simple.Namespace, simple.Name)
+	}
+
+	messages := messagesFrom(ctx, simple)
+	body, contentType := format.Text(simple, messages), "text/plain"
+	if html, ok := format.HTML(simple, messages); ok {
+		body, contentType = html, "text/html"
+	}
+
+	attempt := attemptFrom(ctx)
+	attempt.Attempts++
+	sent := map[string]bool{}
+	for _, previous := range attempt.PreviousRecipients {
+		sent[previous.Address] = previous.Delivered
+	}
+	var failed int
+	for _, to := range sink.To {
+		status := demov2.RecipientStatus{Address: to, Delivered: true}
+		if sent[to] {
+			attempt.Recipients = append(attempt.Recipients, status)
+			continue
+		}
+		err := errRateLimited
+		if s.limiter.Allow() {
+			err = s.sendMail(net.JoinHostPort(host, port), auth, from, []string{to},
+				emailMessage(from, to, subject, body, contentType))
+		}
+		if err != nil {
+			status.Delivered = false
+			status.LastError = err.Error()
+			failed++
+		}
+		attempt.Recipients = append(attempt.Recipients, status)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d recipients failed", failed, len(sink.To))
+	}
+	log.FromContext(ctx).Info("Sent messages by email", "name", simple.Name, "recipients", len(sink.To))
+	return nil
+}
+
+// emailMessage formats an email with body of contentType.
+func emailMessage(from, to, subject, body, contentType string) []byte {
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", strings.NewReplacer("\r", "", "\n", " ").Replace(subject))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: %s; charset=utf-8\r\n", contentType)
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(strings.TrimSuffix(body, "\n"), "\n", "\r\n"))
+	msg.WriteString("\r\n")
+	return []byte(msg.String())
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sinks
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// defaultBackoff is the delay before the first retry of an HTTP sink
+const defaultBackoff = time.Second
+
+// ResponseError is returned when a sink answers with a non-2xx status.
+type ResponseError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *ResponseError) Error() string {
+	return "sink returned " + e.Status
+}
+
+// isRetryable reports whether a failed delivery may succeed when retried.
+// Client errors other than 429 Too Many Requests are permanent, and so is the
+// end of the reconcile deadline of ctx. A request timing out on its own, such
+// as after http.Client.Timeout, is retried.
+func isRetryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var respErr *ResponseError
+	if errors.As(err, &respErr) {
+		return respErr.StatusCode == http.StatusTooManyRequests || respErr.StatusCode >= 500
+	}
+	return true
+}
+
+// HTTP sends the messages as a JSON payload to the endpoint of Spec.Sinks.HTTP.
+type HTTP struct {
+	client     client.Reader
+	httpClient *http.Client
+}
+
+// NewHTTP returns the HTTP sink, reading Secrets through c.
+func NewHTTP(c client.Reader, httpClient *http.Client) *HTTP {
+	return &HTTP{client: c, httpClient: httpClient}
+}
+
+// Name implements Sink.
+func (s *HTTP) Name() string {
+	return "http"
+}
+
+// ConditionType implements ConditionReporter.
+func (s *HTTP) ConditionType() string {
+	return demov2.ConditionHTTPSinkDelivered
+}
+
+// Configured implements Sink.
+func (s *HTTP) Configured(simple *demov2.Simple) bool {
+	return simple.Spec.Sinks != nil && simple.Spec.Sinks.HTTP != nil
+}
+
+// Deliver implements Sink, retrying transient failures according to the retry
+// policy of the sink.
+func (s *HTTP) Deliver(ctx context.Context, simple *demov2.Simple) error {
+	sink := simple.Spec.Sinks.HTTP
+	headers := http.Header{}
+	if ref := sink.HeadersSecretRef; ref != nil {
+		var secret corev1.Secret
+		if err := s.client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: simple.Namespace}, &secret); err != nil {
+			return fmt.Errorf("loading headers from Secret %s: %w", ref.Name, err)
+		}
+		for key, value := range secret.Data {
+			headers.Set(key, string(value))
+		}
+	}
+
+	httpClient, err := s.tlsClient(ctx, simple, sink.TLS)
+	if err != nil {
+		return err
+	}
+
+	// A structured payload is sent as is, in place of the messages
+	body, err := simple.Spec.PayloadJSON()
+	if body == nil && err == nil {
+		body, err = json.Marshal(newPayload(ctx, simple))
+	}
+	if err != nil {
+		return err
+	}
+
+	method := sink.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+	if err := send(ctx, httpClient, retryBackoff(sink.Retry), method, sink.URL, headers, body); err != nil {
+		return fmt.Errorf("delivering to %s: %w", sink.URL, err)
+	}
+	log.FromContext(ctx).Info("Delivered messages to HTTP sink", "name", simple.Name, "url", sink.URL)
+	return nil
+}
+
+// retryBackoff turns a retry policy into the backoff used by send.
+func retryBackoff(policy *demov2.RetryPolicy) wait.Backoff {
+	backoff := wait.Backoff{Steps: 4, Duration: defaultBackoff, Factor: 2, Jitter: 0.1}
+	if policy == nil {
+		return backoff
+	}
+	if policy.MaxAttempts > 0 {
+		backoff.Steps = int(policy.MaxAttempts)
+	}
+	if policy.Backoff != nil {
+		backoff.Duration = policy.Backoff.Duration
+	}
+	return backoff
+}
+
+// tlsClient returns the client to reach the endpoint with the given TLS options.
+func (s *HTTP) tlsClient(ctx context.Context, simple *demov2.Simple, opts *demov2.HTTPSinkTLS) (*http.Client, error) {
+	if opts == nil {
+		return s.httpClient, nil
+	}
+
+	//nolint:gosec // skipping verification is an explicit opt-in of the Simple
+	tlsConfig := &tls.Config{InsecureSkipVerify: opts.InsecureSkipVerify}
+	if ref := opts.CASecretRef; ref != nil {
+		var secret corev1.Secret
+		if err := s.client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: simple.Namespace}, &secret); err != nil {
+			return nil, fmt.Errorf("loading CA from Secret %s: %w", ref.Name, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(secret.Data[ref.Key]) {
+			return nil, fmt.Errorf("secret %s has no PEM certificates in key %q", ref.Name, ref.Key)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Timeout: s.httpClient.Timeout, Transport: transport}, nil
+}
+
+// send delivers body to url, retrying retryable failures with backoff. The
+// number of requests and the last response code are recorded in the Attempt
+// of ctx.
+func send(ctx context.Context, httpClient *http.Client, backoff wait.Backoff,
+	method, url string, headers http.Header, body []byte) error {
+	attempt := attemptFrom(ctx)
+	return retry.OnError(backoff, func(err error) bool { return isRetryable(ctx, err) }, func() error {
+		attempt.Attempts++
+		attempt.ResponseCode = 0
+
+		req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		for key, values := range headers {
+			req.Header[key] = values
+		}
+		req.Header.Set("Content-Type", "application/json")
+		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		attempt.ResponseCode = resp.StatusCode
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return &ResponseError{StatusCode: resp.StatusCode, Status: resp.Status}
+		}
+		return nil
+	})
+}
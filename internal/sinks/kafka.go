@@ -0,0 +1,156 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sinks
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// Keys of the Kafka credentials Secret
+const (
+	kafkaMechanismKey = "mechanism"
+	kafkaUsernameKey  = "username"
+	kafkaPasswordKey  = "password"
+	kafkaCAKey        = "ca.crt"
+)
+
+// kafkaTimeout bounds producing a record, retries included
+const kafkaTimeout = 30 * time.Second
+
+// Kafka produces the messages to the topic of Spec.Sinks.Kafka.
+type Kafka struct {
+	client client.Reader
+}
+
+// NewKafka returns the Kafka sink, reading the credentials Secret through c.
+func NewKafka(c client.Reader) *Kafka {
+	return &Kafka{client: c}
+}
+
+// Name implements Sink.
+func (s *Kafka) Name() string {
+	return "kafka"
+}
+
+// ConditionType implements ConditionReporter.
+func (s *Kafka) ConditionType() string {
+	return demov2.ConditionKafkaSinkDelivered
+}
+
+// Configured implements Sink.
+func (s *Kafka) Configured(simple *demov2.Simple) bool {
+	return simple.Spec.Sinks != nil && simple.Spec.Sinks.Kafka != nil
+}
+
+// Deliver implements Sink. It returns once all in-sync replicas acknowledged
+// the record, so the Simple is only marked Replied after the broker acks.
+func (s *Kafka) Deliver(ctx context.Context, simple *demov2.Simple) error {
+	sink := simple.Spec.Sinks.Kafka
+	// Every delivery dials the brokers anew, as the NATS sink does, so no
+	// connection outlives it. The Writer doesn't close the Transport it uses.
+	transport := &kafka.Transport{ClientID: "simple-operator"}
+	defer transport.CloseIdleConnections()
+	if sink.TLS {
+		transport.TLS = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	if ref := sink.CredentialsSecretRef; ref != nil {
+		var secret corev1.Secret
+		if err := s.client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: simple.Namespace}, &secret); err != nil {
+			return fmt.Errorf("loading credentials from Secret %s: %w", ref.Name, err)
+		}
+		mechanism, err := kafkaMechanism(secret.Data)
+		if err != nil {
+			return fmt.Errorf("secret %s: %w", ref.Name, err)
+		}
+		transport.SASL = mechanism
+		if ca := secret.Data[kafkaCAKey]; len(ca) > 0 && transport.TLS != nil {
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(ca) {
+				return fmt.Errorf("secret %s has no PEM certificates in key %q", ref.Name, kafkaCAKey)
+			}
+			transport.TLS.RootCAs = pool
+		}
+	}
+
+	body, err := json.Marshal(newPayload(ctx, simple))
+	if err != nil {
+		return err
+	}
+
+	writer := &kafka.Writer{
+		Addr:         kafka.TCP(sink.Brokers...),
+		Topic:        sink.Topic,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+		Transport:    transport,
+	}
+	defer func() { _ = writer.Close() }()
+
+	ctx, cancel := context.WithTimeout(ctx, kafkaTimeout)
+	defer cancel()
+
+	attemptFrom(ctx).Attempts++
+	start := time.Now()
+	if err := writer.WriteMessages(ctx, kafka.Message{Key: kafkaKey(simple), Value: body}); err != nil {
+		return fmt.Errorf("producing to %s: %w", sink.Topic, err)
+	}
+	kafkaAckLatency.Observe(time.Since(start).Seconds())
+
+	log.FromContext(ctx).Info("Produced messages to Kafka", "name", simple.Name, "topic", sink.Topic)
+	return nil
+}
+
+// kafkaKey returns the record key of simple, so its records land on one partition.
+func kafkaKey(simple *demov2.Simple) []byte {
+	return []byte(simple.Namespace + "/" + simple.Name)
+}
+
+// kafkaMechanism returns the SASL mechanism configured in the credentials
+// Secret data, or nil when no mechanism is set.
+func kafkaMechanism(data map[string][]byte) (sasl.Mechanism, error) {
+	username, password := string(data[kafkaUsernameKey]), string(data[kafkaPasswordKey])
+	switch mechanism := strings.ToUpper(strings.TrimSpace(string(data[kafkaMechanismKey]))); mechanism {
+	case "":
+		return nil, nil
+	case "PLAIN":
+		return plain.Mechanism{Username: username, Password: password}, nil
+	case "SCRAM-SHA-256":
+		return scram.Mechanism(scram.SHA256, username, password)
+	case "SCRAM-SHA-512":
+		return scram.Mechanism(scram.SHA512, username, password)
+	default:
+		return nil, fmt.Errorf("unsupported SASL mechanism %q", mechanism)
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sinks
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// LatencyBuckets are the buckets of the histograms of the time from the
+// creation of a Simple to the delivery of its messages, fine-grained around
+// the tens of seconds of the delivery SLOs.
+var LatencyBuckets = []float64{1, 2, 5, 10, 15, 20, 30, 45, 60, 120, 300, 600}
+
+var (
+	deliveriesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "simple_sink_deliveries_total",
+			Help: "Number of deliveries to each sink, by result",
+		},
+		[]string{"sink", "result"},
+	)
+	deliveryDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "simple_sink_delivery_duration_seconds",
+			Help:    "Duration of the deliveries to each sink, retries included",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"sink"},
+	)
+	deliveryRetriesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "simple_sink_retries_total",
+			Help: "Number of retried deliveries to each sink, within a delivery or after a failed one",
+		},
+		[]string{"sink"},
+	)
+	timeToDelivered = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "simple_sink_time_to_delivered_seconds",
+			Help:    "Time from the creation of a Simple to the first successful delivery to each sink",
+			Buckets: LatencyBuckets,
+		},
+		[]string{"sink"},
+	)
+	kafkaAckLatency = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "simple_kafka_ack_latency_seconds",
+			Help:    "Time until the Kafka brokers acknowledged a produced record",
+			Buckets: prometheus.DefBuckets,
+		},
+	)
+)
+
+func init() {
+	// Register sink metrics with the global prometheus registry
+	metrics.Registry.MustRegister(deliveriesTotal, deliveryDuration, deliveryRetriesTotal, timeToDelivered, kafkaAckLatency)
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sinks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// Keys of the NATS connection Secret
+const (
+	natsURLKey      = "url"
+	natsUserKey     = "user"
+	natsPasswordKey = "password"
+	natsTokenKey    = "token"
+)
+
+// natsTimeout bounds connecting to the server and waiting for JetStream acks
+const natsTimeout = 10 * time.Second
+
+// NATS publishes the messages to the subject of Spec.Sinks.NATS.
+type NATS struct {
+	client client.Reader
+}
+
+// NewNATS returns the NATS sink, reading the connection Secret through c.
+func NewNATS(c client.Reader) *NATS {
+	return &NATS{client: c}
+}
+
+// Name implements Sink.
+func (s *NATS) Name() string {
+	return "nats"
+}
+
+// ConditionType implements ConditionReporter.
+func (s *NATS) ConditionType() string {
+	return demov2.ConditionNATSSinkDelivered
+}
+
+// Configured implements Sink.
+func (s *NATS) Configured(simple *demov2.Simple) bool {
+	return simple.Spec.Sinks != nil && simple.Spec.Sinks.NATS != nil
+}
+
+// Deliver implements Sink. With JetStream enabled it waits for the stream to
+// acknowledge the message and records the stream and sequence.
+func (s *NATS) Deliver(ctx context.Context, simple *demov2.Simple) error {
+	sink := simple.Spec.Sinks.NATS
+	subject, err := natsSubject(sink.Subject, simple)
+	if err != nil {
+		return err
+	}
+
+	ref := sink.ConnectionSecretRef
+	var secret corev1.Secret
+	if err := s.client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: simple.Namespace}, &secret); err != nil {
+		return fmt.Errorf("loading connection from Secret %s: %w", ref.Name, err)
+	}
+	url := strings.TrimSpace(string(secret.Data[natsURLKey]))
+	if url == "" {
+		return fmt.Errorf("secret %s has no server URL in key %q", ref.Name, natsURLKey)
+	}
+
+	opts := []nats.Option{nats.Name("simple-operator"), nats.Timeout(natsTimeout)}
+	if user := string(secret.Data[natsUserKey]); user != "" {
+		opts = append(opts, nats.UserInfo(user, string(secret.Data[natsPasswordKey])))
+	}
+	if token := string(secret.Data[natsTokenKey]); token != "" {
+		opts = append(opts, nats.Token(token))
+	}
+
+	body, err := json.Marshal(newPayload(ctx, simple))
+	if err != nil {
+		return err
+	}
+
+	attempt := attemptFrom(ctx)
+	attempt.Attempts++
+	conn, err := nats.Connect(url, opts...)
+	if err != nil {
+		return fmt.Errorf("connecting to %s: %w", url, err)
+	}
+	defer conn.Close()
+
+	if sink.JetStream {
+		js, err := conn.JetStream(nats.Context(ctx))
+		if err != nil {
+			return fmt.Errorf("opening JetStream: %w", err)
+		}
+		ack, err := js.Publish(subject, body, nats.AckWait(natsTimeout))
+		if err != nil {
+			return fmt.Errorf("publishing to %s: %w", subject, err)
+		}
+		attempt.Ack = fmt.Sprintf("stream=%s seq=%d", ack.Stream, ack.Sequence)
+	} else {
+		if err := conn.Publish(subject, body); err != nil {
+			return fmt.Errorf("publishing to %s: %w", subject, err)
+		}
+		flushCtx, cancel := context.WithTimeout(ctx, natsTimeout)
+		defer cancel()
+		if err := conn.FlushWithContext(flushCtx); err != nil {
+			return fmt.Errorf("flushing %s: %w", subject, err)
+		}
+	}
+
+	log.FromContext(ctx).Info("Published messages to NATS", "name", simple.Name, "subject", subject)
+	return nil
+}
+
+// natsSubject renders the subject template for simple.
+func natsSubject(subject string, simple *demov2.Simple) (string, error) {
+	if subject == "" {
+		subject = "simples.{{ .Namespace }}.{{ .Name }}"
+	}
+	tmpl, err := template.New("subject").Option("missingkey=error").Parse(subject)
+	if err != nil {
+		return "", fmt.Errorf("parsing subject: %w", err)
+	}
+
+	var out strings.Builder
+	data := map[string]string{"Name": simple.Name, "Namespace": simple.Namespace}
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("rendering subject: %w", err)
+	}
+	if out.Len() == 0 || strings.ContainsAny(out.String(), " \t\r\n") {
+		return "", fmt.Errorf("invalid subject %q", out.String())
+	}
+	return out.String(), nil
+}
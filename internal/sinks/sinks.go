@@ -0,0 +1,318 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sinks delivers the messages of Simples to external destinations.
+package sinks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// Sink delivers the messages of a Simple to a destination. Sinks read the
+// messages to deliver from the context, see WithMessages, or else from
+// Status.Messages.
+type Sink interface {
+	// Name identifies the sink in Status.Sinks and in the metrics
+	Name() string
+	// Configured reports whether simple asks for delivery to this sink
+	Configured(simple *demov2.Simple) bool
+	// Deliver sends the messages of simple to the destination
+	Deliver(ctx context.Context, simple *demov2.Simple) error
+}
+
+// ConditionReporter is implemented by sinks that report their outcome as a
+// status condition in addition to Status.Sinks.
+type ConditionReporter interface {
+	ConditionType() string
+}
+
+// Registry holds the sinks the controller delivers to.
+type Registry struct {
+	sinks []Sink
+}
+
+// tracer records a span per sink delivery
+var tracer = otel.Tracer("github.com/leobip/demo-operator/internal/sinks")
+
+// NewRegistry returns a Registry delivering to sinks, in order.
+func NewRegistry(sinks ...Sink) *Registry {
+	return &Registry{sinks: sinks}
+}
+
+// NewDefaultRegistry returns a Registry with all built-in sinks.
+func NewDefaultRegistry(c client.Reader, httpClient *http.Client) *Registry {
+	return NewRegistry(
+		NewSlack(c, httpClient),
+		NewHTTP(c, httpClient),
+		NewNATS(c),
+		NewKafka(c),
+		NewEmail(c, nil),
+	)
+}
+
+// Register adds sink to the registry.
+func (r *Registry) Register(sink Sink) {
+	r.sinks = append(r.sinks, sink)
+}
+
+// Deliver delivers the messages of simple to every configured sink and records
+// the outcome in its status. A sink is only called again when the messages
+// changed or its last delivery failed. Sinks no longer configured are dropped
+// from the status.
+func (r *Registry) Deliver(ctx context.Context, simple *demov2.Simple, changed bool) error {
+	var errs []error
+	for _, sink := range r.sinks {
+		name := sink.Name()
+		if !sink.Configured(simple) {
+			removeStatus(simple, name)
+			if reporter, ok := sink.(ConditionReporter); ok {
+				meta.RemoveStatusCondition(&simple.Status.Conditions, reporter.ConditionType())
+			}
+			continue
+		}
+		previous := findStatus(simple, name)
+		if !changed && previous != nil && previous.Delivered {
+			continue
+		}
+		// Calling a sink again after its failure is a retry, as are the
+		// retries of the sink itself
+		retried := previous != nil && !previous.Delivered
+		firstDelivery := previous == nil || previous.LastDeliveryTime == nil
+
+		attempt := &Attempt{}
+		if retried && !changed {
+			attempt.PreviousRecipients = previous.Recipients
+		}
+		start := time.Now()
+		sinkCtx, span := tracer.Start(ctx, "Deliver "+name, trace.WithAttributes(attribute.String("sink", name)))
+		err := sink.Deliver(withAttempt(sinkCtx, attempt), simple)
+		deliveryDuration.WithLabelValues(name).Observe(time.Since(start).Seconds())
+		span.SetAttributes(attribute.Int("sink.attempts", int(attempt.Attempts)))
+		if attempt.ResponseCode != 0 {
+			span.SetAttributes(attribute.Int("http.response.status_code", attempt.ResponseCode))
+		}
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+		if retries := max(attempt.Attempts-1, 0); retried || retries > 0 {
+			if retried {
+				retries++
+			}
+			deliveryRetriesTotal.WithLabelValues(name).Add(float64(retries))
+		}
+		if err != nil {
+			deliveriesTotal.WithLabelValues(name, "failure").Inc()
+			errs = append(errs, fmt.Errorf("sink %s: %w", name, err))
+		} else {
+			deliveriesTotal.WithLabelValues(name, "success").Inc()
+			if firstDelivery {
+				timeToDelivered.WithLabelValues(name).Observe(time.Since(simple.CreationTimestamp.Time).Seconds())
+			}
+		}
+
+		setStatus(simple, name, attempt, err)
+		if reporter, ok := sink.(ConditionReporter); ok {
+			setCondition(simple, reporter.ConditionType(), attempt, err)
+		}
+	}
+	setAllDeliveredCondition(simple)
+	return errors.Join(errs...)
+}
+
+// setAllDeliveredCondition sums the Status.Sinks of simple up in its
+// AllSinksDelivered condition, removed when no sink is configured.
+func setAllDeliveredCondition(simple *demov2.Simple) {
+	if len(simple.Status.Sinks) == 0 {
+		meta.RemoveStatusCondition(&simple.Status.Conditions, demov2.ConditionAllSinksDelivered)
+		return
+	}
+	var delivered, pending []string
+	for _, status := range simple.Status.Sinks {
+		if status.Delivered {
+			delivered = append(delivered, status.Name)
+		} else {
+			pending = append(pending, status.Name)
+		}
+	}
+	condition := metav1.Condition{
+		Type:               demov2.ConditionAllSinksDelivered,
+		Status:             metav1.ConditionTrue,
+		Reason:             demov2.ReasonDelivered,
+		Message:            "Delivered to " + strings.Join(delivered, ", "),
+		ObservedGeneration: simple.Generation,
+	}
+	if len(pending) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = demov2.ReasonDeliveryFailed
+		condition.Message = "Not delivered to " + strings.Join(pending, ", ")
+	}
+	meta.SetStatusCondition(&simple.Status.Conditions, condition)
+}
+
+// Attempt collects the details of a delivery, filled in by the sinks.
+type Attempt struct {
+	// Attempts is the number of requests made, including retries
+	Attempts int32
+	// ResponseCode is the HTTP status code of the last request
+	ResponseCode int
+	// Ack is the acknowledgement returned by the destination, if any
+	Ack string
+	// Recipients is the outcome for each recipient, for sinks with several
+	Recipients []demov2.RecipientStatus
+	// PreviousRecipients is the outcome for each recipient of the last
+	// delivery when it failed and the messages didn't change since, so that
+	// only the failed recipients are retried
+	PreviousRecipients []demov2.RecipientStatus
+}
+
+type attemptKey struct{}
+
+func withAttempt(ctx context.Context, attempt *Attempt) context.Context {
+	return context.WithValue(ctx, attemptKey{}, attempt)
+}
+
+// attemptFrom returns the Attempt of the delivery running in ctx. Outside of
+// Registry.Deliver it returns a throwaway Attempt.
+func attemptFrom(ctx context.Context) *Attempt {
+	if attempt, ok := ctx.Value(attemptKey{}).(*Attempt); ok {
+		return attempt
+	}
+	return &Attempt{}
+}
+
+func findStatus(simple *demov2.Simple, name string) *demov2.SinkStatus {
+	for i := range simple.Status.Sinks {
+		if simple.Status.Sinks[i].Name == name {
+			return &simple.Status.Sinks[i]
+		}
+	}
+	return nil
+}
+
+// setStatus records the outcome of a delivery to the sink name.
+func setStatus(simple *demov2.Simple, name string, attempt *Attempt, err error) {
+	status := findStatus(simple, name)
+	if status == nil {
+		simple.Status.Sinks = append(simple.Status.Sinks, demov2.SinkStatus{Name: name})
+		status = &simple.Status.Sinks[len(simple.Status.Sinks)-1]
+	}
+
+	now := metav1.Now()
+	status.LastAttemptTime = &now
+	status.Attempts = attempt.Attempts
+	status.ResponseCode = int32(attempt.ResponseCode)
+	status.Ack = attempt.Ack
+	status.Recipients = attempt.Recipients
+	if err != nil {
+		status.Delivered = false
+		status.LastError = err.Error()
+		return
+	}
+	status.Delivered = true
+	status.LastDeliveryTime = &now
+	status.LastError = ""
+}
+
+func removeStatus(simple *demov2.Simple, name string) {
+	for i := range simple.Status.Sinks {
+		if simple.Status.Sinks[i].Name == name {
+			simple.Status.Sinks = append(simple.Status.Sinks[:i], simple.Status.Sinks[i+1:]...)
+			return
+		}
+	}
+}
+
+func setCondition(simple *demov2.Simple, conditionType string, attempt *Attempt, err error) {
+	// Not every sink counts its attempts or has a response code
+	what, code := "Delivery", ""
+	if attempt.Attempts > 0 {
+		what = fmt.Sprintf("Attempt %d", attempt.Attempts)
+	}
+	if attempt.ResponseCode != 0 {
+		code = fmt.Sprintf(" with response code %d", attempt.ResponseCode)
+	}
+	condition := metav1.Condition{
+		Type:               conditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             demov2.ReasonDelivered,
+		Message:            what + " succeeded" + code,
+		ObservedGeneration: simple.Generation,
+	}
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = demov2.ReasonDeliveryFailed
+		condition.Message = fmt.Sprintf("%s failed%s: %v", what, code, err)
+	}
+	meta.SetStatusCondition(&simple.Status.Conditions, condition)
+}
+
+// payload is the JSON body sent by the HTTP and NATS sinks.
+type payload struct {
+	Name      string           `json:"name"`
+	Namespace string           `json:"namespace"`
+	Messages  []payloadMessage `json:"messages"`
+}
+
+type payloadMessage struct {
+	Name string `json:"name,omitempty"`
+	Text string `json:"text"`
+}
+
+func newPayload(ctx context.Context, simple *demov2.Simple) payload {
+	p := payload{Name: simple.Name, Namespace: simple.Namespace}
+	for _, message := range messagesFrom(ctx, simple) {
+		p.Messages = append(p.Messages, payloadMessage{Name: message.Name, Text: message.Text})
+	}
+	return p
+}
+
+type messagesKey struct{}
+
+// WithMessages returns a copy of ctx carrying the rendered messages to
+// deliver, which Status.Messages doesn't hold with Spec.Redact.
+func WithMessages(ctx context.Context, messages []demov2.MessageSpec) context.Context {
+	return context.WithValue(ctx, messagesKey{}, messages)
+}
+
+// messagesFrom returns the messages of simple to deliver: the ones set by
+// WithMessages, or else the ones in Status.Messages.
+func messagesFrom(ctx context.Context, simple *demov2.Simple) []demov2.MessageSpec {
+	if messages, ok := ctx.Value(messagesKey{}).([]demov2.MessageSpec); ok {
+		return messages
+	}
+	messages := make([]demov2.MessageSpec, 0, len(simple.Status.Messages))
+	for _, message := range simple.Status.Messages {
+		messages = append(messages, demov2.MessageSpec{Name: message.Name, Text: message.Message})
+	}
+	return messages
+}
@@ -0,0 +1,365 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sinks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+var _ = Describe("Sink Registry", func() {
+	var (
+		ctx      context.Context
+		simple   *demov2.Simple
+		registry *Registry
+		requests int
+		statuses []int
+		received []payload
+		headers  http.Header
+		server   *httptest.Server
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		requests = 0
+		statuses = nil
+		received = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			defer GinkgoRecover()
+			var body payload
+			Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+			received = append(received, body)
+			headers = req.Header.Clone()
+			if requests < len(statuses) {
+				w.WriteHeader(statuses[requests])
+			}
+			requests++
+		}))
+		DeferCleanup(server.Close)
+
+		simple = &demov2.Simple{
+			ObjectMeta: metav1.ObjectMeta{Name: "greeter", Namespace: "default"},
+			Spec: demov2.SimpleSpec{
+				Sinks: &demov2.SinksSpec{HTTP: &demov2.HTTPSink{
+					URL: server.URL,
+					Retry: &demov2.RetryPolicy{
+						MaxAttempts: 3,
+						Backoff:     &metav1.Duration{Duration: time.Millisecond},
+					},
+				}},
+			},
+			Status: demov2.SimpleStatus{
+				Messages: []demov2.MessageStatus{{Name: "hello", Message: "Hello", Delivered: true}},
+			},
+		}
+		c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).Build()
+		registry = NewDefaultRegistry(c, server.Client())
+	})
+
+	It("Should deliver the messages and record the outcome", func() {
+		Expect(registry.Deliver(ctx, simple, true)).To(Succeed())
+		Expect(received).To(ConsistOf(payload{
+			Name:      "greeter",
+			Namespace: "default",
+			Messages:  []payloadMessage{{Name: "hello", Text: "Hello"}},
+		}))
+
+		Expect(simple.Status.Sinks).To(HaveLen(1))
+		Expect(simple.Status.Sinks[0].Name).To(Equal("http"))
+		Expect(simple.Status.Sinks[0].Delivered).To(BeTrue())
+		Expect(simple.Status.Sinks[0].ResponseCode).To(Equal(int32(http.StatusOK)))
+		Expect(meta.IsStatusConditionTrue(simple.Status.Conditions, demov2.ConditionHTTPSinkDelivered)).To(BeTrue())
+	})
+
+	It("Should deliver the messages of the context over the status", func() {
+		simple.Status.Messages[0].Message = "sha256:0123"
+		ctx = WithMessages(ctx, []demov2.MessageSpec{{Name: "hello", Text: "Hello"}})
+		Expect(registry.Deliver(ctx, simple, true)).To(Succeed())
+		Expect(received).To(ConsistOf(HaveField("Messages", []payloadMessage{{Name: "hello", Text: "Hello"}})))
+	})
+
+	It("Should send a structured payload as the HTTP body", func() {
+		var body map[string]any
+		server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			defer GinkgoRecover()
+			Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+		})
+		simple.Spec.Payload = &runtime.RawExtension{Raw: []byte(`{"greeting":"hello","count":2}`)}
+		Expect(registry.Deliver(ctx, simple, true)).To(Succeed())
+		Expect(body).To(Equal(map[string]any{"greeting": "hello", "count": float64(2)}))
+	})
+
+	It("Should only deliver again when the messages changed", func() {
+		Expect(registry.Deliver(ctx, simple, true)).To(Succeed())
+		Expect(registry.Deliver(ctx, simple, false)).To(Succeed())
+		Expect(requests).To(Equal(1))
+
+		Expect(registry.Deliver(ctx, simple, true)).To(Succeed())
+		Expect(requests).To(Equal(2))
+	})
+
+	It("Should retry server errors but not client errors", func() {
+		retries := testutil.ToFloat64(deliveryRetriesTotal.WithLabelValues("http"))
+		statuses = []int{http.StatusBadGateway, http.StatusOK}
+		Expect(registry.Deliver(ctx, simple, true)).To(Succeed())
+		Expect(simple.Status.Sinks[0].Attempts).To(Equal(int32(2)))
+		Expect(testutil.ToFloat64(deliveryRetriesTotal.WithLabelValues("http"))).To(Equal(retries + 1))
+
+		requests = 0
+		statuses = []int{http.StatusBadRequest}
+		Expect(registry.Deliver(ctx, simple, true)).To(MatchError(ContainSubstring("400 Bad Request")))
+		Expect(requests).To(Equal(1))
+		Expect(simple.Status.Sinks[0].Delivered).To(BeFalse())
+		Expect(simple.Status.Sinks[0].ResponseCode).To(Equal(int32(http.StatusBadRequest)))
+		Expect(meta.IsStatusConditionFalse(simple.Status.Conditions, demov2.ConditionHTTPSinkDelivered)).To(BeTrue())
+	})
+
+	It("Should retry requests timing out but not the end of the context", func() {
+		slow := true
+		server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			requests++
+			if slow {
+				slow = false
+				time.Sleep(200 * time.Millisecond)
+			}
+		})
+		httpClient := server.Client()
+		httpClient.Timeout = 100 * time.Millisecond
+		c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).Build()
+		registry = NewDefaultRegistry(c, httpClient)
+		Expect(registry.Deliver(ctx, simple, true)).To(Succeed())
+		Expect(simple.Status.Sinks[0].Attempts).To(Equal(int32(2)))
+
+		requests = 0
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		Expect(registry.Deliver(canceled, simple, true)).NotTo(Succeed())
+		Expect(simple.Status.Sinks[0].Attempts).To(Equal(int32(1)))
+	})
+
+	It("Should trace the delivery and propagate the context to the destination", func() {
+		spans := tracetest.NewSpanRecorder()
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+		previous, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+		otel.SetTracerProvider(provider)
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+		DeferCleanup(func() {
+			otel.SetTracerProvider(previous)
+			otel.SetTextMapPropagator(previousPropagator)
+		})
+
+		Expect(registry.Deliver(ctx, simple, true)).To(Succeed())
+		Expect(spans.Ended()).To(HaveLen(1))
+		span := spans.Ended()[0]
+		Expect(span.Name()).To(Equal("Deliver http"))
+		Expect(span.Attributes()).To(ContainElement(attribute.String("sink", "http")))
+
+		carrier := propagation.HeaderCarrier(headers)
+		remote := trace.SpanContextFromContext(propagation.TraceContext{}.Extract(ctx, carrier))
+		Expect(remote.TraceID()).To(Equal(span.SpanContext().TraceID()))
+		Expect(remote.SpanID()).To(Equal(span.SpanContext().SpanID()))
+	})
+
+	It("Should drop the status of sinks that are no longer configured", func() {
+		Expect(registry.Deliver(ctx, simple, true)).To(Succeed())
+
+		simple.Spec.Sinks = nil
+		Expect(registry.Deliver(ctx, simple, false)).To(Succeed())
+		Expect(simple.Status.Sinks).To(BeEmpty())
+		Expect(meta.FindStatusCondition(simple.Status.Conditions, demov2.ConditionHTTPSinkDelivered)).To(BeNil())
+	})
+
+	It("Should fail when the Slack webhook Secret is missing", func() {
+		simple.Spec.Sinks = &demov2.SinksSpec{Slack: &demov2.SlackSink{
+			WebhookURLSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "missing"},
+				Key:                  "url",
+			},
+		}}
+		Expect(registry.Deliver(ctx, simple, true)).To(MatchError(ContainSubstring("sink slack")))
+		Expect(simple.Status.Sinks).To(ConsistOf(HaveField("Name", "slack")))
+		Expect(meta.FindStatusCondition(simple.Status.Conditions, demov2.ConditionSlackSinkDelivered)).To(And(
+			HaveField("Status", metav1.ConditionFalse),
+			HaveField("Message", ContainSubstring("Delivery failed: loading webhook URL from Secret missing"))))
+	})
+
+	It("Should sum the sinks up in the AllSinksDelivered condition", func() {
+		simple.Spec.Sinks.Slack = &demov2.SlackSink{WebhookURLSecretRef: corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "missing"},
+			Key:                  "url",
+		}}
+		Expect(registry.Deliver(ctx, simple, true)).To(HaveOccurred())
+		Expect(meta.FindStatusCondition(simple.Status.Conditions, demov2.ConditionAllSinksDelivered)).To(And(
+			HaveField("Status", metav1.ConditionFalse),
+			HaveField("Message", "Not delivered to slack")))
+
+		By("Counting the sinks delivered earlier")
+		simple.Spec.Sinks.Slack = nil
+		Expect(registry.Deliver(ctx, simple, false)).To(Succeed())
+		Expect(meta.FindStatusCondition(simple.Status.Conditions, demov2.ConditionAllSinksDelivered)).To(And(
+			HaveField("Status", metav1.ConditionTrue),
+			HaveField("Message", "Delivered to http")))
+
+		simple.Spec.Sinks = nil
+		Expect(registry.Deliver(ctx, simple, false)).To(Succeed())
+		Expect(meta.FindStatusCondition(simple.Status.Conditions, demov2.ConditionAllSinksDelivered)).To(BeNil())
+	})
+})
+
+var _ = Describe("NATS Sink", func() {
+	simple := &demov2.Simple{ObjectMeta: metav1.ObjectMeta{Name: "greeter", Namespace: "default"}}
+
+	It("Should render the subject from the Simple", func() {
+		Expect(natsSubject("", simple)).To(Equal("simples.default.greeter"))
+		Expect(natsSubject("events.{{ .Name }}", simple)).To(Equal("events.greeter"))
+	})
+
+	It("Should reject invalid subject templates", func() {
+		Expect(natsSubject("{{ .Name", simple)).Error().To(MatchError(ContainSubstring("parsing subject")))
+		Expect(natsSubject("{{ .Missing }}", simple)).Error().To(MatchError(ContainSubstring("rendering subject")))
+		Expect(natsSubject("with space", simple)).Error().To(MatchError(ContainSubstring("invalid subject")))
+	})
+})
+
+var _ = Describe("Kafka Sink", func() {
+	It("Should key records by namespace and name", func() {
+		simple := &demov2.Simple{ObjectMeta: metav1.ObjectMeta{Name: "greeter", Namespace: "default"}}
+		Expect(string(kafkaKey(simple))).To(Equal("default/greeter"))
+	})
+
+	It("Should select the SASL mechanism from the credentials", func() {
+		Expect(kafkaMechanism(map[string][]byte{})).To(BeNil())
+
+		mechanism, err := kafkaMechanism(map[string][]byte{"mechanism": []byte("plain"), "username": []byte("u")})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mechanism.Name()).To(Equal("PLAIN"))
+
+		mechanism, err = kafkaMechanism(map[string][]byte{"mechanism": []byte("SCRAM-SHA-512"), "username": []byte("u")})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mechanism.Name()).To(Equal("SCRAM-SHA-512"))
+
+		Expect(kafkaMechanism(map[string][]byte{"mechanism": []byte("GSSAPI")})).Error().To(
+			MatchError(ContainSubstring("unsupported SASL mechanism")))
+	})
+})
+
+var _ = Describe("Email Sink", func() {
+	var (
+		ctx    context.Context
+		simple *demov2.Simple
+		sink   *Email
+		sent   []string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		sent = nil
+		simple = &demov2.Simple{
+			ObjectMeta: metav1.ObjectMeta{Name: "greeter", Namespace: "default"},
+			Spec: demov2.SimpleSpec{Sinks: &demov2.SinksSpec{Email: &demov2.EmailSink{
+				SMTPSecretRef: corev1.LocalObjectReference{Name: "smtp"},
+				To:            []string{"a@example.com", "b@example.com", "c@example.com"},
+			}}},
+			Status: demov2.SimpleStatus{Messages: []demov2.MessageStatus{{Message: "Hello"}}},
+		}
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "smtp", Namespace: "default"},
+			Data: map[string][]byte{
+				"host": []byte("smtp.example.com"),
+				"port": []byte("25"),
+				"from": []byte("simple@example.com"),
+			},
+		}
+		c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(secret).Build()
+		sink = NewEmail(c, rate.NewLimiter(rate.Inf, 0))
+		sink.sendMail = func(addr string, _ smtp.Auth, from string, to []string, msg []byte) error {
+			defer GinkgoRecover()
+			Expect(addr).To(Equal("smtp.example.com:25"))
+			Expect(from).To(Equal("simple@example.com"))
+			Expect(string(msg)).To(ContainSubstring("Subject: Message from default/greeter\r\n"))
+			if to[0] == "b@example.com" {
+				return errors.New("mailbox unavailable")
+			}
+			sent = append(sent, to[0])
+			return nil
+		}
+	})
+
+	It("Should record the outcome of every recipient", func() {
+		Expect(NewRegistry(sink).Deliver(ctx, simple, true)).To(MatchError(ContainSubstring("1 of 3 recipients failed")))
+		Expect(sent).To(Equal([]string{"a@example.com", "c@example.com"}))
+		Expect(simple.Status.Sinks[0].Recipients).To(Equal([]demov2.RecipientStatus{
+			{Address: "a@example.com", Delivered: true},
+			{Address: "b@example.com", LastError: "mailbox unavailable"},
+			{Address: "c@example.com", Delivered: true},
+		}))
+
+		By("only retrying the failed recipients")
+		sent = nil
+		Expect(NewRegistry(sink).Deliver(ctx, simple, false)).To(HaveOccurred())
+		Expect(sent).To(BeEmpty())
+		Expect(simple.Status.Sinks[0].Recipients).To(HaveLen(3))
+		Expect(simple.Status.Sinks[0].Recipients[0].Delivered).To(BeTrue())
+
+		By("sending changed messages to every recipient")
+		Expect(NewRegistry(sink).Deliver(ctx, simple, true)).To(HaveOccurred())
+		Expect(sent).To(Equal([]string{"a@example.com", "c@example.com"}))
+	})
+
+	It("Should send Markdown messages as HTML", func() {
+		var body string
+		sink.sendMail = func(_ string, _ smtp.Auth, _ string, _ []string, msg []byte) error {
+			body = string(msg)
+			return nil
+		}
+		simple.Spec.Format = demov2.FormatMarkdown
+		simple.Status.Messages[0].Message = "**Hello**"
+		Expect(sink.Deliver(ctx, simple)).To(Succeed())
+		Expect(body).To(ContainSubstring("Content-Type: text/html; charset=utf-8\r\n"))
+		Expect(body).To(HaveSuffix("<p><strong>Hello</strong></p>\r\n"))
+	})
+
+	It("Should stop sending once the rate limit is reached", func() {
+		sink.limiter = rate.NewLimiter(rate.Every(time.Hour), 1)
+		Expect(sink.Deliver(ctx, simple)).To(HaveOccurred())
+		Expect(sent).To(Equal([]string{"a@example.com"}))
+	})
+})
@@ -0,0 +1,83 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sinks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+	"github.com/leobip/demo-operator/internal/format"
+)
+
+// Slack posts the messages to the incoming webhook of Spec.Sinks.Slack.
+type Slack struct {
+	client     client.Reader
+	httpClient *http.Client
+}
+
+// NewSlack returns the Slack sink, reading the webhook URL Secret through c.
+func NewSlack(c client.Reader, httpClient *http.Client) *Slack {
+	return &Slack{client: c, httpClient: httpClient}
+}
+
+// Name implements Sink.
+func (s *Slack) Name() string {
+	return "slack"
+}
+
+// ConditionType implements ConditionReporter.
+func (s *Slack) ConditionType() string {
+	return demov2.ConditionSlackSinkDelivered
+}
+
+// Configured implements Sink.
+func (s *Slack) Configured(simple *demov2.Simple) bool {
+	return simple.Spec.Sinks != nil && simple.Spec.Sinks.Slack != nil
+}
+
+// Deliver implements Sink, retrying transient failures.
+func (s *Slack) Deliver(ctx context.Context, simple *demov2.Simple) error {
+	ref := simple.Spec.Sinks.Slack.WebhookURLSecretRef
+	var secret corev1.Secret
+	if err := s.client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: simple.Namespace}, &secret); err != nil {
+		return fmt.Errorf("loading webhook URL from Secret %s: %w", ref.Name, err)
+	}
+	url := strings.TrimSpace(string(secret.Data[ref.Key]))
+	if url == "" {
+		return fmt.Errorf("secret %s has no webhook URL in key %q", ref.Name, ref.Key)
+	}
+
+	body, err := json.Marshal(map[string]string{"text": format.Text(simple, messagesFrom(ctx, simple))})
+	if err != nil {
+		return err
+	}
+	if err := send(ctx, s.httpClient, retry.DefaultBackoff, http.MethodPost, url, nil, body); err != nil {
+		return err
+	}
+	log.FromContext(ctx).Info("Delivered messages to Slack", "name", simple.Name)
+	return nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// validateDependencies rejects a Simple depending on itself, directly or
+// through the Simples of its Spec.DependsOn. Only self-references are
+// rejected when Client is nil.
+func (v *SimpleCustomValidator) validateDependencies(ctx context.Context, simple *demov2.Simple,
+	fldPath *field.Path) (field.ErrorList, error) {
+	if simple.Name == "" {
+		// A generated name can't be referred to yet
+		return nil, nil
+	}
+	self := types.NamespacedName{Namespace: namespaceOf(ctx, simple), Name: simple.Name}
+
+	var allErrs field.ErrorList
+	visited := map[types.NamespacedName]bool{}
+	for i, ref := range simple.Spec.DependsOn {
+		key := types.NamespacedName{Namespace: cmp.Or(ref.Namespace, self.Namespace), Name: ref.Name}
+		if key == self {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), ref.Name, "a Simple cannot depend on itself"))
+			continue
+		}
+		if v.Client == nil {
+			continue
+		}
+		cycle, err := v.findCycle(ctx, self, key, visited)
+		if err != nil {
+			return nil, err
+		}
+		if cycle != nil {
+			path := append([]string{self.String()}, cycle...)
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), ref.Name,
+				"dependency cycle: "+strings.Join(path, " -> ")))
+		}
+	}
+	return allErrs, nil
+}
+
+// findCycle returns the path of dependencies from key back to self, or nil
+// if there is none. visited holds the Simples already known not to lead to
+// self.
+func (v *SimpleCustomValidator) findCycle(ctx context.Context, self, key types.NamespacedName,
+	visited map[types.NamespacedName]bool) ([]string, error) {
+	if key == self {
+		return []string{key.String()}, nil
+	}
+	if visited[key] {
+		return nil, nil
+	}
+	visited[key] = true
+
+	var dep demov2.Simple
+	if err := v.Client.Get(ctx, key, &dep); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting dependency %s: %w", key, err)
+	}
+	for _, ref := range dep.Spec.DependsOn {
+		next := types.NamespacedName{Namespace: cmp.Or(ref.Namespace, dep.Namespace), Name: ref.Name}
+		cycle, err := v.findCycle(ctx, self, next, visited)
+		if err != nil {
+			return nil, err
+		}
+		if cycle != nil {
+			return append([]string{key.String()}, cycle...), nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// quotaDefaultKey is the key of the quota ConfigMap holding the limit of
+// namespaces without a key of their own.
+const quotaDefaultKey = "maxSimplesPerNamespace"
+
+// namespaceOf returns the namespace the Simple is created in.
+func namespaceOf(ctx context.Context, simple *demov2.Simple) string {
+	if req, err := admission.RequestFromContext(ctx); err == nil && req.Namespace != "" {
+		return req.Namespace
+	}
+	return simple.Namespace
+}
+
+// quotaLimit returns the maximum number of Simples in namespace, 0 meaning
+// unlimited. The quota ConfigMap, when configured, overrides MaxSimplesPerNamespace.
+func (v *SimpleCustomValidator) quotaLimit(ctx context.Context, namespace string) (int, error) {
+	opts := v.options()
+	limit := opts.MaxSimplesPerNamespace
+	if opts.QuotaConfigMap.Name == "" || v.APIReader == nil {
+		return limit, nil
+	}
+
+	var cm corev1.ConfigMap
+	if err := v.APIReader.Get(ctx, opts.QuotaConfigMap, &cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return limit, nil
+		}
+		return 0, fmt.Errorf("reading quota ConfigMap %s: %w", opts.QuotaConfigMap, err)
+	}
+	for _, key := range []string{quotaDefaultKey, namespace} {
+		value, ok := cm.Data[key]
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("quota ConfigMap %s: key %s must be a non-negative integer, got %q",
+				opts.QuotaConfigMap, key, value)
+		}
+		limit = n
+	}
+	return limit, nil
+}
+
+// validateQuota rejects the creation of simple when its namespace already
+// holds the maximum number of Simples. They are counted from the API server,
+// as the manager's cache may not hold the namespace, e.g. with
+// --watch-namespaces, but concurrent creations may still slightly exceed the
+// limit.
+func (v *SimpleCustomValidator) validateQuota(ctx context.Context, simple *demov2.Simple) error {
+	reader := v.APIReader
+	if reader == nil {
+		reader = v.Client
+	}
+	if reader == nil {
+		return nil
+	}
+	namespace := namespaceOf(ctx, simple)
+	limit, err := v.quotaLimit(ctx, namespace)
+	if err != nil || limit == 0 {
+		return err
+	}
+
+	// Only the metadata is needed to count the Simples
+	var simples metav1.PartialObjectMetadataList
+	simples.SetGroupVersionKind(demov2.GroupVersion.WithKind("SimpleList"))
+	if err := reader.List(ctx, &simples, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("counting Simples in namespace %s: %w", namespace, err)
+	}
+	if len(simples.Items) >= limit {
+		return apierrors.NewForbidden(demov2.GroupVersion.WithResource("simples").GroupResource(), simple.Name,
+			fmt.Errorf("namespace %s already has %d Simples, the quota is %d", namespace, len(simples.Items), limit))
+	}
+	return nil
+}
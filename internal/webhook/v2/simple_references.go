@@ -0,0 +1,180 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// reference is a ConfigMap or Secret of the Simple's namespace referred to by its spec.
+type reference struct {
+	// path is the reference in the spec
+	path *field.Path
+	kind string
+	name string
+	// keys are the keys the object must hold
+	keys []string
+	// selector tells whether path is a key selector, whose key field is reported for missing keys
+	selector bool
+}
+
+// String identifies the object and the keys referred to, to tell references apart.
+func (r reference) String() string {
+	return r.kind + "/" + r.name + ":" + strings.Join(r.keys, ",")
+}
+
+// specReferences returns the ConfigMaps and Secrets spec requires for its
+// messageFrom and its sinks, with the keys the sinks read. Optional key
+// selectors aren't returned.
+func specReferences(spec *demov2.SimpleSpec, fldPath *field.Path) []reference {
+	var refs []reference
+	secretKey := func(path *field.Path, ref *corev1.SecretKeySelector) {
+		if ref != nil && !ptr.Deref(ref.Optional, false) {
+			refs = append(refs, reference{path: path, kind: "Secret", name: ref.Name, keys: []string{ref.Key}, selector: true})
+		}
+	}
+	secret := func(path *field.Path, name string, keys ...string) {
+		refs = append(refs, reference{path: path, kind: "Secret", name: name, keys: keys})
+	}
+
+	if from := spec.MessageFrom; from != nil {
+		path := fldPath.Child("messageFrom")
+		if ref := from.ConfigMapKeyRef; ref != nil && !ptr.Deref(ref.Optional, false) {
+			refs = append(refs, reference{path: path.Child("configMapKeyRef"), kind: "ConfigMap", name: ref.Name,
+				keys: []string{ref.Key}, selector: true})
+		}
+		secretKey(path.Child("secretKeyRef"), from.SecretKeyRef)
+	}
+	sinks := spec.Sinks
+	if sinks == nil {
+		return refs
+	}
+	path := fldPath.Child("sinks")
+	if sinks.Slack != nil {
+		secretKey(path.Child("slack", "webhookURLSecretRef"), &sinks.Slack.WebhookURLSecretRef)
+	}
+	if sinks.HTTP != nil {
+		if ref := sinks.HTTP.HeadersSecretRef; ref != nil {
+			secret(path.Child("http", "headersSecretRef"), ref.Name)
+		}
+		if sinks.HTTP.TLS != nil {
+			secretKey(path.Child("http", "tls", "caSecretRef"), sinks.HTTP.TLS.CASecretRef)
+		}
+	}
+	// The keys the sinks can't do without
+	if sinks.NATS != nil {
+		secret(path.Child("nats", "connectionSecretRef"), sinks.NATS.ConnectionSecretRef.Name, "url")
+	}
+	if sinks.Kafka != nil && sinks.Kafka.CredentialsSecretRef != nil {
+		secret(path.Child("kafka", "credentialsSecretRef"), sinks.Kafka.CredentialsSecretRef.Name)
+	}
+	if sinks.Email != nil {
+		secret(path.Child("email", "smtpSecretRef"), sinks.Email.SMTPSecretRef.Name, "host", "port", "from")
+	}
+	return refs
+}
+
+// validateReferences rejects a Simple referring to ConfigMaps or Secrets
+// that don't exist or lack the keys it needs, unless it sets the
+// SkipReferenceCheckAnnotation. On update, only the references added or
+// changed since oldSimple are checked, so a deleted Secret doesn't block
+// the other updates of the Simple, e.g. the removal of its finalizer.
+// Nothing is checked when neither reader is set.
+func (v *SimpleCustomValidator) validateReferences(ctx context.Context, simple, oldSimple *demov2.Simple,
+	fldPath *field.Path) (field.ErrorList, error) {
+	reader := v.APIReader
+	if reader == nil {
+		reader = v.Client
+	}
+	if reader == nil || simple.Annotations[demov2.SkipReferenceCheckAnnotation] == "true" {
+		return nil, nil
+	}
+	var unchanged []string
+	if oldSimple != nil {
+		for _, ref := range specReferences(&oldSimple.Spec, fldPath) {
+			unchanged = append(unchanged, ref.String())
+		}
+	}
+
+	namespace := namespaceOf(ctx, simple)
+	hint := fmt.Sprintf("create it first or set the %s annotation to \"true\"", demov2.SkipReferenceCheckAnnotation)
+	var allErrs field.ErrorList
+	for _, ref := range specReferences(&simple.Spec, fldPath) {
+		if slices.Contains(unchanged, ref.String()) {
+			continue
+		}
+		keys, err := objectKeys(ctx, reader, ref.kind, types.NamespacedName{Namespace: namespace, Name: ref.name})
+		if apierrors.IsNotFound(err) {
+			allErrs = append(allErrs, field.Invalid(ref.path.Child("name"), ref.name,
+				fmt.Sprintf("%s %s/%s not found, %s", ref.kind, namespace, ref.name, hint)))
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range ref.keys {
+			if slices.Contains(keys, key) {
+				continue
+			}
+			path := ref.path
+			if ref.selector {
+				path = path.Child("key")
+			}
+			allErrs = append(allErrs, field.Invalid(path, key,
+				fmt.Sprintf("%s %s/%s has no key %q", ref.kind, namespace, ref.name, key)))
+		}
+	}
+	return allErrs, nil
+}
+
+// objectKeys returns the keys of the ConfigMap or Secret key.
+func objectKeys(ctx context.Context, reader client.Reader, kind string, key types.NamespacedName) ([]string, error) {
+	var keys []string
+	if kind == "ConfigMap" {
+		var configMap corev1.ConfigMap
+		if err := reader.Get(ctx, key, &configMap); err != nil {
+			return nil, err
+		}
+		for k := range configMap.Data {
+			keys = append(keys, k)
+		}
+		for k := range configMap.BinaryData {
+			keys = append(keys, k)
+		}
+		return keys, nil
+	}
+	var secret corev1.Secret
+	if err := reader.Get(ctx, key, &secret); err != nil {
+		return nil, err
+	}
+	for k := range secret.Data {
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
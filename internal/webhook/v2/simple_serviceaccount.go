@@ -0,0 +1,75 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+	"fmt"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// validateServiceAccount checks that the user creating or updating simple
+// may impersonate its spec.serviceAccountName, which the controller writes
+// its children as: otherwise a Simple would lend the permissions of any
+// ServiceAccount of its namespace. An unchanged ServiceAccount isn't checked
+// again, so other users may still edit the Simple.
+func (v *SimpleCustomValidator) validateServiceAccount(ctx context.Context, simple, oldSimple *demov2.Simple,
+	fldPath *field.Path) (field.ErrorList, error) {
+	name := simple.Spec.ServiceAccountName
+	if v.AccessReviewer == nil || name == "" || (oldSimple != nil && oldSimple.Spec.ServiceAccountName == name) {
+		return nil, nil
+	}
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reviewing the access to ServiceAccount %s: %w", name, err)
+	}
+
+	namespace := namespaceOf(ctx, simple)
+	user := req.UserInfo
+	review := &authorizationv1.SubjectAccessReview{Spec: authorizationv1.SubjectAccessReviewSpec{
+		User:   user.Username,
+		UID:    user.UID,
+		Groups: user.Groups,
+		ResourceAttributes: &authorizationv1.ResourceAttributes{
+			Namespace: namespace,
+			Verb:      "impersonate",
+			Resource:  "serviceaccounts",
+			Name:      name,
+		},
+	}}
+	for k, values := range user.Extra {
+		if review.Spec.Extra == nil {
+			review.Spec.Extra = map[string]authorizationv1.ExtraValue{}
+		}
+		review.Spec.Extra[k] = authorizationv1.ExtraValue(values)
+	}
+	if err := v.AccessReviewer.Create(ctx, review); err != nil {
+		return nil, fmt.Errorf("reviewing the access to ServiceAccount %s: %w", name, err)
+	}
+	if !review.Status.Allowed {
+		return field.ErrorList{field.Forbidden(fldPath.Child("serviceAccountName"),
+			fmt.Sprintf("user %q may not impersonate ServiceAccount %s/%s", user.Username, namespace, name))}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// warnings returns the soft problems of spec, which are admitted but
+// surfaced to the user by kubectl.
+func (v *SimpleCustomValidator) warnings(spec *demov2.SimpleSpec, fldPath *field.Path) admission.Warnings {
+	var warnings admission.Warnings
+
+	for _, deprecated := range spec.DeprecatedFields() {
+		warnings = append(warnings, fmt.Sprintf("%s is deprecated, use %s",
+			fldPath.Child(deprecated.Name), fldPath.Child(deprecated.Replacement)))
+	}
+
+	warnLength := v.options().WarnMessageLength
+	warnLong := func(message string, msgPath *field.Path) {
+		// Messages over the maximum length are denied instead
+		if maxLength := v.options().MaxMessageLength; warnLength > 0 && len(message) > warnLength &&
+			(maxLength == 0 || len(message) <= maxLength) {
+			warnings = append(warnings, fmt.Sprintf("%s is %d bytes long, more than the %d bytes expected of a message",
+				msgPath, len(message), warnLength))
+		}
+	}
+	warnLong(spec.Message, fldPath.Child("message"))
+	for i, message := range spec.Messages {
+		warnLong(message.Text, fldPath.Child("messages").Index(i).Child("text"))
+	}
+
+	if sinks := spec.Sinks; sinks != nil {
+		sinksPath := fldPath.Child("sinks")
+		if http := sinks.HTTP; http != nil {
+			if http.HeadersSecretRef == nil {
+				warnings = append(warnings, fmt.Sprintf("%s has no headersSecretRef, the messages are sent without credentials",
+					sinksPath.Child("http")))
+			}
+			if strings.HasPrefix(http.URL, "http://") {
+				warnings = append(warnings, fmt.Sprintf("%s is a plain HTTP URL, the messages are sent unencrypted",
+					sinksPath.Child("http", "url")))
+			}
+			if http.TLS != nil && http.TLS.InsecureSkipVerify {
+				warnings = append(warnings, fmt.Sprintf("%s disables the verification of the server certificate",
+					sinksPath.Child("http", "tls", "insecureSkipVerify")))
+			}
+		}
+		if kafka := sinks.Kafka; kafka != nil && kafka.CredentialsSecretRef == nil {
+			warnings = append(warnings, fmt.Sprintf("%s has no credentialsSecretRef, the messages are produced without credentials",
+				sinksPath.Child("kafka")))
+		}
+	}
+
+	return warnings
+}
@@ -0,0 +1,532 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+	"github.com/leobip/demo-operator/internal/policy"
+)
+
+// nolint:unused
+// log is for logging in this package.
+var simplelog = logf.Log.WithName("simple-resource")
+
+// Standard labels injected by the defaulting webhook
+const (
+	managedByLabel = "app.kubernetes.io/managed-by"
+	managedByValue = "simple-operator"
+)
+
+// messageConfigMapKey is the ConfigMap key holding all messages, which a
+// named message can't use
+const messageConfigMapKey = "message"
+
+// Options configures the Simple admission webhooks.
+type Options struct {
+	// DefaultInterval is set on Simples without an interval, 0 keeps delivering once
+	DefaultInterval time.Duration
+	// MaxMessageLength is the maximum length in bytes of a message, 0 disables the check
+	MaxMessageLength int
+	// WarnMessageLength is the length in bytes of a message above which it is
+	// admitted with a warning, 0 disables the warning
+	WarnMessageLength int
+	// AllowPatterns, when set, require every message to match at least one of them
+	AllowPatterns []*regexp.Regexp
+	// DenyPatterns reject any message matching one of them
+	DenyPatterns []*regexp.Regexp
+	// MaxSimplesPerNamespace is the maximum number of Simples in a namespace, 0 disables the quota
+	MaxSimplesPerNamespace int
+	// QuotaConfigMap, when set, names a ConfigMap overriding MaxSimplesPerNamespace. Its
+	// maxSimplesPerNamespace key sets the default, a key named after a namespace its own quota.
+	QuotaConfigMap types.NamespacedName
+	// DefaultResourceRequests are the requests set on the echo and run
+	// containers of the Simples not requesting these resources
+	DefaultResourceRequests corev1.ResourceList
+}
+
+// LiveOptions holds the Options of the webhooks, which may be replaced while
+// they serve requests.
+type LiveOptions struct {
+	current atomic.Pointer[Options]
+}
+
+// NewLiveOptions returns LiveOptions starting with opts.
+func NewLiveOptions(opts Options) *LiveOptions {
+	l := &LiveOptions{}
+	l.Store(opts)
+	return l
+}
+
+// Load returns the current Options.
+func (l *LiveOptions) Load() Options {
+	return *l.current.Load()
+}
+
+// Store replaces the Options used by the webhooks.
+func (l *LiveOptions) Store(opts Options) {
+	l.current.Store(&opts)
+}
+
+// SetupSimpleWebhookWithManager registers the webhook for Simple in the manager.
+func SetupSimpleWebhookWithManager(mgr ctrl.Manager, opts *LiveOptions) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&demov2.Simple{}).
+		WithValidator(&SimpleCustomValidator{Live: opts, Client: mgr.GetClient(), APIReader: mgr.GetAPIReader(),
+			AccessReviewer: mgr.GetClient()}).
+		WithDefaulter(&SimpleCustomDefaulter{Live: opts}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-demo-demo-local-v2-simple,mutating=true,failurePolicy=fail,sideEffects=None,groups=demo.demo.local,resources=simples,verbs=create;update,versions=v2,name=msimple-v2.kb.io,admissionReviewVersions=v1
+
+// SimpleCustomDefaulter struct is responsible for setting default values on the custom resource of the
+// Kind Simple when those are created or updated.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as it is used only for temporary operations and does not need to be deeply copied.
+// +kubebuilder:object:generate=false
+type SimpleCustomDefaulter struct {
+	Options
+
+	// Live, when set, replaces Options and picks up their changes
+	Live *LiveOptions
+}
+
+func (d *SimpleCustomDefaulter) options() Options {
+	if d.Live != nil {
+		return d.Live.Load()
+	}
+	return d.Options
+}
+
+var _ webhook.CustomDefaulter = &SimpleCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind Simple.
+func (d *SimpleCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	simple, ok := obj.(*demov2.Simple)
+	if !ok {
+		return fmt.Errorf("expected an Simple object but got %T", obj)
+	}
+	simplelog.Info("Defaulting for Simple", "name", simple.GetName())
+
+	// Normalize the message text
+	simple.Spec.Message = strings.TrimSpace(simple.Spec.Message)
+	for i := range simple.Spec.Messages {
+		simple.Spec.Messages[i].Text = strings.TrimSpace(simple.Spec.Messages[i].Text)
+	}
+
+	if simple.Spec.Format == "" {
+		simple.Spec.Format = demov2.FormatPlain
+	}
+
+	if simple.Spec.Echo != nil {
+		defaultWorkload(&simple.Spec.Echo.WorkloadSpec, d.options().DefaultResourceRequests)
+	}
+	if simple.Spec.Run != nil {
+		defaultWorkload(&simple.Spec.Run.WorkloadSpec, d.options().DefaultResourceRequests)
+	}
+
+	interval := d.options().DefaultInterval
+	if simple.Spec.Interval == nil && simple.Spec.Schedule == "" && interval > 0 {
+		simple.Spec.Interval = &metav1.Duration{Duration: interval}
+	}
+
+	if _, ok := simple.Labels[managedByLabel]; !ok {
+		if simple.Labels == nil {
+			simple.Labels = map[string]string{}
+		}
+		simple.Labels[managedByLabel] = managedByValue
+	}
+
+	return nil
+}
+
+// NOTE: The 'path' attribute must follow a specific pattern and should not be modified directly here.
+// Modifying the path for an invalid path can cause API server errors; failing to locate the webhook.
+// +kubebuilder:webhook:path=/validate-demo-demo-local-v2-simple,mutating=false,failurePolicy=fail,sideEffects=None,groups=demo.demo.local,resources=simples,verbs=create;update;delete,versions=v2,name=vsimple-v2.kb.io,admissionReviewVersions=v1
+
+// SimpleCustomValidator struct is responsible for validating the Simple resource
+// when it is created, updated, or deleted.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+// +kubebuilder:object:generate=false
+type SimpleCustomValidator struct {
+	Options
+
+	// Live, when set, replaces Options and picks up their changes
+	Live *LiveOptions
+
+	// Client counts the Simples of a namespace for the quota and lists the
+	// SimplePolicies, neither is enforced when nil
+	Client client.Reader
+	// APIReader reads the quota ConfigMap and counts the Simples of the quota,
+	// which may live outside of the cached namespaces
+	APIReader client.Reader
+	// AccessReviewer creates the SubjectAccessReviews checking the requesting
+	// user may impersonate spec.serviceAccountName, not checked when nil
+	AccessReviewer client.Writer
+}
+
+var _ webhook.CustomValidator = &SimpleCustomValidator{}
+
+func (v *SimpleCustomValidator) options() Options {
+	if v.Live != nil {
+		return v.Live.Load()
+	}
+	return v.Options
+}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Simple.
+func (v *SimpleCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	simple, ok := obj.(*demov2.Simple)
+	if !ok {
+		return nil, fmt.Errorf("expected a Simple object but got %T", obj)
+	}
+	simplelog.Info("Validation for Simple upon creation", "name", simple.GetName())
+
+	warnings := v.warnings(&simple.Spec, field.NewPath("spec"))
+	allErrs := v.validateSpec(&simple.Spec, field.NewPath("spec"))
+	policyErrs, err := v.validatePolicies(ctx, simple, field.NewPath("spec"))
+	if err != nil {
+		return warnings, err
+	}
+	dependencyErrs, err := v.validateDependencies(ctx, simple, field.NewPath("spec", "dependsOn"))
+	if err != nil {
+		return warnings, err
+	}
+	allErrs = append(allErrs, dependencyErrs...)
+	referenceErrs, err := v.validateReferences(ctx, simple, nil, field.NewPath("spec"))
+	if err != nil {
+		return warnings, err
+	}
+	allErrs = append(allErrs, referenceErrs...)
+	serviceAccountErrs, err := v.validateServiceAccount(ctx, simple, nil, field.NewPath("spec"))
+	if err != nil {
+		return warnings, err
+	}
+	allErrs = append(allErrs, serviceAccountErrs...)
+	if err := invalid(simple, append(allErrs, policyErrs...)); err != nil {
+		return warnings, err
+	}
+	return warnings, v.validateQuota(ctx, simple)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Simple.
+func (v *SimpleCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	simple, ok := newObj.(*demov2.Simple)
+	if !ok {
+		return nil, fmt.Errorf("expected a Simple object for the newObj but got %T", newObj)
+	}
+	oldSimple, ok := oldObj.(*demov2.Simple)
+	if !ok {
+		return nil, fmt.Errorf("expected a Simple object for the oldObj but got %T", oldObj)
+	}
+	simplelog.Info("Validation for Simple upon update", "name", simple.GetName())
+
+	warnings := v.warnings(&simple.Spec, field.NewPath("spec"))
+	allErrs := validateImmutable(oldSimple, simple, field.NewPath("spec"))
+	allErrs = append(allErrs, v.validateSpec(&simple.Spec, field.NewPath("spec"))...)
+	// A SimplePolicy added later must not block the other updates of an
+	// admitted Simple, e.g. the removal of its finalizer
+	var policyErrs field.ErrorList
+	if simple.DeletionTimestamp.IsZero() && !equality.Semantic.DeepEqual(oldSimple.Spec, simple.Spec) {
+		var err error
+		if policyErrs, err = v.validatePolicies(ctx, simple, field.NewPath("spec")); err != nil {
+			return warnings, err
+		}
+	}
+	dependencyErrs, err := v.validateDependencies(ctx, simple, field.NewPath("spec", "dependsOn"))
+	if err != nil {
+		return warnings, err
+	}
+	allErrs = append(allErrs, dependencyErrs...)
+	referenceErrs, err := v.validateReferences(ctx, simple, oldSimple, field.NewPath("spec"))
+	if err != nil {
+		return warnings, err
+	}
+	allErrs = append(allErrs, referenceErrs...)
+	serviceAccountErrs, err := v.validateServiceAccount(ctx, simple, oldSimple, field.NewPath("spec"))
+	if err != nil {
+		return warnings, err
+	}
+	allErrs = append(allErrs, serviceAccountErrs...)
+	return warnings, invalid(simple, append(allErrs, policyErrs...))
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Simple.
+func (v *SimpleCustomValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	simple, ok := obj.(*demov2.Simple)
+	if !ok {
+		return nil, fmt.Errorf("expected a Simple object but got %T", obj)
+	}
+	simplelog.Info("Validation for Simple upon deletion", "name", simple.GetName())
+
+	if simple.DeletionProtected() {
+		return nil, apierrors.NewForbidden(demov2.GroupVersion.WithResource("simples").GroupResource(), simple.Name,
+			fmt.Errorf("the Simple is protected by the %s annotation, set the %s annotation to %q to delete it",
+				demov2.ProtectedAnnotation, demov2.UnlockDeletionAnnotation, simple.Name))
+	}
+	return nil, nil
+}
+
+// validatePolicies returns the violations of the SimplePolicies selecting
+// simple. Messages from messageFrom, messageURL, gitSource and rendered
+// templates are only known to the controller, which checks them again.
+func (v *SimpleCustomValidator) validatePolicies(ctx context.Context, simple *demov2.Simple,
+	fldPath *field.Path) (field.ErrorList, error) {
+	if v.Client == nil {
+		return nil, nil
+	}
+	if simple.Namespace == "" {
+		simple = simple.DeepCopy()
+		simple.Namespace = namespaceOf(ctx, simple)
+	}
+
+	var texts []string
+	for _, message := range simple.Spec.AllMessages() {
+		texts = append(texts, message.Text)
+	}
+	violations, err := policy.Check(ctx, v.Client, simple, texts)
+	if err != nil {
+		return nil, err
+	}
+	var allErrs field.ErrorList
+	for _, violation := range violations {
+		allErrs = append(allErrs, field.Forbidden(fldPath, violation.String()))
+	}
+	return allErrs, nil
+}
+
+// invalid returns an Invalid error for simple listing allErrs, or nil if there are none.
+func invalid(simple *demov2.Simple, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: demov2.GroupVersion.Group, Kind: "Simple"},
+		simple.Name, allErrs)
+}
+
+// validateImmutable rejects changes to the messages of a Simple that was
+// immutable, to where they come from and to how they are rendered, unless the update sets the
+// AllowMutationAnnotation to "true".
+func validateImmutable(oldSimple, simple *demov2.Simple, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if !oldSimple.Spec.Immutable {
+		return nil
+	}
+	if simple.Annotations[demov2.AllowMutationAnnotation] == "true" {
+		return nil
+	}
+	msg := "the Simple is immutable, set the " + demov2.AllowMutationAnnotation + ` annotation to "true" to change it`
+	oldSpec, spec := &oldSimple.Spec, &simple.Spec
+	for _, source := range []struct {
+		name     string
+		old, new any
+	}{
+		{"message", oldSpec.Message, spec.Message},
+		{"messages", oldSpec.Messages, spec.Messages},
+		{"payload", oldSpec.Payload, spec.Payload},
+		{"messageFrom", oldSpec.MessageFrom, spec.MessageFrom},
+		{"messageURL", oldSpec.MessageURL, spec.MessageURL},
+		{"gitSource", oldSpec.GitSource, spec.GitSource},
+		{"encryptedMessage", oldSpec.EncryptedMessage, spec.EncryptedMessage},
+		{"messageKey", oldSpec.MessageKey, spec.MessageKey},
+		{"catalog", oldSpec.Catalog, spec.Catalog},
+		{"locale", oldSpec.Locale, spec.Locale},
+		{"format", oldSpec.Format, spec.Format},
+	} {
+		if !equality.Semantic.DeepEqual(source.old, source.new) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child(source.name), msg))
+		}
+	}
+	if !simple.Spec.Immutable {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("immutable"), msg))
+	}
+
+	return allErrs
+}
+
+func (v *SimpleCustomValidator) validateSpec(spec *demov2.SimpleSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if spec.Message == "" && spec.MessageFrom == nil && spec.MessageURL == nil && spec.GitSource == nil &&
+		spec.EncryptedMessage == nil && spec.MessageKey == "" && len(spec.Messages) == 0 && spec.Payload == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("messages"),
+			"message, messageFrom, messageURL, gitSource, encryptedMessage, messageKey, messages or payload must be set"))
+	}
+	if spec.Message != "" && spec.MessageFrom != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("messageFrom"), "message and messageFrom are mutually exclusive"))
+	}
+	if spec.MessageURL != nil && (spec.Message != "" || spec.MessageFrom != nil) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("messageURL"),
+			"messageURL is mutually exclusive with message and messageFrom"))
+	}
+	if spec.GitSource != nil && (spec.Message != "" || spec.MessageFrom != nil || spec.MessageURL != nil) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("gitSource"),
+			"gitSource is mutually exclusive with message, messageFrom and messageURL"))
+	}
+	allErrs = append(allErrs, validateEncryptedMessage(spec, fldPath)...)
+	if spec.MessageKey != "" && (spec.Message != "" || spec.MessageFrom != nil || spec.MessageURL != nil ||
+		spec.GitSource != nil || spec.EncryptedMessage != nil) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("messageKey"),
+			"messageKey is mutually exclusive with message, messageFrom, messageURL, gitSource and encryptedMessage"))
+	}
+	if (spec.MessageKey == "") != (spec.Catalog == "") {
+		allErrs = append(allErrs, field.Required(fldPath.Child("catalog"), "messageKey and catalog must be set together"))
+	}
+	if spec.Locale != "" && spec.MessageKey == "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("locale"), "locale requires messageKey"))
+	}
+	if from := spec.MessageFrom; from != nil && (from.ConfigMapKeyRef == nil) == (from.SecretKeyRef == nil) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("messageFrom"), "",
+			"exactly one of configMapKeyRef or secretKeyRef must be set"))
+	}
+	if spec.Message != "" {
+		allErrs = append(allErrs, v.validateMessage(spec.Message, spec.Redacted(), fldPath.Child("message"))...)
+	}
+	if spec.Schedule != "" {
+		if _, err := cron.ParseStandard(spec.Schedule); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("schedule"), spec.Schedule, err.Error()))
+		}
+		if spec.Interval != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("interval"), "interval and schedule are mutually exclusive"))
+		}
+	}
+	if payload, err := spec.PayloadJSON(); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("payload"), "", err.Error()))
+	} else if payload != nil {
+		allErrs = append(allErrs, v.validateMessage(string(payload), spec.Redacted(), fldPath.Child("payload"))...)
+	}
+	names := map[string]bool{}
+	for i, message := range spec.Messages {
+		msgPath := fldPath.Child("messages").Index(i)
+		if message.Name != "" {
+			if names[message.Name] || message.Name == messageConfigMapKey ||
+				(message.Name == demov2.PayloadMessageName && spec.Payload != nil) {
+				allErrs = append(allErrs, field.Duplicate(msgPath.Child("name"), message.Name))
+			}
+			names[message.Name] = true
+		}
+		if strings.TrimSpace(message.Text) == "" {
+			allErrs = append(allErrs, field.Required(msgPath.Child("text"), "messages must not be empty"))
+			continue
+		}
+		allErrs = append(allErrs, v.validateMessage(message.Text, spec.Redacted(), msgPath.Child("text"))...)
+	}
+	if spec.Echo != nil {
+		allErrs = append(allErrs, validateWorkload(&spec.Echo.WorkloadSpec, fldPath.Child("echo"))...)
+	}
+	if spec.Run != nil {
+		allErrs = append(allErrs, validateWorkload(&spec.Run.WorkloadSpec, fldPath.Child("run"))...)
+	}
+
+	return allErrs
+}
+
+// validateEncryptedMessage checks that the plaintext of Spec.EncryptedMessage
+// is only written to a Secret.
+func validateEncryptedMessage(spec *demov2.SimpleSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	encrypted := spec.EncryptedMessage
+	if encrypted == nil {
+		return nil
+	}
+	encPath := fldPath.Child("encryptedMessage")
+	if spec.Message != "" || spec.MessageFrom != nil || spec.MessageURL != nil || spec.GitSource != nil {
+		allErrs = append(allErrs, field.Forbidden(encPath,
+			"encryptedMessage is mutually exclusive with message, messageFrom, messageURL and gitSource"))
+	}
+	if (encrypted.SealedBox == nil) == (encrypted.VaultTransit == nil) {
+		allErrs = append(allErrs, field.Invalid(encPath, "", "exactly one of sealedBox or vaultTransit must be set"))
+	}
+	if spec.OutputKind() != demov2.OutputKindSecret {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("output", "kind"), spec.OutputKind(),
+			"encryptedMessage requires output.kind Secret"))
+	}
+	if spec.Echo != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("echo"), "can't be used with encryptedMessage"))
+	}
+	if spec.Run != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("run"), "can't be used with encryptedMessage"))
+	}
+	if spec.PodTarget != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("podTarget"), "can't be used with encryptedMessage"))
+	}
+
+	return allErrs
+}
+
+// validateMessage checks message against the limits of the webhook. The
+// errors of a message with Spec.Redact leave its value out.
+func (v *SimpleCustomValidator) validateMessage(message string, redact bool, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	var value any = message
+	if redact {
+		value = field.OmitValueType{}
+	}
+
+	opts := v.options()
+	if opts.MaxMessageLength > 0 && len(message) > opts.MaxMessageLength {
+		allErrs = append(allErrs, field.TooLong(fldPath, value, opts.MaxMessageLength))
+	}
+	if len(opts.AllowPatterns) > 0 && !matchesAny(opts.AllowPatterns, message) {
+		allErrs = append(allErrs, field.Invalid(fldPath, value, "message does not match any allowed pattern"))
+	}
+	for _, re := range opts.DenyPatterns {
+		if re.MatchString(message) {
+			allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("message matches denied pattern %q", re)))
+		}
+	}
+
+	return allErrs
+}
+
+func matchesAny(patterns []*regexp.Regexp, s string) bool {
+	for _, re := range patterns {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
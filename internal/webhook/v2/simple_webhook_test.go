@@ -0,0 +1,575 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+// errorsOnly drops the warnings of the validations, as Gomega's Error()
+// requires the other results to be zero. The specs checking the warnings call
+// the embedded SimpleCustomValidator.
+type errorsOnly struct {
+	SimpleCustomValidator
+}
+
+func (v *errorsOnly) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	_, err := v.SimpleCustomValidator.ValidateCreate(ctx, obj)
+	return nil, err
+}
+
+func (v *errorsOnly) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	_, err := v.SimpleCustomValidator.ValidateUpdate(ctx, oldObj, newObj)
+	return nil, err
+}
+
+var _ = Describe("Simple Webhook", func() {
+	var (
+		obj       *demov2.Simple
+		oldObj    *demov2.Simple
+		validator errorsOnly
+		defaulter SimpleCustomDefaulter
+	)
+
+	BeforeEach(func() {
+		obj = &demov2.Simple{Spec: demov2.SimpleSpec{Message: "Hello"}}
+		oldObj = &demov2.Simple{Spec: demov2.SimpleSpec{Message: "Hello"}}
+		validator = errorsOnly{SimpleCustomValidator{Options: Options{MaxMessageLength: 16}}}
+		defaulter = SimpleCustomDefaulter{Options: Options{DefaultInterval: time.Hour}}
+	})
+
+	Context("When creating Simple under Defaulting Webhook", func() {
+		It("Should trim the messages", func() {
+			obj.Spec.Message = "  Hello "
+			obj.Spec.Messages = []demov2.MessageSpec{{Text: "\tWorld\n"}}
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Message).To(Equal("Hello"))
+			Expect(obj.Spec.Messages).To(Equal([]demov2.MessageSpec{{Text: "World"}}))
+		})
+
+		It("Should default the format to Plain", func() {
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Format).To(Equal(demov2.FormatPlain))
+
+			obj.Spec.Format = demov2.FormatMarkdown
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Format).To(Equal(demov2.FormatMarkdown))
+		})
+
+		It("Should apply the default interval only when unset", func() {
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Interval).To(Equal(&metav1.Duration{Duration: time.Hour}))
+
+			obj.Spec.Interval = &metav1.Duration{Duration: time.Minute}
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Interval.Duration).To(Equal(time.Minute))
+		})
+
+		It("Should inject the managed-by label without overriding it", func() {
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Labels).To(HaveKeyWithValue("app.kubernetes.io/managed-by", "simple-operator"))
+
+			obj.Labels["app.kubernetes.io/managed-by"] = "kustomize"
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Labels).To(HaveKeyWithValue("app.kubernetes.io/managed-by", "kustomize"))
+		})
+
+		It("Should default the missing resource requests of the echo and run containers", func() {
+			defaulter.DefaultResourceRequests = corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("10m"),
+				corev1.ResourceMemory: resource.MustParse("32Mi"),
+			}
+			obj.Spec.Echo = &demov2.EchoSpec{}
+			obj.Spec.Run = &demov2.RunSpec{WorkloadSpec: demov2.WorkloadSpec{Resources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("16Mi")},
+			}}}
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Echo.Resources.Requests).To(Equal(defaulter.DefaultResourceRequests))
+			Expect(obj.Spec.Run.Resources.Requests).To(Equal(corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("16Mi"),
+			}))
+		})
+	})
+
+	Context("When creating or updating Simple under Validating Webhook", func() {
+		It("Should admit a valid message", func() {
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should validate the resources and the scheduling of the echo and run Pods", func() {
+			obj.Spec.Echo = &demov2.EchoSpec{WorkloadSpec: demov2.WorkloadSpec{
+				Resources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+					Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("200m")},
+				},
+				NodeSelector:      map[string]string{"kubernetes.io/os": "linux"},
+				Tolerations:       []corev1.Toleration{{Key: "dedicated", Value: "simple", Effect: corev1.TaintEffectNoSchedule}},
+				PriorityClassName: "low",
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+					MaxSkew: 1, TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: corev1.ScheduleAnyway,
+				}},
+			}}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+
+			obj.Spec.Run = &demov2.RunSpec{WorkloadSpec: demov2.WorkloadSpec{
+				Resources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+					Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+				},
+				NodeSelector: map[string]string{"bad key": "linux"},
+				Tolerations:  []corev1.Toleration{{Operator: corev1.TolerationOpEqual, Value: "simple"}},
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+					TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: "Never",
+				}},
+			}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			for _, path := range []string{
+				"spec.run.resources.requests[memory]", "spec.run.nodeSelector[bad key]", "spec.run.tolerations[0].operator",
+				"spec.run.topologySpreadConstraints[0].maxSkew", "spec.run.topologySpreadConstraints[0].whenUnsatisfiable",
+			} {
+				Expect(err.Error()).To(ContainSubstring(path))
+			}
+			Expect(err.Error()).NotTo(ContainSubstring("spec.echo"))
+		})
+
+		It("Should deny creation if no message is set", func() {
+			obj.Spec.Message = ""
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(HaveOccurred())
+		})
+
+		It("Should require exactly one messageFrom reference", func() {
+			obj.Spec.Message = ""
+			obj.Spec.MessageFrom = &demov2.MessageSource{}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(
+				MatchError(ContainSubstring("spec.messageFrom")))
+
+			obj.Spec.MessageFrom.SecretKeyRef = &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "greeting"},
+				Key:                  "text",
+			}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+
+			obj.Spec.Message = "Hello"
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(
+				MatchError(ContainSubstring("mutually exclusive")))
+		})
+
+		It("Should reject a messageURL combined with message or messageFrom", func() {
+			obj.Spec.Message = ""
+			obj.Spec.MessageURL = &demov2.MessageURLSource{URL: "https://example.com/motd"}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+
+			obj.Spec.Message = "Hello"
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(
+				MatchError(ContainSubstring("spec.messageURL")))
+		})
+
+		It("Should only write an encrypted message to a Secret", func() {
+			obj.Spec.Message = ""
+			obj.Spec.EncryptedMessage = &demov2.EncryptedMessage{
+				Ciphertext: "c2VhbGVk",
+				SealedBox: &demov2.SealedBoxDecryption{PrivateKeySecretRef: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "key"}, Key: "private",
+				}},
+			}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.output.kind")))
+
+			obj.Spec.Output = &demov2.OutputSpec{Kind: demov2.OutputKindSecret}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+
+			obj.Spec.Echo = &demov2.EchoSpec{}
+			obj.Spec.Message = "Hello"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.echo")))
+			Expect(err).To(MatchError(ContainSubstring("spec.encryptedMessage")))
+			Expect(err.Error()).NotTo(ContainSubstring("Hello"))
+		})
+
+		It("Should require a catalog with messageKey", func() {
+			obj.Spec.Message = ""
+			obj.Spec.MessageKey = "greeting"
+			obj.Spec.Locale = "de-AT"
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.catalog")))
+
+			obj.Spec.Catalog = "messages"
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+
+			obj.Spec.Message = "Hello"
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.messageKey")))
+
+			obj.Spec.MessageKey = ""
+			obj.Spec.Catalog = ""
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.locale")))
+		})
+
+		It("Should reject message changes of an immutable Simple", func() {
+			oldObj.Spec.Immutable = true
+			obj.Spec.Immutable = true
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+
+			obj.Spec.Message = "Tampered"
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().To(
+				MatchError(ContainSubstring("spec.message")))
+
+			obj.Spec.Message = "Hello"
+			obj.Spec.Immutable = false
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().To(
+				MatchError(ContainSubstring("spec.immutable")))
+
+			obj.Spec.Immutable = true
+			obj.Spec.MessageURL = &demov2.MessageURLSource{URL: "https://example.com/message"}
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().To(
+				MatchError(ContainSubstring("spec.messageURL")))
+
+			obj.Spec.MessageURL = nil
+			obj.Spec.Message = "Corrected"
+			obj.Annotations = map[string]string{demov2.AllowMutationAnnotation: "false"}
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().To(HaveOccurred())
+
+			obj.Annotations[demov2.AllowMutationAnnotation] = "true"
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		DescribeTable("Should reject changes to how the messages of an immutable Simple are delivered",
+			func(field string, change func(spec *demov2.SimpleSpec)) {
+				oldObj.Spec.Message = ""
+				oldObj.Spec.MessageKey = "greeting"
+				oldObj.Spec.Catalog = "greetings"
+				oldObj.Spec.Locale = "de-AT"
+				oldObj.Spec.Format = demov2.FormatPlain
+				oldObj.Spec.Immutable = true
+				obj = oldObj.DeepCopy()
+				change(&obj.Spec)
+				Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().To(
+					MatchError(ContainSubstring(field)))
+
+				obj.Annotations = map[string]string{demov2.AllowMutationAnnotation: "true"}
+				Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+			},
+			Entry("catalog", "spec.catalog", func(spec *demov2.SimpleSpec) { spec.Catalog = "other-greetings" }),
+			Entry("locale", "spec.locale", func(spec *demov2.SimpleSpec) { spec.Locale = "fr" }),
+			Entry("format", "spec.format", func(spec *demov2.SimpleSpec) { spec.Format = demov2.FormatMarkdown }),
+		)
+
+		It("Should validate the schedule", func() {
+			obj.Spec.Schedule = "0 9 * * MON"
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+
+			obj.Spec.Schedule = "every monday"
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(
+				MatchError(ContainSubstring("spec.schedule")))
+
+			obj.Spec.Schedule = "@hourly"
+			obj.Spec.Interval = &metav1.Duration{Duration: time.Hour}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(
+				MatchError(ContainSubstring("mutually exclusive")))
+		})
+
+		It("Should deny empty items in messages", func() {
+			obj.Spec.Messages = []demov2.MessageSpec{{Text: "fine"}, {Text: "  "}}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(
+				MatchError(ContainSubstring("spec.messages[1].text")))
+		})
+
+		It("Should validate the payload as a JSON or YAML object", func() {
+			validator.MaxMessageLength = 64
+			obj.Spec.Message = ""
+			obj.Spec.Payload = &runtime.RawExtension{Raw: []byte(`{"greeting":"hello"}`)}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+
+			obj.Spec.Payload = &runtime.RawExtension{Raw: []byte(`"greeting: hello\ncount: 2\n"`)}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+			payload, err := obj.Spec.PayloadJSON()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(payload).To(MatchJSON(`{"count":2,"greeting":"hello"}`))
+
+			obj.Spec.Payload = &runtime.RawExtension{Raw: []byte(`"- not\n- an object\n"`)}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.payload")))
+		})
+
+		It("Should deny duplicate message names", func() {
+			obj.Spec.Messages = []demov2.MessageSpec{{Name: "a", Text: "one"}, {Name: "a", Text: "two"}}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(
+				MatchError(ContainSubstring("spec.messages[1].name")))
+		})
+
+		It("Should deny messages longer than the maximum length", func() {
+			obj.Spec.Messages = []demov2.MessageSpec{{Text: strings.Repeat("x", 17)}}
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().To(
+				MatchError(ContainSubstring("may not be more than 16 bytes")))
+		})
+
+		It("Should warn about soft problems without denying them", func() {
+			validator.WarnMessageLength = 8
+			obj.Spec.Messages = []demov2.MessageSpec{{Text: "A long message"}, {Text: strings.Repeat("x", 17)}}
+			warnings, err := validator.SimpleCustomValidator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.messages[1].text")))
+			Expect(warnings).To(ConsistOf(
+				"spec.message is deprecated, use spec.messages",
+				"spec.messages[0].text is 14 bytes long, more than the 8 bytes expected of a message",
+			))
+
+			obj.Spec.Message = ""
+			obj.Spec.Messages = []demov2.MessageSpec{{Text: "Hello"}}
+			obj.Spec.Sinks = &demov2.SinksSpec{
+				HTTP:  &demov2.HTTPSink{URL: "http://example.com/hook", TLS: &demov2.HTTPSinkTLS{InsecureSkipVerify: true}},
+				Kafka: &demov2.KafkaSink{Brokers: []string{"kafka:9092"}, Topic: "simples"},
+			}
+			warnings, err = validator.SimpleCustomValidator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(
+				ContainSubstring("spec.sinks.http has no headersSecretRef"),
+				ContainSubstring("spec.sinks.http.url is a plain HTTP URL"),
+				ContainSubstring("spec.sinks.http.tls.insecureSkipVerify"),
+				ContainSubstring("spec.sinks.kafka has no credentialsSecretRef"),
+			))
+		})
+
+		It("Should leave the messages of a redacted Simple out of the errors", func() {
+			validator.AllowPatterns = []*regexp.Regexp{regexp.MustCompile(`^\[team-a\]`)}
+			obj.Spec.Message = "card 4111"
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("card 4111")))
+
+			obj.Spec.Redact = true
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("message does not match any allowed pattern")))
+			Expect(err.Error()).NotTo(ContainSubstring("card 4111"))
+		})
+
+		It("Should enforce the allow and deny patterns", func() {
+			validator.AllowPatterns = []*regexp.Regexp{regexp.MustCompile(`^Hello`)}
+			validator.DenyPatterns = []*regexp.Regexp{regexp.MustCompile(`secret`)}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+
+			obj.Spec.Message = "Goodbye"
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(
+				MatchError(ContainSubstring("does not match any allowed pattern")))
+
+			obj.Spec.Message = "Hello secret"
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(
+				MatchError(ContainSubstring("denied pattern")))
+		})
+
+		It("Should enforce the SimplePolicies selecting the Simple", func() {
+			policy := &demov2.SimplePolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "no-http"},
+				Spec:       demov2.SimplePolicySpec{AllowedSinks: []string{"slack"}},
+			}
+			validator.Client = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(policy).Build()
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+
+			obj.Spec.Sinks = &demov2.SinksSpec{HTTP: &demov2.HTTPSink{URL: "https://example.com"}}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(
+				MatchError(ContainSubstring("SimplePolicy no-http: sink http is not allowed")))
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().To(
+				MatchError(ContainSubstring("SimplePolicy no-http")))
+
+			By("admitting the updates leaving the spec unchanged, and the ones of a deleted Simple")
+			violating := obj.DeepCopy()
+			violating.Labels = map[string]string{"team": "a"}
+			Expect(validator.ValidateUpdate(ctx, obj, violating)).Error().NotTo(HaveOccurred())
+			violating.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			violating.Spec.Message = "Changed"
+			Expect(validator.ValidateUpdate(ctx, obj, violating)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny dependency cycles", func() {
+			obj.Name = "a"
+			obj.Namespace = "default"
+			obj.Spec.DependsOn = []demov2.ObjectReference{{Name: "a"}}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(
+				MatchError(ContainSubstring("a Simple cannot depend on itself")))
+
+			b := &demov2.Simple{
+				ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "default"},
+				Spec:       demov2.SimpleSpec{DependsOn: []demov2.ObjectReference{{Name: "c", Namespace: "other"}}},
+			}
+			c := &demov2.Simple{
+				ObjectMeta: metav1.ObjectMeta{Name: "c", Namespace: "other"},
+				Spec:       demov2.SimpleSpec{DependsOn: []demov2.ObjectReference{{Name: "a", Namespace: "default"}}},
+			}
+			validator.Client = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(b, c).Build()
+			obj.Spec.DependsOn = []demov2.ObjectReference{{Name: "missing"}, {Name: "b"}}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(
+				MatchError(ContainSubstring("dependency cycle: default/a -> default/b -> other/c -> default/a")))
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().To(
+				MatchError(ContainSubstring("spec.dependsOn[1]")))
+
+			obj.Spec.DependsOn = []demov2.ObjectReference{{Name: "c", Namespace: "other"}}
+			obj.Name = "d"
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny references to missing ConfigMaps, Secrets and keys", func() {
+			smtp := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "smtp", Namespace: "default"},
+				Data:       map[string][]byte{"host": []byte("smtp.example.com"), "port": []byte("587")},
+			}
+			source := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "source", Namespace: "default"},
+				Data:       map[string]string{"message": "Hello"},
+			}
+			validator.APIReader = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(smtp, source).Build()
+			obj.Namespace = "default"
+			obj.Spec.Message = ""
+			obj.Spec.MessageFrom = &demov2.MessageSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "source"}, Key: "message",
+			}}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+
+			obj.Spec.Sinks = &demov2.SinksSpec{
+				Slack: &demov2.SlackSink{WebhookURLSecretRef: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "slack"}, Key: "url",
+				}},
+				Email: &demov2.EmailSink{SMTPSecretRef: corev1.LocalObjectReference{Name: "smtp"}, To: []string{"a@example.com"}},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring(
+				`spec.sinks.slack.webhookURLSecretRef.name: Invalid value: "slack": Secret default/slack not found, ` +
+					`create it first or set the simple.example.com/skip-reference-check annotation to "true"`)))
+			Expect(err).To(MatchError(ContainSubstring(
+				`spec.sinks.email.smtpSecretRef: Invalid value: "from": Secret default/smtp has no key "from"`)))
+
+			By("Only checking the changed references on update")
+			oldObj = obj.DeepCopy()
+			obj.Spec.MessageFrom.ConfigMapKeyRef.Key = "other"
+			_, err = validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring(`spec.messageFrom.configMapKeyRef.key: Invalid value: "other"`)))
+			Expect(err).NotTo(MatchError(ContainSubstring("spec.sinks")))
+
+			By("Skipping the check on request")
+			obj.Annotations = map[string]string{demov2.SkipReferenceCheckAnnotation: "true"}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny deleting a protected Simple until it is unlocked", func() {
+			obj.Name = "alerts"
+			Expect(validator.ValidateDelete(ctx, obj)).Error().NotTo(HaveOccurred())
+
+			obj.Annotations = map[string]string{demov2.ProtectedAnnotation: "true"}
+			_, err := validator.ValidateDelete(ctx, obj)
+			Expect(apierrors.IsForbidden(err)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring(`set the simple.example.com/unlock-deletion annotation to "alerts"`)))
+
+			By("Unlocking it for another Simple")
+			obj.Annotations[demov2.UnlockDeletionAnnotation] = "other"
+			Expect(validator.ValidateDelete(ctx, obj)).Error().To(HaveOccurred())
+
+			By("Unlocking it")
+			obj.Annotations[demov2.UnlockDeletionAnnotation] = "alerts"
+			Expect(validator.ValidateDelete(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should enforce the per-namespace quota", func() {
+			existing := &demov2.Simple{ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "team-a"}}
+			policy := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "simple-quota", Namespace: "simple-operator-system"},
+				Data:       map[string]string{"maxSimplesPerNamespace": "5", "team-a": "1"},
+			}
+			reader := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(existing, policy).Build()
+			// The cache of the manager doesn't hold the namespace of the Simples
+			validator.Client = fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+			validator.APIReader = reader
+			obj.Namespace = "team-a"
+
+			By("Rejecting creations beyond the flag limit")
+			validator.MaxSimplesPerNamespace = 1
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(apierrors.IsForbidden(err)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring("namespace team-a already has 1 Simples, the quota is 1")))
+
+			By("Using the default of the quota ConfigMap")
+			validator.QuotaConfigMap = types.NamespacedName{Namespace: "simple-operator-system", Name: "simple-quota"}
+			obj.Namespace = "team-b"
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+
+			By("Using the namespace override of the quota ConfigMap")
+			obj.Namespace = "team-a"
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("the quota is 1")))
+		})
+
+		It("Should only let the users allowed to impersonate the ServiceAccount set it", func() {
+			var reviews []*authorizationv1.SubjectAccessReview
+			validator.AccessReviewer = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+					review := obj.(*authorizationv1.SubjectAccessReview)
+					review.Status.Allowed = review.Spec.User == "admin"
+					reviews = append(reviews, review)
+					return nil
+				},
+			}).Build()
+			request := func(user string) context.Context {
+				return admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+					Namespace: "team-a",
+					UserInfo:  authenticationv1.UserInfo{Username: user, Groups: []string{"team-a"}},
+				}})
+			}
+			obj.Spec.ServiceAccountName = "tenant"
+
+			_, err := validator.ValidateCreate(request("admin"), obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reviews).To(HaveLen(1))
+			Expect(reviews[0].Spec.Groups).To(Equal([]string{"team-a"}))
+			Expect(*reviews[0].Spec.ResourceAttributes).To(Equal(authorizationv1.ResourceAttributes{
+				Namespace: "team-a", Verb: "impersonate", Resource: "serviceaccounts", Name: "tenant",
+			}))
+
+			_, err = validator.ValidateCreate(request("developer"), obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring(
+				`spec.serviceAccountName: Forbidden: user "developer" may not impersonate ServiceAccount team-a/tenant`)))
+
+			By("Not checking an unchanged ServiceAccount again")
+			reviews = nil
+			oldObj = obj.DeepCopy()
+			obj.Spec.Message = "Hi"
+			_, err = validator.ValidateUpdate(request("developer"), oldObj, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reviews).To(BeEmpty())
+
+			obj.Spec.ServiceAccountName = "other"
+			_, err = validator.ValidateUpdate(request("developer"), oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring("may not impersonate ServiceAccount team-a/other")))
+		})
+	})
+})
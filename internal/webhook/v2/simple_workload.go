@@ -0,0 +1,144 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"maps"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	demov2 "github.com/leobip/demo-operator/api/v2"
+)
+
+var (
+	tolerationOperators = sets.New(corev1.TolerationOpEqual, corev1.TolerationOpExists)
+	taintEffects        = sets.New(corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule,
+		corev1.TaintEffectNoExecute)
+	unsatisfiableActions = sets.New(corev1.DoNotSchedule, corev1.ScheduleAnyway)
+)
+
+// defaultWorkload sets the requests of defaults the resources of workload
+// don't set. A resource with a limit but no request is requested up to its
+// limit, as the API server would.
+func defaultWorkload(workload *demov2.WorkloadSpec, defaults corev1.ResourceList) {
+	if len(defaults) == 0 {
+		return
+	}
+	if workload.Resources == nil {
+		workload.Resources = &corev1.ResourceRequirements{}
+	}
+	resources := workload.Resources
+	for _, name := range slices.Sorted(maps.Keys(defaults)) {
+		if _, ok := resources.Requests[name]; ok {
+			continue
+		}
+		if resources.Requests == nil {
+			resources.Requests = corev1.ResourceList{}
+		}
+		if limit, ok := resources.Limits[name]; ok {
+			resources.Requests[name] = limit.DeepCopy()
+		} else {
+			resources.Requests[name] = defaults[name].DeepCopy()
+		}
+	}
+}
+
+// validateWorkload checks the resources and the scheduling of workload. The
+// affinity rules are left to the API server, which rejects the invalid ones
+// when the operator creates the Pods.
+func validateWorkload(workload *demov2.WorkloadSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if resources := workload.Resources; resources != nil {
+		for _, name := range slices.Sorted(maps.Keys(resources.Requests)) {
+			request := resources.Requests[name]
+			reqPath := fldPath.Child("resources", "requests").Key(string(name))
+			if request.Sign() < 0 {
+				allErrs = append(allErrs, field.Invalid(reqPath, request.String(), "must not be negative"))
+			}
+			if limit, ok := resources.Limits[name]; ok && request.Cmp(limit) > 0 {
+				allErrs = append(allErrs, field.Invalid(reqPath, request.String(),
+					"must be less than or equal to the limit "+limit.String()))
+			}
+		}
+		for _, name := range slices.Sorted(maps.Keys(resources.Limits)) {
+			if limit := resources.Limits[name]; limit.Sign() < 0 {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("resources", "limits").Key(string(name)),
+					limit.String(), "must not be negative"))
+			}
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(workload.NodeSelector)) {
+		keyPath := fldPath.Child("nodeSelector").Key(key)
+		for _, msg := range validation.IsQualifiedName(key) {
+			allErrs = append(allErrs, field.Invalid(keyPath, key, msg))
+		}
+		for _, msg := range validation.IsValidLabelValue(workload.NodeSelector[key]) {
+			allErrs = append(allErrs, field.Invalid(keyPath, workload.NodeSelector[key], msg))
+		}
+	}
+
+	for i, toleration := range workload.Tolerations {
+		tolPath := fldPath.Child("tolerations").Index(i)
+		operator := toleration.Operator
+		if operator == "" {
+			operator = corev1.TolerationOpEqual
+		}
+		switch {
+		case !tolerationOperators.Has(operator):
+			allErrs = append(allErrs, field.NotSupported(tolPath.Child("operator"), toleration.Operator,
+				sets.List(tolerationOperators)))
+		case toleration.Key == "" && operator != corev1.TolerationOpExists:
+			allErrs = append(allErrs, field.Invalid(tolPath.Child("operator"), toleration.Operator,
+				"must be Exists when key is empty"))
+		case operator == corev1.TolerationOpExists && toleration.Value != "":
+			allErrs = append(allErrs, field.Invalid(tolPath.Child("value"), toleration.Value,
+				"must be empty when operator is Exists"))
+		}
+		if toleration.Effect != "" && !taintEffects.Has(toleration.Effect) {
+			allErrs = append(allErrs, field.NotSupported(tolPath.Child("effect"), toleration.Effect,
+				sets.List(taintEffects)))
+		}
+	}
+
+	if name := workload.PriorityClassName; name != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(name) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("priorityClassName"), name, msg))
+		}
+	}
+
+	for i, constraint := range workload.TopologySpreadConstraints {
+		tscPath := fldPath.Child("topologySpreadConstraints").Index(i)
+		if constraint.MaxSkew < 1 {
+			allErrs = append(allErrs, field.Invalid(tscPath.Child("maxSkew"), constraint.MaxSkew, "must be at least 1"))
+		}
+		if constraint.TopologyKey == "" {
+			allErrs = append(allErrs, field.Required(tscPath.Child("topologyKey"), ""))
+		}
+		if !unsatisfiableActions.Has(constraint.WhenUnsatisfiable) {
+			allErrs = append(allErrs, field.NotSupported(tscPath.Child("whenUnsatisfiable"),
+				constraint.WhenUnsatisfiable, sets.List(unsatisfiableActions)))
+		}
+	}
+
+	return allErrs
+}